@@ -0,0 +1,226 @@
+// Package agreement renders the agreement letters of a disbursed loan: one
+// for the borrower and one for every investor, each as HTML and as PDF. The
+// letters are filled in from templates, so their wording can be changed
+// without touching the code.
+package agreement
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"math/big"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+)
+
+// Terms is everything the letters are filled in from.
+type Terms struct {
+	Loan     domain.Loan
+	Borrower domain.Borrower
+	// Investments are the loan's investments; an investor who invested more
+	// than once gets a single letter for the total.
+	Investments []domain.Investment
+	// Investors holds the registered investors by email, for their names.
+	Investors   map[string]domain.Investor
+	Schedule    []domain.Installment
+	DisbursedAt time.Time
+}
+
+// Letter is one rendered agreement letter.
+type Letter struct {
+	Party         domain.AgreementParty
+	InvestorEmail string
+	Format        domain.AgreementFormat
+	ContentType   string
+	Data          []byte
+}
+
+// templateNames are the templates a template set must define. The HTML
+// letters are rendered from the .html templates and the PDF letters from the
+// plain text .txt ones.
+var templateNames = []string{"borrower.html", "investor.html", "borrower.txt", "investor.txt"}
+
+//go:embed templates
+var embedded embed.FS
+
+// DefaultTemplates are the templates built into the service.
+var DefaultTemplates = func() fs.FS {
+	sub, err := fs.Sub(embedded, "templates")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}()
+
+var defaultRenderer = func() *Renderer {
+	r, err := NewRenderer(DefaultTemplates)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}()
+
+// Default returns a Renderer using DefaultTemplates.
+func Default() *Renderer {
+	return defaultRenderer
+}
+
+type Renderer struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// NewRenderer parses the templates in fsys, which must define every name in
+// templateNames.
+func NewRenderer(fsys fs.FS) (*Renderer, error) {
+	html, err := htmltemplate.New("").Funcs(funcs).ParseFS(fsys, "*.html")
+	if err != nil {
+		return nil, fmt.Errorf("parse agreement templates: %w", err)
+	}
+	text, err := texttemplate.New("").Funcs(funcs).ParseFS(fsys, "*.txt")
+	if err != nil {
+		return nil, fmt.Errorf("parse agreement templates: %w", err)
+	}
+	for _, name := range templateNames {
+		missing := html.Lookup(name) == nil
+		if strings.HasSuffix(name, ".txt") {
+			missing = text.Lookup(name) == nil
+		}
+		if missing {
+			return nil, fmt.Errorf("agreement template %s is missing", name)
+		}
+	}
+	return &Renderer{html: html, text: text}, nil
+}
+
+var funcs = map[string]any{
+	"money": domain.Money.Format,
+	"date":  func(t time.Time) string { return t.Format("2 January 2006") },
+}
+
+// BorrowerLetter is the data the borrower templates are executed with.
+type BorrowerLetter struct {
+	Terms
+	Number         string
+	TotalInterest  domain.Money
+	TotalRepayment domain.Money
+	InvestorCount  int
+}
+
+// InvestorLetter is the data the investor templates are executed with.
+type InvestorLetter struct {
+	Terms
+	Number   string
+	Investor domain.Investor
+	Amount   domain.Money
+	// Share is the investor's part of the principal in percent, with two
+	// decimals.
+	Share string
+	// ExpectedReturn is what the investor earns if the borrower repays the
+	// schedule in full.
+	ExpectedReturn domain.Money
+}
+
+// Render fills in the borrower's letter and one letter per investor, each in
+// HTML and PDF.
+func (r *Renderer) Render(t Terms) ([]Letter, error) {
+	currency := t.Loan.PrincipalAmount.Currency
+	principal, interest := domain.Zero(currency), domain.Zero(currency)
+	for _, inst := range t.Schedule {
+		principal = principal.Add(inst.PrincipalDue)
+		interest = interest.Add(inst.InterestDue)
+	}
+
+	investments := perInvestor(t.Investments)
+	number := fmt.Sprintf("LA-%06d", t.Loan.ID)
+	letters, err := r.render(domain.AgreementBorrower, "", number, t.DisbursedAt, BorrowerLetter{
+		Terms:          t,
+		Number:         number,
+		TotalInterest:  interest,
+		TotalRepayment: principal.Add(interest),
+		InvestorCount:  len(investments),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(investments) == 0 {
+		return letters, nil
+	}
+
+	// Investors earn their share of the scheduled interest at the loan's
+	// ROI, exactly as repayments are later paid out to them.
+	dist, err := domain.DistributeRepayment(t.Loan, domain.Repayment{
+		Amount:    principal.Add(interest),
+		Principal: principal,
+		Interest:  interest,
+		Fee:       domain.Zero(currency),
+	}, investments)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, inv := range investments {
+		investor, ok := t.Investors[inv.InvestorEmail]
+		if !ok {
+			investor = domain.Investor{Email: inv.InvestorEmail}
+		}
+		number := fmt.Sprintf("LA-%06d-%02d", t.Loan.ID, i+1)
+		l, err := r.render(domain.AgreementInvestor, inv.InvestorEmail, number, t.DisbursedAt, InvestorLetter{
+			Terms:          t,
+			Number:         number,
+			Investor:       investor,
+			Amount:         inv.Amount,
+			Share:          share(inv.Amount, t.Loan.PrincipalAmount),
+			ExpectedReturn: dist.Payouts[i].Return,
+		})
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, l...)
+	}
+	return letters, nil
+}
+
+func (r *Renderer) render(party domain.AgreementParty, email, title string, created time.Time, data any) ([]Letter, error) {
+	name := string(party)
+
+	var html bytes.Buffer
+	if err := r.html.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return nil, fmt.Errorf("render %s agreement: %w", name, err)
+	}
+	var text bytes.Buffer
+	if err := r.text.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return nil, fmt.Errorf("render %s agreement: %w", name, err)
+	}
+
+	return []Letter{
+		{Party: party, InvestorEmail: email, Format: domain.AgreementHTML, ContentType: "text/html; charset=utf-8", Data: html.Bytes()},
+		{Party: party, InvestorEmail: email, Format: domain.AgreementPDF, ContentType: "application/pdf", Data: renderPDF(title, text.String(), created)},
+	}, nil
+}
+
+// perInvestor adds up the investments of each investor, leaving out refunded
+// ones.
+func perInvestor(investments []domain.Investment) []domain.Investment {
+	var active []domain.Investment
+	for _, inv := range investments {
+		if inv.RefundedAt == nil {
+			active = append(active, inv)
+		}
+	}
+	return domain.TotalsByInvestor(active)
+}
+
+// share returns part as a percentage of total with two decimals.
+func share(part, total domain.Money) string {
+	if total.IsZero() {
+		return "0.00"
+	}
+	r := new(big.Rat).Quo(part.Rat(), total.Rat())
+	return r.Mul(r, big.NewRat(100, 1)).FloatString(2)
+}
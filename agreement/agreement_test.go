@@ -0,0 +1,158 @@
+package agreement
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func idr(s string) domain.Money {
+	return domain.MustParseMoney(s, domain.CurrencyIDR)
+}
+
+func testTerms(t *testing.T) Terms {
+	loan := domain.Loan{
+		ID:              42,
+		BorrowerID:      "BRW-1",
+		PrincipalAmount: idr("1200000"),
+		Rate:            12,
+		ROI:             6,
+		TenorMonths:     3,
+		RepaymentMethod: domain.RepaymentFlat,
+	}
+	disbursedAt := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	schedule, err := domain.GenerateSchedule(loan, disbursedAt)
+	require.NoError(t, err)
+
+	return Terms{
+		Loan:     loan,
+		Borrower: domain.Borrower{ID: "BRW-1", Name: "Budi <Santoso>", IdentityNumber: "3174000000000001", Address: "Jl. Sudirman 1, Jakarta"},
+		Investments: []domain.Investment{
+			{ID: 1, LoanID: 42, InvestorEmail: "a@example.com", Amount: idr("600000")},
+			{ID: 2, LoanID: 42, InvestorEmail: "b@example.com", Amount: idr("300000")},
+			{ID: 3, LoanID: 42, InvestorEmail: "a@example.com", Amount: idr("300000")},
+		},
+		Investors: map[string]domain.Investor{
+			"a@example.com": {Email: "a@example.com", Name: "Ani"},
+		},
+		Schedule:    schedule,
+		DisbursedAt: disbursedAt,
+	}
+}
+
+func TestRender(t *testing.T) {
+	letters, err := Default().Render(testTerms(t))
+	require.NoError(t, err)
+	require.Len(t, letters, 6)
+
+	byKey := map[string]Letter{}
+	for _, l := range letters {
+		byKey[string(l.Party)+"/"+l.InvestorEmail+"/"+string(l.Format)] = l
+	}
+
+	borrower := string(byKey["borrower//html"].Data)
+	assert.Contains(t, borrower, "No. LA-000042")
+	assert.Contains(t, borrower, "Budi &lt;Santoso&gt;", "borrower data is escaped")
+	assert.Contains(t, borrower, "IDR 1,200,000.00")
+	assert.Contains(t, borrower, "IDR 36,000.00", "total interest")
+	assert.Contains(t, borrower, "1 October 2025", "last due date")
+	assert.NotContains(t, borrower, "a@example.com", "investors stay private")
+
+	// Ani invested twice and gets one letter for both investments.
+	ani := string(byKey["investor/a@example.com/html"].Data)
+	assert.Contains(t, ani, "Ani, a@example.com")
+	assert.Contains(t, ani, "IDR 900,000.00")
+	assert.Contains(t, ani, "75.00%")
+	assert.Contains(t, ani, "IDR 13,500.00", "half the interest, as ROI is half the rate")
+	assert.NotContains(t, ani, "b@example.com")
+
+	other := string(byKey["investor/b@example.com/html"].Data)
+	assert.Contains(t, other, "25.00%")
+	assert.Contains(t, other, "IDR 4,500.00")
+
+	for _, l := range letters {
+		if l.Format == domain.AgreementPDF {
+			assert.Equal(t, "application/pdf", l.ContentType)
+			assertValidPDF(t, l.Data, 1)
+		}
+	}
+	assert.Contains(t, string(byKey["investor/a@example.com/pdf"].Data), "(  Amount invested        IDR 900,000.00) Tj")
+}
+
+func TestRender_IsDeterministic(t *testing.T) {
+	first, err := Default().Render(testTerms(t))
+	require.NoError(t, err)
+	second, err := Default().Render(testTerms(t))
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestNewRenderer_CustomTemplates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"borrower.html": {Data: []byte("<p>{{.Borrower.Name}} owes {{money .Loan.PrincipalAmount}}</p>")},
+		"investor.html": {Data: []byte("<p>{{.Investor.Email}}</p>")},
+		"borrower.txt":  {Data: []byte("{{.Borrower.Name}}")},
+		"investor.txt":  {Data: []byte("{{.Investor.Email}}")},
+	}
+	r, err := NewRenderer(fsys)
+	require.NoError(t, err)
+
+	letters, err := r.Render(testTerms(t))
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Budi &lt;Santoso&gt; owes IDR 1,200,000.00</p>", string(letters[0].Data))
+
+	delete(fsys, "investor.txt")
+	_, err = NewRenderer(fsys)
+	assert.ErrorContains(t, err, "investor.txt is missing")
+}
+
+func TestRenderPDF_WrapsAndPaginates(t *testing.T) {
+	var text strings.Builder
+	text.WriteString("# A heading (with parentheses)\n")
+	for i := 0; i < 120; i++ {
+		text.WriteString("Line " + strconv.Itoa(i) + "\n")
+	}
+	text.WriteString(strings.Repeat("word ", 40) + "\n")
+	text.WriteString("Café – 100%\n")
+
+	pdf := renderPDF("Title", text.String(), time.Unix(0, 0))
+	assertValidPDF(t, pdf, 3)
+	assert.Contains(t, string(pdf), "/F2 10 Tf\n(A heading \\(with parentheses\\)) Tj")
+	assert.Contains(t, string(pdf), "(Caf\\351 \\226 100%) Tj")
+	assert.Contains(t, string(pdf), "(Page 3 of 3) Tj")
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, []string{"short"}, wrap("short", 10))
+	assert.Equal(t, []string{"  one two", "  three"}, wrap("  one two three", 10))
+	assert.Equal(t, []string{"abcdefghij", "klm"}, wrap("abcdefghijklm", 10))
+}
+
+// assertValidPDF checks the page count and that every cross-reference entry
+// points at the object it names.
+func assertValidPDF(t *testing.T, pdf []byte, pages int) {
+	t.Helper()
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "/Count "+strconv.Itoa(pages)+" >>")
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	require.NotNil(t, m)
+	xref, _ := strconv.Atoi(string(m[1]))
+	require.True(t, bytes.HasPrefix(pdf[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	require.Len(t, entries, 5+2*pages)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		assert.True(t, bytes.HasPrefix(pdf[off:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+	}
+}
@@ -0,0 +1,191 @@
+package agreement
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Page geometry in points: A4 with 2 cm margins. Letters are set in 10 pt
+// Courier, whose characters are all 6 pt wide, so wrapping only needs to
+// count characters.
+const (
+	pageWidth  = 595
+	pageHeight = 842
+	margin     = 57
+	fontSize   = 10
+	leading    = 13
+	lineChars  = (pageWidth - 2*margin) / 6
+	// pageLines leaves the bottom two lines free for the page number.
+	pageLines = (pageHeight-2*margin)/leading - 2
+)
+
+type pdfLine struct {
+	text string
+	bold bool
+}
+
+// layout wraps text to the page width. Lines starting with "# " are
+// headings and are set in bold without the marker.
+func layout(text string) []pdfLine {
+	var lines []pdfLine
+	for _, raw := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		raw = strings.ReplaceAll(strings.TrimRight(raw, " \r"), "\t", "    ")
+		bold := strings.HasPrefix(raw, "# ")
+		if bold {
+			raw = strings.TrimPrefix(raw, "# ")
+		}
+		for _, l := range wrap(raw, lineChars) {
+			lines = append(lines, pdfLine{text: l, bold: bold})
+		}
+	}
+	return lines
+}
+
+// wrap breaks s into lines of at most width characters at spaces,
+// continuing wrapped lines at the original indentation. Words longer than a
+// line are cut.
+func wrap(s string, width int) []string {
+	if utf8.RuneCountInString(s) <= width {
+		return []string{s}
+	}
+	indent := s[:len(s)-len(strings.TrimLeft(s, " "))]
+	if len(indent) > width/2 {
+		indent = ""
+	}
+
+	var (
+		lines []string
+		line  = indent
+	)
+	for _, word := range strings.Fields(s) {
+		for utf8.RuneCountInString(word) > width-len(indent) {
+			if strings.TrimSpace(line) != "" {
+				lines = append(lines, line)
+			}
+			cut := []rune(word)
+			lines = append(lines, indent+string(cut[:width-len(indent)]))
+			word = string(cut[width-len(indent):])
+			line = indent
+		}
+		switch {
+		case strings.TrimSpace(line) == "":
+			line = indent + word
+		case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = indent + word
+		}
+	}
+	return append(lines, line)
+}
+
+// renderPDF typesets text as a plain PDF document using the standard Courier
+// fonts, which every PDF reader has, so nothing needs to be embedded. The
+// output only depends on its arguments.
+func renderPDF(title, text string, created time.Time) []byte {
+	lines := layout(text)
+	var pages [][]pdfLine
+	for len(lines) > pageLines {
+		pages = append(pages, lines[:pageLines])
+		lines = lines[pageLines:]
+	}
+	pages = append(pages, lines)
+
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects 1 to 5 are fixed; each page then adds a page object and its
+	// content stream.
+	const firstPage = 6
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title %s /Producer (loan-service) /CreationDate (D:%s) >>",
+		pdfString(title), created.UTC().Format("20060102150405Z")))
+
+	for i, page := range pages {
+		content := pageContent(page, i+1, len(pages))
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+func pageContent(lines []pdfLine, page, pages int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", fontSize, leading, margin, pageHeight-margin-fontSize)
+	bold := false
+	for _, l := range lines {
+		if l.bold != bold {
+			bold = l.bold
+			font := "/F1"
+			if bold {
+				font = "/F2"
+			}
+			fmt.Fprintf(&b, "%s %d Tf\n", font, fontSize)
+		}
+		fmt.Fprintf(&b, "%s Tj T*\n", pdfString(l.text))
+	}
+	b.WriteString("ET\n")
+
+	footer := fmt.Sprintf("Page %d of %d", page, pages)
+	fmt.Fprintf(&b, "BT\n/F1 8 Tf\n%d %d Td\n%s Tj\nET", pageWidth-margin-len(footer)*5, margin/2, pdfString(footer))
+	return b.String()
+}
+
+// winAnsi maps the typographic characters templates are likely to use onto
+// their WinAnsiEncoding codes. Latin-1 characters keep their code.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// pdfString encodes s as a PDF literal string in WinAnsiEncoding. Characters
+// the encoding lacks become "?".
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case winAnsi[r] != 0:
+			fmt.Fprintf(&b, "\\%03o", winAnsi[r])
+		default:
+			b.WriteByte('?')
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
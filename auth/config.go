@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/martinusiron/loan-service/configs"
+)
+
+// NewFromConfig builds a JWTAuthenticator from the auth section of the
+// config, loading every configured HMAC secret, PEM key and JWKS file.
+func NewFromConfig(cfg configs.AuthConfig) (*JWTAuthenticator, error) {
+	keys := NewKeySet()
+
+	for _, k := range cfg.HMACKeys {
+		secret := os.Getenv(k.SecretEnv)
+		if secret == "" {
+			return nil, fmt.Errorf("hmac key %q: environment variable %s is not set", k.Kid, k.SecretEnv)
+		}
+		keys.AddHMAC(k.Kid, []byte(secret))
+	}
+
+	for _, k := range cfg.RSAKeys {
+		data, err := os.ReadFile(k.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("rsa key %q: %w", k.Kid, err)
+		}
+		pub, err := ParseRSAPublicKeyPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("rsa key %q: %w", k.Kid, err)
+		}
+		keys.AddRSA(k.Kid, pub)
+	}
+
+	if cfg.JWKSFile != "" {
+		data, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: %w", err)
+		}
+		set, err := ParseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: %w", err)
+		}
+		for kid, pub := range set {
+			keys.AddRSA(kid, pub)
+		}
+	}
+
+	if keys.Len() == 0 {
+		return nil, errors.New("no token verification keys configured")
+	}
+
+	leeway := 30 * time.Second
+	if cfg.Leeway != "" {
+		d, err := time.ParseDuration(cfg.Leeway)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid leeway %q", cfg.Leeway)
+		}
+		leeway = d
+	}
+
+	roleMap := make(map[string]Role, len(cfg.RoleMap))
+	for from, to := range cfg.RoleMap {
+		role, err := ParseRole(to)
+		if err != nil {
+			return nil, fmt.Errorf("role_map %q: %w", from, err)
+		}
+		roleMap[from] = role
+	}
+
+	return NewJWTAuthenticator(
+		&Verifier{Keys: keys, Issuer: cfg.Issuer, Audience: cfg.Audience, Leeway: leeway},
+		ClaimsMapper{RoleClaim: cfg.RoleClaim, EmailClaim: cfg.EmailClaim, RoleMap: roleMap},
+	), nil
+}
@@ -0,0 +1,296 @@
+// Package auth authenticates API callers from JSON Web Tokens and maps their
+// claims to the roles the loan service authorizes requests against.
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers SHA-256 for crypto.Hash
+	_ "crypto/sha512" // registers SHA-384 and SHA-512 for crypto.Hash
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+	ErrUnknownKey   = errors.New("no key to verify token")
+)
+
+// algorithms maps the supported JWS "alg" values to their hash. HS* tokens
+// are only ever checked against HMAC secrets and RS* tokens only against RSA
+// public keys, so a token cannot pick the key type it is verified with.
+var algorithms = map[string]crypto.Hash{
+	"HS256": crypto.SHA256,
+	"HS384": crypto.SHA384,
+	"HS512": crypto.SHA512,
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+}
+
+// Claims are the decoded claims of a verified token.
+type Claims map[string]any
+
+// String returns the claim as a string, or "" when it is missing or not a
+// string. Dotted names such as "realm_access.roles" walk nested objects.
+func (c Claims) String(name string) string {
+	s, _ := c.lookup(name).(string)
+	return s
+}
+
+// Strings returns the claim as a list. A single string claim is split on
+// spaces, the way OAuth "scope" claims are encoded.
+func (c Claims) Strings(name string) []string {
+	switch v := c.lookup(name).(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func (c Claims) lookup(name string) any {
+	var cur any = map[string]any(c)
+	for _, part := range strings.Split(name, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[part]
+	}
+	return cur
+}
+
+func (c Claims) time(name string) (time.Time, bool) {
+	v, ok := c[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(v), 0), true
+}
+
+func (c Claims) hasAudience(aud string) bool {
+	switch v := c["aud"].(type) {
+	case string:
+		return v == aud
+	case []any:
+		for _, a := range v {
+			if a == aud {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// KeySet holds the keys tokens may be signed with, indexed by key ID. Tokens
+// without a "kid" header are tried against every key of the matching type.
+type KeySet struct {
+	hmac map[string][]byte
+	rsa  map[string]*rsa.PublicKey
+}
+
+func NewKeySet() *KeySet {
+	return &KeySet{hmac: map[string][]byte{}, rsa: map[string]*rsa.PublicKey{}}
+}
+
+func (ks *KeySet) AddHMAC(kid string, secret []byte) {
+	ks.hmac[kid] = secret
+}
+
+func (ks *KeySet) AddRSA(kid string, key *rsa.PublicKey) {
+	ks.rsa[kid] = key
+}
+
+// Len returns the number of keys in the set.
+func (ks *KeySet) Len() int {
+	return len(ks.hmac) + len(ks.rsa)
+}
+
+// Verifier checks token signatures and the registered time, issuer and
+// audience claims.
+type Verifier struct {
+	Keys *KeySet
+	// Issuer and Audience, when set, must match the "iss" and "aud" claims.
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew between the token issuer and this service.
+	Leeway time.Duration
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// Verify checks the token and returns its claims. Tokens without an "exp"
+// claim are rejected.
+func (v *Verifier) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	hash, ok := algorithms[h.Alg]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, h.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+	if err := v.verifySignature(h, hash, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	if err := v.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *Verifier) verifySignature(h header, hash crypto.Hash, signed, sig []byte) error {
+	tried := 0
+	if strings.HasPrefix(h.Alg, "HS") {
+		for _, secret := range candidates(v.Keys.hmac, h.Kid) {
+			tried++
+			mac := hmac.New(hash.New, secret)
+			mac.Write(signed)
+			if hmac.Equal(mac.Sum(nil), sig) {
+				return nil
+			}
+		}
+	} else {
+		digest := hash.New()
+		digest.Write(signed)
+		sum := digest.Sum(nil)
+		for _, key := range candidates(v.Keys.rsa, h.Kid) {
+			tried++
+			if rsa.VerifyPKCS1v15(key, hash, sum, sig) == nil {
+				return nil
+			}
+		}
+	}
+
+	if tried == 0 {
+		return fmt.Errorf("%w: kid %q", ErrUnknownKey, h.Kid)
+	}
+	return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+}
+
+// candidates returns the key named by kid, or every key when the token does
+// not name one.
+func candidates[K any](keys map[string]K, kid string) []K {
+	if kid != "" {
+		if k, ok := keys[kid]; ok {
+			return []K{k}
+		}
+		return nil
+	}
+	out := make([]K, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, k)
+	}
+	return out
+}
+
+func (v *Verifier) validate(c Claims) error {
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+
+	exp, ok := c.time("exp")
+	if !ok {
+		return fmt.Errorf("%w: missing exp", ErrInvalidToken)
+	}
+	if !now.Before(exp.Add(v.Leeway)) {
+		return ErrTokenExpired
+	}
+	if nbf, ok := c.time("nbf"); ok && now.Add(v.Leeway).Before(nbf) {
+		return fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	}
+	if v.Issuer != "" && c.String("iss") != v.Issuer {
+		return fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if v.Audience != "" && !c.hasAudience(v.Audience) {
+		return fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	return nil
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// Sign issues a token over claims. key is a []byte secret for HS*
+// algorithms and an *rsa.PrivateKey for RS* algorithms. The service itself
+// only verifies tokens; Sign exists for tests and local tooling.
+func Sign(alg, kid string, key any, claims Claims) (string, error) {
+	hash, ok := algorithms[alg]
+	if !ok {
+		return "", fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	hb, err := json.Marshal(header{Alg: alg, Kid: kid, Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	cb, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(hb) + "." + base64.RawURLEncoding.EncodeToString(cb)
+
+	var sig []byte
+	switch k := key.(type) {
+	case []byte:
+		if !strings.HasPrefix(alg, "HS") {
+			return "", fmt.Errorf("%s needs an RSA private key", alg)
+		}
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return "", fmt.Errorf("%s needs an HMAC secret", alg)
+		}
+		digest := hash.New()
+		digest.Write([]byte(signed))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest.Sum(nil))
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unsupported key type %T", key)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
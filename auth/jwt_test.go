@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+func claims(extra Claims) Claims {
+	c := Claims{"sub": "BR00000001", "roles": []string{"borrower"}, "exp": testNow.Add(time.Hour).Unix()}
+	for k, v := range extra {
+		c[k] = v
+	}
+	return c
+}
+
+func hmacVerifier(secret string) *Verifier {
+	keys := NewKeySet()
+	keys.AddHMAC("hs", []byte(secret))
+	return &Verifier{Keys: keys, Now: func() time.Time { return testNow }}
+}
+
+func TestVerify_HMAC(t *testing.T) {
+	v := hmacVerifier("secret")
+
+	for _, alg := range []string{"HS256", "HS384", "HS512"} {
+		tok, err := Sign(alg, "hs", []byte("secret"), claims(nil))
+		require.NoError(t, err)
+		c, err := v.Verify(tok)
+		require.NoError(t, err, alg)
+		assert.Equal(t, "BR00000001", c.String("sub"))
+	}
+
+	tok, _ := Sign("HS256", "hs", []byte("other"), claims(nil))
+	_, err := v.Verify(tok)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	tok, _ = Sign("HS256", "unknown", []byte("secret"), claims(nil))
+	_, err = v.Verify(tok)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestVerify_RSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keys := NewKeySet()
+	keys.AddRSA("rs", &priv.PublicKey)
+	v := &Verifier{Keys: keys, Now: func() time.Time { return testNow }}
+
+	tok, err := Sign("RS256", "rs", priv, claims(nil))
+	require.NoError(t, err)
+	_, err = v.Verify(tok)
+	assert.NoError(t, err)
+
+	// Tokens without a kid are tried against every key of their type.
+	tok, _ = Sign("RS512", "", priv, claims(nil))
+	_, err = v.Verify(tok)
+	assert.NoError(t, err)
+
+	// An HS token signed with the public key's bytes must not pass.
+	pub := x509.MarshalPKCS1PublicKey(&priv.PublicKey)
+	tok, _ = Sign("HS256", "rs", pub, claims(nil))
+	_, err = v.Verify(tok)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestVerify_RejectsUnsignedAndMalformedTokens(t *testing.T) {
+	v := hmacVerifier("secret")
+	enc := base64.RawURLEncoding.EncodeToString
+
+	none := enc([]byte(`{"alg":"none"}`)) + "." + enc([]byte(`{"sub":"x","exp":9999999999}`)) + "."
+	for _, tok := range []string{"", "abc", "a.b", none} {
+		_, err := v.Verify(tok)
+		assert.ErrorIs(t, err, ErrInvalidToken, tok)
+	}
+}
+
+func TestVerify_RegisteredClaims(t *testing.T) {
+	v := hmacVerifier("secret")
+	v.Issuer = "idp"
+	v.Audience = "loan-service"
+	v.Leeway = time.Minute
+	valid := Claims{"iss": "idp", "aud": []string{"other", "loan-service"}}
+
+	cases := []struct {
+		name   string
+		claims Claims
+		err    error
+	}{
+		{"valid", claims(valid), nil},
+		{"expired", claims(Claims{"iss": "idp", "aud": "loan-service", "exp": testNow.Add(-2 * time.Minute).Unix()}), ErrTokenExpired},
+		{"expired within leeway", claims(Claims{"iss": "idp", "aud": "loan-service", "exp": testNow.Add(-30 * time.Second).Unix()}), nil},
+		{"missing exp", Claims{"sub": "x", "iss": "idp", "aud": "loan-service"}, ErrInvalidToken},
+		{"not yet valid", claims(Claims{"iss": "idp", "aud": "loan-service", "nbf": testNow.Add(time.Hour).Unix()}), ErrInvalidToken},
+		{"wrong issuer", claims(Claims{"iss": "evil", "aud": "loan-service"}), ErrInvalidToken},
+		{"wrong audience", claims(Claims{"iss": "idp", "aud": "other"}), ErrInvalidToken},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tok, err := Sign("HS256", "hs", []byte("secret"), tc.claims)
+			require.NoError(t, err)
+			_, err = v.Verify(tok)
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestParseRSAPublicKeyPEM(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkix, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+	for _, block := range []*pem.Block{
+		{Type: "PUBLIC KEY", Bytes: pkix},
+		{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&priv.PublicKey)},
+	} {
+		key, err := ParseRSAPublicKeyPEM(pem.EncodeToMemory(block))
+		require.NoError(t, err, block.Type)
+		assert.True(t, key.Equal(&priv.PublicKey))
+	}
+
+	_, err = ParseRSAPublicKeyPEM([]byte("not pem"))
+	assert.Error(t, err)
+}
+
+func TestParseJWKS(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	n := base64.RawURLEncoding.EncodeToString(priv.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(priv.E)).Bytes())
+
+	data := fmt.Sprintf(`{"keys":[
+		{"kty":"RSA","use":"sig","kid":"k1","n":%q,"e":%q},
+		{"kty":"RSA","use":"enc","kid":"k2","n":%q,"e":%q},
+		{"kty":"EC","kid":"k3","crv":"P-256"}
+	]}`, n, e, n, e)
+	keys, err := ParseJWKS([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, keys["k1"].Equal(&priv.PublicKey))
+
+	_, err = ParseJWKS([]byte(`{"keys":[{"kty":"RSA","kid":"bad","n":"!!","e":"AQAB"}]}`))
+	assert.Error(t, err)
+}
+
+func TestClaimsMapper(t *testing.T) {
+	m := ClaimsMapper{RoleClaim: "realm_access.roles", RoleMap: map[string]Role{"loan-approver": RoleFieldValidator}}
+
+	p, err := m.Principal(Claims{
+		"sub":          "EMP001",
+		"email":        "emp@example.com",
+		"realm_access": map[string]any{"roles": []any{"loan-approver", "admin", "offline_access", "admin"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Role{RoleFieldValidator, RoleAdmin}, p.Roles)
+	assert.True(t, p.Is("EMP001"))
+	assert.True(t, p.Is("EMP@example.com"))
+	assert.False(t, p.Is(""))
+
+	_, err = m.Principal(Claims{"sub": "EMP001", "realm_access": map[string]any{"roles": []any{"offline_access"}}})
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	p, err = ClaimsMapper{}.Principal(Claims{"email": "inv@example.com", "roles": "investor borrower"})
+	require.NoError(t, err)
+	assert.Equal(t, []Role{RoleInvestor, RoleBorrower}, p.Roles)
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	a := NewJWTAuthenticator(hmacVerifier("secret"), ClaimsMapper{})
+	tok, _ := Sign("HS256", "hs", []byte("secret"), claims(nil))
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	_, err := a.Authenticate(req)
+	assert.ErrorIs(t, err, ErrMissingToken)
+
+	req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	_, err = a.Authenticate(req)
+	assert.ErrorIs(t, err, ErrMissingToken)
+
+	req.Header.Set("Authorization", "Bearer "+tok)
+	p, err := a.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, "BR00000001", p.Subject)
+	assert.True(t, p.HasRole(RoleBorrower))
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var ErrMissingToken = errors.New("missing bearer token")
+
+type Role string
+
+const (
+	RoleBorrower       Role = "borrower"
+	RoleInvestor       Role = "investor"
+	RoleFieldValidator Role = "field_validator"
+	RoleFieldOfficer   Role = "field_officer"
+	RoleAdmin          Role = "admin"
+)
+
+var knownRoles = map[Role]bool{
+	RoleBorrower:       true,
+	RoleInvestor:       true,
+	RoleFieldValidator: true,
+	RoleFieldOfficer:   true,
+	RoleAdmin:          true,
+}
+
+func ParseRole(s string) (Role, error) {
+	r := Role(s)
+	if !knownRoles[r] {
+		return "", fmt.Errorf("unknown role %q", s)
+	}
+	return r, nil
+}
+
+// Principal is the authenticated caller. Subject is the borrower ID for
+// borrowers and the employee ID for staff; investors are identified by
+// Email.
+type Principal struct {
+	Subject string
+	Email   string
+	Roles   []Role
+}
+
+// HasRole reports whether the principal holds any of roles. A nil
+// principal holds none.
+func (p *Principal) HasRole(roles ...Role) bool {
+	if p == nil {
+		return false
+	}
+	for _, have := range p.Roles {
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Is reports whether id names the principal, by subject or e-mail address.
+func (p *Principal) Is(id string) bool {
+	if p == nil || id == "" {
+		return false
+	}
+	return id == p.Subject || strings.EqualFold(id, p.Email)
+}
+
+// Authenticator identifies the caller of a request. Implementations return
+// ErrMissingToken when the request carries no credentials at all.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// ClaimsMapper turns verified token claims into a Principal.
+type ClaimsMapper struct {
+	// RoleClaim names the claim holding the caller's roles. Dotted names
+	// reach into nested objects, e.g. "realm_access.roles". Defaults to
+	// "roles".
+	RoleClaim string
+	// EmailClaim names the claim holding the caller's e-mail address.
+	// Defaults to "email".
+	EmailClaim string
+	// RoleMap translates role names used by the token issuer to service
+	// roles. Names that are neither mapped nor a service role are ignored.
+	RoleMap map[string]Role
+}
+
+func (m ClaimsMapper) Principal(c Claims) (*Principal, error) {
+	roleClaim, emailClaim := m.RoleClaim, m.EmailClaim
+	if roleClaim == "" {
+		roleClaim = "roles"
+	}
+	if emailClaim == "" {
+		emailClaim = "email"
+	}
+
+	p := &Principal{Subject: c.String("sub"), Email: c.String(emailClaim)}
+	seen := map[Role]bool{}
+	for _, name := range c.Strings(roleClaim) {
+		role, ok := m.RoleMap[name]
+		if !ok {
+			role = Role(name)
+		}
+		if knownRoles[role] && !seen[role] {
+			seen[role] = true
+			p.Roles = append(p.Roles, role)
+		}
+	}
+
+	if p.Subject == "" && p.Email == "" {
+		return nil, fmt.Errorf("%w: token names no subject", ErrInvalidToken)
+	}
+	if len(p.Roles) == 0 {
+		return nil, fmt.Errorf("%w: token grants no known role", ErrInvalidToken)
+	}
+	return p, nil
+}
+
+// JWTAuthenticator authenticates requests carrying an
+// "Authorization: Bearer <jwt>" header.
+type JWTAuthenticator struct {
+	Verifier *Verifier
+	Mapper   ClaimsMapper
+}
+
+func NewJWTAuthenticator(v *Verifier, m ClaimsMapper) *JWTAuthenticator {
+	return &JWTAuthenticator{Verifier: v, Mapper: m}
+}
+
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return nil, ErrMissingToken
+	}
+
+	claims, err := a.Verifier.Verify(strings.TrimSpace(token))
+	if err != nil {
+		return nil, err
+	}
+	return a.Mapper.Principal(claims)
+}
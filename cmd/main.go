@@ -1,14 +1,25 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"os"
+	"time"
 
+	"github.com/martinusiron/loan-service/agreement"
+	"github.com/martinusiron/loan-service/auth"
 	"github.com/martinusiron/loan-service/configs"
 	"github.com/martinusiron/loan-service/delivery/http"
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/events"
+	"github.com/martinusiron/loan-service/jobs"
+	"github.com/martinusiron/loan-service/kafka"
+	"github.com/martinusiron/loan-service/notify"
 	"github.com/martinusiron/loan-service/repository/postgres"
+	"github.com/martinusiron/loan-service/storage"
 	"github.com/martinusiron/loan-service/usecase"
+	"github.com/martinusiron/loan-service/webhook"
 
 	_ "github.com/lib/pq"
 	_ "github.com/martinusiron/loan-service/docs" // Swagger docs
@@ -16,11 +27,15 @@ import (
 
 // @title Amartha Loan Service API
 // @version 1.0
-// @description RESTful API to simulate loan lifecycle (proposed → approved → invested → disbursed).
+// @description RESTful API to simulate loan lifecycle (proposed → approved → invested → disbursed, or proposed → rejected).
 // @contact.name Martinus Iron Sijabat
 // @contact.email your@email.com
 // @host localhost:8080
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT issued by the identity provider, sent as "Bearer <token>".
 func main() {
 	cfg := configs.Load()
 
@@ -32,11 +47,148 @@ func main() {
 
 	loanRepo := postgres.NewLoanRepo(db)
 	approvalRepo := postgres.NewApprovalRepo(db)
+	rejectionRepo := postgres.NewRejectionRepo(db)
 	investRepo := postgres.NewInvestmentRepo(db)
+	historyRepo := postgres.NewLoanHistoryRepo(db)
+	scheduleRepo := postgres.NewScheduleRepo(db)
+	repaymentRepo := postgres.NewRepaymentRepo(db)
+	ledgerRepo := postgres.NewLedgerRepo(db)
+	walletRepo := postgres.NewWalletRepo(db)
+	borrowerRepo := postgres.NewBorrowerRepo(db)
+	investorRepo := postgres.NewInvestorRepo(db)
+	employeeRepo := postgres.NewEmployeeRepo(db)
+	agreementRepo := postgres.NewAgreementRepo(db)
+	outboxRepo := postgres.NewOutboxRepo(db)
+	webhookRepo := postgres.NewWebhookRepo(db)
+	webhookDeliveryRepo := postgres.NewWebhookDeliveryRepo(db)
 
-	uc := usecase.NewLoanUsecase(loanRepo, approvalRepo, investRepo, db)
+	blobs, err := storage.NewFromConfig(cfg.Storage)
+	if err != nil {
+		log.Fatalf("invalid storage config: %v", err)
+	}
+
+	notifier, err := notify.NewFromConfig(cfg.Notifications)
+	if err != nil {
+		log.Fatalf("invalid notifications config: %v", err)
+	}
+
+	webhookTimeout := 10 * time.Second
+	if cfg.Webhooks.Timeout != "" {
+		webhookTimeout, err = time.ParseDuration(cfg.Webhooks.Timeout)
+		if err != nil || webhookTimeout <= 0 {
+			log.Fatalf("invalid webhook timeout: %q", cfg.Webhooks.Timeout)
+		}
+	}
+	webhookUC := usecase.NewWebhookUsecase(webhookRepo, webhookDeliveryRepo, outboxRepo, webhook.NewSender(webhookTimeout), db)
+
+	// Modules react to loan lifecycle events by subscribing here rather
+	// than being called from LoanUsecase.
+	bus := events.NewBus()
+	bus.Subscribe(usecase.NewInvestorNotifier(outboxRepo).Handle, usecase.InvestorNotifierEvents...)
+	bus.Subscribe(webhookUC.HandleEvent)
+	// Loan events are streamed to Kafka only when brokers are configured.
+	producer, err := kafka.NewFromConfig(cfg.Kafka)
+	if err != nil {
+		log.Fatalf("invalid kafka config: %v", err)
+	}
+	if producer != nil {
+		defer producer.Close()
+		bus.Subscribe(usecase.NewEventStreamer(outboxRepo).Handle)
+	}
+	bus.SubscribeAsync(events.LogHandler, 1000)
+
+	waterfall, err := domain.ParseWaterfall(cfg.Repayment.Waterfall)
+	if err != nil {
+		log.Fatalf("invalid repayment waterfall: %v", err)
+	}
+
+	uc := usecase.NewLoanUsecase(loanRepo, approvalRepo, rejectionRepo, investRepo, historyRepo, scheduleRepo, ledgerRepo, walletRepo, borrowerRepo, investorRepo, employeeRepo, agreementRepo, blobs, bus, db)
+	if cfg.Funding.DeadlineDays > 0 {
+		uc.FundingPeriod = time.Duration(cfg.Funding.DeadlineDays) * 24 * time.Hour
+	}
+	if cfg.Storage.MaxPictureProofBytes > 0 {
+		uc.MaxPictureProofSize = cfg.Storage.MaxPictureProofBytes
+	}
+	if cfg.Agreements.TemplateDir != "" {
+		uc.Letters, err = agreement.NewRenderer(os.DirFS(cfg.Agreements.TemplateDir))
+		if err != nil {
+			log.Fatalf("invalid agreement templates: %v", err)
+		}
+	}
+	uc.AgreementBaseURL = cfg.Agreements.BaseURL
+	walletUC := usecase.NewWalletUsecase(walletRepo, ledgerRepo, db)
+	borrowerUC := usecase.NewBorrowerUsecase(borrowerRepo)
+	investorUC := usecase.NewInvestorUsecase(investorRepo, db)
+	employeeUC := usecase.NewEmployeeUsecase(employeeRepo)
+	repaymentUC := usecase.NewRepaymentUsecase(loanRepo, scheduleRepo, repaymentRepo, investRepo, historyRepo, ledgerRepo, walletRepo, waterfall, bus, db)
+
+	idempotencyRepo := postgres.NewIdempotencyRepo(db)
+
+	downloadTTL := 15 * time.Minute
+	if cfg.Storage.DownloadURLTTL != "" {
+		downloadTTL, err = time.ParseDuration(cfg.Storage.DownloadURLTTL)
+		if err != nil || downloadTTL <= 0 {
+			log.Fatalf("invalid download URL TTL: %q", cfg.Storage.DownloadURLTTL)
+		}
+	}
+	urlSecret := os.Getenv(cfg.Storage.URLSecretEnv)
+	if urlSecret == "" {
+		log.Fatalf("download URL secret is not set (%s)", cfg.Storage.URLSecretEnv)
+	}
+	fileUC := usecase.NewFileUsecase(blobs, storage.NewURLSigner([]byte(urlSecret), http.FileRoute, downloadTTL))
+
+	expiryInterval := time.Minute
+	if cfg.Funding.ExpiryCheckInterval != "" {
+		expiryInterval, err = time.ParseDuration(cfg.Funding.ExpiryCheckInterval)
+		if err != nil || expiryInterval <= 0 {
+			log.Fatalf("invalid funding expiry check interval: %q", cfg.Funding.ExpiryCheckInterval)
+		}
+	}
+	go jobs.RunFundingExpiry(context.Background(), uc, expiryInterval)
+
+	dispatcher := usecase.NewOutboxDispatcher(outboxRepo, db)
+	dispatcher.Register(domain.TopicNotification, usecase.NotificationHandler(notifier))
+	if producer != nil {
+		dispatcher.Register(domain.TopicEvent, usecase.EventStreamHandler(producer))
+	}
+	if cfg.Outbox.BatchSize > 0 {
+		dispatcher.BatchSize = cfg.Outbox.BatchSize
+	}
+	if cfg.Outbox.MaxAttempts > 0 {
+		dispatcher.MaxAttempts = cfg.Outbox.MaxAttempts
+	}
+	for _, d := range []struct {
+		value string
+		into  *time.Duration
+	}{
+		{cfg.Outbox.RetryInitialBackoff, &dispatcher.InitialBackoff},
+		{cfg.Outbox.RetryMaxBackoff, &dispatcher.MaxBackoff},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if *d.into, err = time.ParseDuration(d.value); err != nil || *d.into <= 0 {
+			log.Fatalf("invalid outbox retry backoff: %q", d.value)
+		}
+	}
+	// Registered once MaxAttempts is final, so the delivery log marks
+	// deliveries failed when the dispatcher gives up on them.
+	dispatcher.Register(domain.TopicWebhook, webhookUC.DeliveryHandler(dispatcher.MaxAttempts))
+	pollInterval := time.Second
+	if cfg.Outbox.PollInterval != "" {
+		pollInterval, err = time.ParseDuration(cfg.Outbox.PollInterval)
+		if err != nil || pollInterval <= 0 {
+			log.Fatalf("invalid outbox poll interval: %q", cfg.Outbox.PollInterval)
+		}
+	}
+	go jobs.RunOutboxDispatcher(context.Background(), dispatcher, pollInterval)
+
+	authn, err := auth.NewFromConfig(cfg.Auth)
+	if err != nil {
+		log.Fatalf("invalid auth config: %v", err)
+	}
 
-	router := http.InitRouter(uc)
+	router := http.InitRouter(authn, uc, repaymentUC, walletUC, borrowerUC, investorUC, employeeUC, fileUC, webhookUC, idempotencyRepo)
 
 	port := os.Getenv("PORT")
 	if port == "" {
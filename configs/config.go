@@ -8,7 +8,165 @@ import (
 )
 
 type Config struct {
-	DB_URL string `yaml:"db_url"`
+	DB_URL     string           `yaml:"db_url"`
+	Repayment  RepaymentConfig  `yaml:"repayment"`
+	Funding    FundingConfig    `yaml:"funding"`
+	Auth       AuthConfig       `yaml:"auth"`
+	Storage    StorageConfig    `yaml:"storage"`
+	Agreements AgreementsConfig `yaml:"agreements"`
+
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Outbox        OutboxConfig        `yaml:"outbox"`
+	Webhooks      WebhooksConfig      `yaml:"webhooks"`
+	Kafka         KafkaConfig         `yaml:"kafka"`
+}
+
+type RepaymentConfig struct {
+	// Waterfall is the order in which a payment settles each instalment's
+	// components. Defaults to fees, interest, principal when empty.
+	Waterfall []string `yaml:"waterfall"`
+}
+
+type FundingConfig struct {
+	// DeadlineDays is how long an approved loan stays open for investment
+	// when the approval does not set a deadline. Defaults to 30.
+	DeadlineDays int `yaml:"deadline_days"`
+	// ExpiryCheckInterval is how often overdue loans are expired, as a Go
+	// duration string. Defaults to 1m.
+	ExpiryCheckInterval string `yaml:"expiry_check_interval"`
+}
+
+type AuthConfig struct {
+	// Issuer and Audience, when set, must match the tokens' "iss" and "aud"
+	// claims.
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// Leeway tolerates clock skew on "exp" and "nbf", as a Go duration
+	// string. Defaults to 30s.
+	Leeway string `yaml:"leeway"`
+	// RoleClaim and EmailClaim name the claims holding the caller's roles
+	// and e-mail address. Default to "roles" and "email".
+	RoleClaim  string `yaml:"role_claim"`
+	EmailClaim string `yaml:"email_claim"`
+	// RoleMap translates the issuer's role names to service roles.
+	RoleMap map[string]string `yaml:"role_map"`
+	// HMACKeys verify HS256/384/512 tokens.
+	HMACKeys []HMACKeyConfig `yaml:"hmac_keys"`
+	// RSAKeys verify RS256/384/512 tokens.
+	RSAKeys []RSAKeyConfig `yaml:"rsa_keys"`
+	// JWKSFile is a local JSON Web Key Set whose RSA keys verify RS* tokens
+	// by "kid".
+	JWKSFile string `yaml:"jwks_file"`
+}
+
+type HMACKeyConfig struct {
+	Kid string `yaml:"kid"`
+	// SecretEnv names the environment variable holding the shared secret,
+	// so the secret itself stays out of the config file.
+	SecretEnv string `yaml:"secret_env"`
+}
+
+type RSAKeyConfig struct {
+	Kid string `yaml:"kid"`
+	// PublicKeyFile is a PEM encoded RSA public key or certificate.
+	PublicKeyFile string `yaml:"public_key_file"`
+}
+
+type StorageConfig struct {
+	// Driver selects the blob store: "local" (default) or "s3".
+	Driver   string   `yaml:"driver"`
+	LocalDir string   `yaml:"local_dir"`
+	S3       S3Config `yaml:"s3"`
+	// MaxPictureProofBytes caps picture proof uploads. Defaults to 5 MiB.
+	MaxPictureProofBytes int64 `yaml:"max_picture_proof_bytes"`
+	// DownloadURLTTL is how long signed download links stay valid, as a Go
+	// duration string. Defaults to 15m.
+	DownloadURLTTL string `yaml:"download_url_ttl"`
+	// URLSecretEnv names the environment variable holding the key download
+	// links are signed with.
+	URLSecretEnv string `yaml:"url_secret_env"`
+}
+
+type AgreementsConfig struct {
+	// TemplateDir holds borrower.html, investor.html, borrower.txt and
+	// investor.txt to render agreement letters with instead of the built-in
+	// templates.
+	TemplateDir string `yaml:"template_dir"`
+	// BaseURL is the service's public address, put in front of the
+	// agreement letter link stored on disbursed loans.
+	BaseURL string `yaml:"base_url"`
+}
+
+type NotificationsConfig struct {
+	// Driver selects how e-mails are sent: "log" (default) only logs them,
+	// "smtp" sends them through the SMTP server.
+	Driver string     `yaml:"driver"`
+	From   string     `yaml:"from"`
+	SMTP   SMTPConfig `yaml:"smtp"`
+	// TemplateDir holds one <kind>.txt template per notification kind to
+	// render messages with instead of the built-in templates.
+	TemplateDir string `yaml:"template_dir"`
+}
+
+type OutboxConfig struct {
+	// PollInterval is how often the dispatcher looks for due messages, as a
+	// Go duration string. Defaults to 1s.
+	PollInterval string `yaml:"poll_interval"`
+	// BatchSize caps how many messages are handled per poll. Defaults to 100.
+	BatchSize int `yaml:"batch_size"`
+	// MaxAttempts is how often a message is tried before it is
+	// dead-lettered. Defaults to 10.
+	MaxAttempts int `yaml:"max_attempts"`
+	// RetryInitialBackoff and RetryMaxBackoff bound the wait between
+	// attempts, as Go duration strings. Default to 5s and 1h.
+	RetryInitialBackoff string `yaml:"retry_initial_backoff"`
+	RetryMaxBackoff     string `yaml:"retry_max_backoff"`
+}
+
+type WebhooksConfig struct {
+	// Timeout bounds one delivery attempt, as a Go duration string. Defaults
+	// to 10s. Failed deliveries are retried by the outbox dispatcher.
+	Timeout string `yaml:"timeout"`
+}
+
+type KafkaConfig struct {
+	// Brokers are the host:port addresses of the brokers to bootstrap from.
+	// Loan events are only streamed when at least one is set.
+	Brokers []string `yaml:"brokers"`
+	// Topic receives the events, keyed by loan ID. Defaults to "loan-events".
+	Topic    string `yaml:"topic"`
+	ClientID string `yaml:"client_id"`
+	// Serialization is "json" (default) or "protobuf".
+	Serialization string `yaml:"serialization"`
+	// Acks is "all" (default) to wait for every in-sync replica or "leader".
+	Acks string `yaml:"acks"`
+	// Timeout bounds connecting and every request, as a Go duration string.
+	// Defaults to 10s. Failed events are retried by the outbox dispatcher.
+	Timeout string `yaml:"timeout"`
+}
+
+type SMTPConfig struct {
+	// Addr is the server's host:port.
+	Addr string `yaml:"addr"`
+	// TLS is "" to use STARTTLS when offered, "starttls" to require it,
+	// "tls" for implicit TLS or "none".
+	TLS string `yaml:"tls"`
+	// UsernameEnv and PasswordEnv name the environment variables holding
+	// the credentials; no authentication is attempted when the username is
+	// empty.
+	UsernameEnv string `yaml:"username_env"`
+	PasswordEnv string `yaml:"password_env"`
+}
+
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	PathStyle bool   `yaml:"path_style"`
+	// AccessKeyEnv and SecretKeyEnv name the environment variables holding
+	// the credentials.
+	AccessKeyEnv string `yaml:"access_key_env"`
+	SecretKeyEnv string `yaml:"secret_key_env"`
 }
 
 func Load() Config {
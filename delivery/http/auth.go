@@ -0,0 +1,70 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/martinusiron/loan-service/auth"
+)
+
+const principalKey = "principal"
+
+var (
+	errUnauthenticated = errors.New("authentication required")
+	errForbidden       = errors.New("not allowed to perform this request")
+)
+
+// Authenticate rejects requests the authenticator cannot identify with 401
+// and makes the caller available to later handlers through principalFrom.
+func Authenticate(a auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, err := a.Authenticate(c.Request)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="loan-service"`)
+			if errors.Is(err, auth.ErrMissingToken) {
+				errorResponse(c, http.StatusUnauthorized, errUnauthenticated)
+			} else {
+				errorResponse(c, http.StatusUnauthorized, err)
+			}
+			c.Abort()
+			return
+		}
+		c.Set(principalKey, p)
+		c.Next()
+	}
+}
+
+// RequireRoles lets the request through only when the caller holds one of
+// roles, and rejects it with 403 otherwise.
+func RequireRoles(roles ...auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !principalFrom(c).HasRole(roles...) {
+			errorResponse(c, http.StatusForbidden, errForbidden)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func principalFrom(c *gin.Context) *auth.Principal {
+	v, ok := c.Get(principalKey)
+	if !ok {
+		return nil
+	}
+	p, _ := v.(*auth.Principal)
+	return p
+}
+
+// actingAs reports whether the caller may act as id: admins may act as
+// anyone, everyone else only as themselves. It writes a 403 response when
+// they may not.
+func actingAs(c *gin.Context, id string) bool {
+	p := principalFrom(c)
+	if p.HasRole(auth.RoleAdmin) || p.Is(id) {
+		return true
+	}
+	errorResponse(c, http.StatusForbidden, errForbidden)
+	return false
+}
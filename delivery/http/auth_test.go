@@ -0,0 +1,212 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/martinusiron/loan-service/auth"
+	"github.com/martinusiron/loan-service/domain"
+	mockRepo "github.com/martinusiron/loan-service/mocks"
+	"github.com/martinusiron/loan-service/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// staticAuthenticator authenticates every request as the principal named by
+// its X-Test-User header.
+type staticAuthenticator map[string]*auth.Principal
+
+func (a staticAuthenticator) Authenticate(r *http.Request) (*auth.Principal, error) {
+	p, ok := a[r.Header.Get("X-Test-User")]
+	if !ok {
+		return nil, auth.ErrMissingToken
+	}
+	return p, nil
+}
+
+var testUsers = staticAuthenticator{
+	"borrower":  {Subject: "BR00000001", Roles: []auth.Role{auth.RoleBorrower}},
+	"investor":  {Email: "inv@example.com", Roles: []auth.Role{auth.RoleInvestor}},
+	"validator": {Subject: "EMP001", Roles: []auth.Role{auth.RoleFieldValidator}},
+	"admin":     {Subject: "EMP900", Roles: []auth.Role{auth.RoleAdmin}},
+}
+
+func newAuthTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	g := r.Group("/v1", Authenticate(testUsers))
+	g.POST("/loans/:id/approve", RequireRoles(auth.RoleAdmin, auth.RoleFieldValidator), func(c *gin.Context) {
+		if !actingAs(c, c.Query("employee_id")) {
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	g.POST("/loans/:id/invest", RequireRoles(auth.RoleInvestor), Idempotency(newMemoryIdempotencyRepo()), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"investor": principalFrom(c).Email})
+	})
+	return r
+}
+
+func doAs(r http.Handler, user, path, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"amount":100}`))
+	if user != "" {
+		req.Header.Set("X-Test-User", user)
+	}
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthenticate_RejectsAnonymousRequests(t *testing.T) {
+	w := doAs(newAuthTestRouter(), "", "/v1/loans/1/approve?employee_id=EMP001", "")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+}
+
+func TestRequireRoles(t *testing.T) {
+	r := newAuthTestRouter()
+
+	assert.Equal(t, http.StatusForbidden, doAs(r, "borrower", "/v1/loans/1/approve?employee_id=BR00000001", "").Code)
+	assert.Equal(t, http.StatusForbidden, doAs(r, "admin", "/v1/loans/1/invest", "").Code)
+	assert.Equal(t, http.StatusOK, doAs(r, "validator", "/v1/loans/1/approve?employee_id=EMP001", "").Code)
+}
+
+func TestActingAs(t *testing.T) {
+	r := newAuthTestRouter()
+
+	assert.Equal(t, http.StatusForbidden, doAs(r, "validator", "/v1/loans/1/approve?employee_id=EMP002", "").Code)
+	// Admins may act on behalf of any employee.
+	assert.Equal(t, http.StatusOK, doAs(r, "admin", "/v1/loans/1/approve?employee_id=EMP002", "").Code)
+}
+
+func TestIdempotency_KeyIsScopedToCaller(t *testing.T) {
+	testUsers["other-investor"] = &auth.Principal{Email: "other@example.com", Roles: []auth.Role{auth.RoleInvestor}}
+	defer delete(testUsers, "other-investor")
+	r := newAuthTestRouter()
+
+	first := doAs(r, "investor", "/v1/loans/1/invest", "key-1")
+	assert.Equal(t, http.StatusOK, first.Code)
+
+	// Another caller picking the same key gets their own request run, not
+	// the first caller's response or a conflict.
+	w := doAs(r, "other-investor", "/v1/loans/1/invest", "key-1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+	assert.Contains(t, w.Body.String(), "other@example.com")
+
+	// Each caller's retry still replays their own response.
+	w = doAs(r, "investor", "/v1/loans/1/invest", "key-1")
+	assert.Equal(t, "true", w.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, first.Body.String(), w.Body.String())
+}
+
+// newLoanReadTestRouter serves the real routes over loan 1, which belongs to
+// BR00000002, loan 2, which belongs to the "borrower" test user, loan 3,
+// which is open for funding, and loan 4, which the "investor" test user has
+// invested in.
+func newLoanReadTestRouter(loanRepo *mockRepo.LoanRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	loanRepo.On("GetLoanByID", mock.Anything, 1).Return(&domain.Loan{ID: 1, BorrowerID: "BR00000002", Status: domain.StatusInvested}, nil).Maybe()
+	loanRepo.On("GetLoanByID", mock.Anything, 2).Return(&domain.Loan{ID: 2, BorrowerID: "BR00000001"}, nil).Maybe()
+	loanRepo.On("GetLoanByID", mock.Anything, 3).Return(&domain.Loan{ID: 3, BorrowerID: "BR00000002", Status: domain.StatusApproved}, nil).Maybe()
+	loanRepo.On("GetLoanByID", mock.Anything, 4).Return(&domain.Loan{ID: 4, BorrowerID: "BR00000002", Status: domain.StatusDisbursed}, nil).Maybe()
+
+	investRepo := new(mockRepo.InvestmentRepository)
+	investRepo.On("GetInvestorsByLoan", mock.Anything, 1).Return([]domain.Investment{{LoanID: 1, InvestorEmail: "other@example.com"}}, nil).Maybe()
+	investRepo.On("GetInvestorsByLoan", mock.Anything, 4).Return([]domain.Investment{{LoanID: 4, InvestorEmail: "INV@example.com"}}, nil).Maybe()
+
+	// Agreement letters are never reached by these tests: the mock panics
+	// if a caller gets past the access checks.
+	agreementRepo := new(mockRepo.AgreementRepository)
+
+	uc := usecase.NewLoanUsecase(loanRepo, nil, nil, investRepo, nil, nil, nil, nil, nil, nil, nil, agreementRepo, nil, nil, nil)
+	r := gin.New()
+	NewHandler(r, testUsers, uc, nil, nil, nil, nil, nil, nil, nil, newMemoryIdempotencyRepo())
+	return r
+}
+
+func getAs(r http.Handler, user, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("X-Test-User", user)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestLoanReads_BorrowerMustOwnLoan(t *testing.T) {
+	r := newLoanReadTestRouter(new(mockRepo.LoanRepository))
+
+	for _, path := range []string{"/v1/loans/1", "/v1/loans/1/history", "/v1/loans/1/schedule", "/v1/loans/1/investments", "/v1/loans/1/approval"} {
+		assert.Equal(t, http.StatusForbidden, getAs(r, "borrower", path).Code, path)
+	}
+	assert.Equal(t, http.StatusOK, getAs(r, "borrower", "/v1/loans/2").Code)
+	assert.Equal(t, http.StatusOK, getAs(r, "validator", "/v1/loans/1").Code)
+}
+
+func TestLoanReads_InvestorsCannotReadLoanInvestments(t *testing.T) {
+	r := newLoanReadTestRouter(new(mockRepo.LoanRepository))
+
+	assert.Equal(t, http.StatusForbidden, getAs(r, "investor", "/v1/loans/1/investments").Code)
+	assert.Equal(t, http.StatusForbidden, getAs(r, "investor", "/v1/loans/1").Code)
+}
+
+func TestListLoans_BorrowersSeeOnlyTheirLoans(t *testing.T) {
+	loanRepo := new(mockRepo.LoanRepository)
+	loanRepo.On("ListLoans", mock.Anything, mock.MatchedBy(func(f domain.LoanFilter) bool {
+		return f.BorrowerID == "BR00000001"
+	})).Return([]domain.Loan{}, nil).Once()
+	r := newLoanReadTestRouter(loanRepo)
+
+	w := getAs(r, "borrower", "/v1/loans?borrower_id=BR00000002")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	loanRepo.AssertExpectations(t)
+}
+
+func TestLoanReads_InvestorsSeeFundableAndOwnLoans(t *testing.T) {
+	r := newLoanReadTestRouter(new(mockRepo.LoanRepository))
+
+	assert.Equal(t, http.StatusOK, getAs(r, "investor", "/v1/loans/3").Code)
+	assert.Equal(t, http.StatusOK, getAs(r, "investor", "/v1/loans/4").Code)
+	assert.Equal(t, http.StatusForbidden, getAs(r, "investor", "/v1/loans/1").Code)
+	assert.Equal(t, http.StatusForbidden, getAs(r, "borrower", "/v1/loans/3").Code)
+}
+
+func TestGetAgreements_CheckCallerFirst(t *testing.T) {
+	r := newLoanReadTestRouter(new(mockRepo.LoanRepository))
+
+	assert.Equal(t, http.StatusForbidden, getAs(r, "borrower", "/v1/loans/1/agreements").Code)
+	assert.Equal(t, http.StatusForbidden, getAs(r, "investor", "/v1/loans/1/agreements").Code)
+}
+
+func TestListLoans_InvestorsSeeFundableAndOwnLoans(t *testing.T) {
+	loanRepo := new(mockRepo.LoanRepository)
+	loanRepo.On("ListLoans", mock.Anything, mock.MatchedBy(func(f domain.LoanFilter) bool {
+		return f.InvestorEmail == "inv@example.com" && f.BorrowerID == ""
+	})).Return([]domain.Loan{}, nil).Once()
+	r := newLoanReadTestRouter(loanRepo)
+
+	w := getAs(r, "investor", "/v1/loans")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	loanRepo.AssertExpectations(t)
+}
+
+func TestListLoans_BorrowerInvestorsSeeOnlyTheirLoans(t *testing.T) {
+	testUsers["borrower-investor"] = &auth.Principal{Subject: "BR00000001", Email: "inv@example.com", Roles: []auth.Role{auth.RoleBorrower, auth.RoleInvestor}}
+	defer delete(testUsers, "borrower-investor")
+	loanRepo := new(mockRepo.LoanRepository)
+	loanRepo.On("ListLoans", mock.Anything, mock.MatchedBy(func(f domain.LoanFilter) bool {
+		return f.BorrowerID == "BR00000001"
+	})).Return([]domain.Loan{}, nil).Once()
+	r := newLoanReadTestRouter(loanRepo)
+
+	w := getAs(r, "borrower-investor", "/v1/loans")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	loanRepo.AssertExpectations(t)
+}
@@ -4,44 +4,241 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/mail"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/martinusiron/loan-service/auth"
+	"github.com/martinusiron/loan-service/domain"
 	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/repository"
 	"github.com/martinusiron/loan-service/usecase"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type Handler struct {
-	UC *usecase.LoanUsecase
+	UC          *usecase.LoanUsecase
+	RepaymentUC *usecase.RepaymentUsecase
+	WalletUC    *usecase.WalletUsecase
+	BorrowerUC  *usecase.BorrowerUsecase
+	InvestorUC  *usecase.InvestorUsecase
+	EmployeeUC  *usecase.EmployeeUsecase
+	FileUC      *usecase.FileUsecase
+	WebhookUC   *usecase.WebhookUsecase
 }
 
-func NewHandler(r *gin.Engine, uc *usecase.LoanUsecase) {
-	h := &Handler{UC: uc}
+func NewHandler(r *gin.Engine, authn auth.Authenticator, uc *usecase.LoanUsecase, repaymentUC *usecase.RepaymentUsecase, walletUC *usecase.WalletUsecase, borrowerUC *usecase.BorrowerUsecase, investorUC *usecase.InvestorUsecase, employeeUC *usecase.EmployeeUsecase, fileUC *usecase.FileUsecase, webhookUC *usecase.WebhookUsecase, idempotencyRepo repository.IdempotencyRepository) {
+	h := &Handler{UC: uc, RepaymentUC: repaymentUC, WalletUC: walletUC, BorrowerUC: borrowerUC, InvestorUC: investorUC, EmployeeUC: employeeUC, FileUC: fileUC, WebhookUC: webhookUC}
+	registerValidators()
+	idempotent := Idempotency(idempotencyRepo)
 
-	v1 := r.Group("/v1")
+	var (
+		admin     = RequireRoles(auth.RoleAdmin)
+		staff     = RequireRoles(auth.RoleAdmin, auth.RoleFieldValidator, auth.RoleFieldOfficer)
+		validator = RequireRoles(auth.RoleAdmin, auth.RoleFieldValidator)
+		officer   = RequireRoles(auth.RoleAdmin, auth.RoleFieldOfficer)
+		borrower  = RequireRoles(auth.RoleAdmin, auth.RoleBorrower)
+		investor  = RequireRoles(auth.RoleAdmin, auth.RoleInvestor)
+		// loanParty may read a loan; handlers narrow borrowers to their own
+		// loans with ownsLoan.
+		loanParty = RequireRoles(auth.RoleAdmin, auth.RoleFieldValidator, auth.RoleFieldOfficer, auth.RoleBorrower)
+		// loanViewer may also list and open loans; handlers narrow investors
+		// to loans open for funding and those they have invested in.
+		loanViewer = RequireRoles(auth.RoleAdmin, auth.RoleFieldValidator, auth.RoleFieldOfficer, auth.RoleBorrower, auth.RoleInvestor)
+	)
+
+	// Every route requires a valid token; the role checks below narrow
+	// each route further, and handlers check that callers act only as
+	// themselves where the payload names a borrower, investor or employee.
+	v1 := r.Group("/v1", Authenticate(authn))
 	{
-		v1.POST("/loans", h.CreateLoan)
-		v1.POST("/loans/:id/approve", h.ApproveLoan)
-		v1.POST("/loans/:id/invest", h.InvestLoan)
-		v1.POST("/loans/:id/disburse", h.DisburseLoan)
-		v1.GET("/loans/:id", h.GetLoan)
+		v1.POST("/borrowers", admin, idempotent, h.CreateBorrower)
+		v1.GET("/borrowers", staff, h.ListBorrowers)
+		v1.GET("/borrowers/:id", RequireRoles(auth.RoleAdmin, auth.RoleFieldValidator, auth.RoleFieldOfficer, auth.RoleBorrower), h.GetBorrower)
+		v1.PUT("/borrowers/:id", admin, h.UpdateBorrower)
+		v1.DELETE("/borrowers/:id", admin, h.DeactivateBorrower)
+		v1.POST("/loans", borrower, idempotent, h.CreateLoan)
+		v1.POST("/loans/:id/approve", validator, LimitBody(uc.MaxPictureProofSize+multipartOverhead), idempotent, h.ApproveLoan)
+		v1.POST("/loans/:id/reject", validator, idempotent, h.RejectLoan)
+		v1.POST("/loans/:id/cancel", borrower, idempotent, h.CancelLoan)
+		v1.POST("/loans/:id/invest", RequireRoles(auth.RoleInvestor), idempotent, h.InvestLoan)
+		v1.POST("/loans/:id/disburse", officer, idempotent, h.DisburseLoan)
+		v1.POST("/loans/:id/repayments", RequireRoles(auth.RoleAdmin, auth.RoleFieldOfficer, auth.RoleBorrower), idempotent, h.RecordRepayment)
+		v1.GET("/loans", loanViewer, h.ListLoans)
+		v1.GET("/loans/:id", loanViewer, h.GetLoan)
+		v1.GET("/loans/:id/history", loanParty, h.GetLoanHistory)
+		v1.GET("/loans/:id/investments", loanParty, h.ListLoanInvestments)
+		v1.GET("/loans/:id/schedule", loanParty, h.GetRepaymentSchedule)
+		v1.GET("/loans/:id/approval", loanParty, h.GetLoanApproval)
+		v1.GET("/loans/:id/agreements", loanViewer, h.GetAgreements)
+		v1.POST("/employees", admin, idempotent, h.CreateEmployee)
+		v1.GET("/employees/:id", admin, h.GetEmployee)
+		v1.PUT("/employees/:id", admin, h.UpdateEmployee)
+		v1.POST("/investors", investor, idempotent, h.RegisterInvestor)
+		v1.GET("/investors", admin, h.ListInvestors)
+		v1.GET("/investors/:email", investor, h.GetInvestor)
+		v1.POST("/investors/:email/kyc", admin, idempotent, h.ReviewKYC)
+		v1.GET("/investors/:email/investments", investor, h.ListInvestorInvestments)
+		v1.GET("/investors/:email/wallet", investor, h.GetWallet)
+		v1.POST("/investors/:email/wallet/top-up", investor, idempotent, h.TopUpWallet)
+		v1.POST("/investors/:email/wallet/withdraw", investor, idempotent, h.WithdrawWallet)
+		v1.POST("/webhooks", admin, idempotent, h.CreateWebhook)
+		v1.GET("/webhooks", admin, h.ListWebhooks)
+		v1.GET("/webhooks/:id", admin, h.GetWebhook)
+		v1.PUT("/webhooks/:id", admin, h.UpdateWebhook)
+		v1.DELETE("/webhooks/:id", admin, h.DeleteWebhook)
+		v1.GET("/webhooks/:id/deliveries", admin, h.ListWebhookDeliveries)
+		v1.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", admin, idempotent, h.RedeliverWebhook)
+	}
+
+	// Files are fetched through signed links instead of a bearer token, so
+	// they can be embedded directly in pages and e-mails.
+	r.GET(FileRoute+"/*key", h.DownloadFile)
+}
+
+// ownsLoan reports whether the caller may act on the loan: staff may act on
+// any loan, borrowers only on their own. It writes the error response when
+// they may not.
+func (h *Handler) ownsLoan(c *gin.Context, loanID int) bool {
+	p := principalFrom(c)
+	if p.HasRole(auth.RoleAdmin, auth.RoleFieldValidator, auth.RoleFieldOfficer) {
+		return true
+	}
+
+	loan, err := h.UC.GetLoan(c, loanID)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return false
+	}
+	if loan == nil {
+		errorResponse(c, http.StatusNotFound, errors.New("loan not found"))
+		return false
+	}
+	if !p.Is(loan.BorrowerID) {
+		errorResponse(c, http.StatusForbidden, errForbidden)
+		return false
+	}
+	return true
+}
+
+// canViewLoan is ownsLoan extended to investors, who may also view loans open
+// for funding and loans they have invested in.
+func (h *Handler) canViewLoan(c *gin.Context, loanID int) bool {
+	p := principalFrom(c)
+	if p.HasRole(auth.RoleAdmin, auth.RoleFieldValidator, auth.RoleFieldOfficer) {
+		return true
+	}
+
+	loan, err := h.UC.GetLoan(c, loanID)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return false
+	}
+	if loan == nil {
+		errorResponse(c, http.StatusNotFound, errors.New("loan not found"))
+		return false
 	}
+	if p.HasRole(auth.RoleBorrower) && p.Is(loan.BorrowerID) {
+		return true
+	}
+	if p.HasRole(auth.RoleInvestor) {
+		if loan.Status == domain.StatusApproved {
+			return true
+		}
+		invested, err := h.UC.HasInvested(c, loanID, investorEmail(p))
+		if err != nil {
+			errorResponse(c, http.StatusInternalServerError, err)
+			return false
+		}
+		if invested {
+			return true
+		}
+	}
+	errorResponse(c, http.StatusForbidden, errForbidden)
+	return false
+}
+
+// investorEmail returns the address an investor is registered under.
+func investorEmail(p *auth.Principal) string {
+	if p.Email != "" {
+		return p.Email
+	}
+	return p.Subject
 }
 
+// codeInvestorNotVerified tells clients an investment was refused because the
+// investor has not passed KYC, as opposed to any other 403.
+const codeInvestorNotVerified = "investor_not_verified"
+
 func errorResponse(c *gin.Context, status int, err error) {
-	c.JSON(status, gin.H{"error": err.Error()})
+	body := gin.H{"error": err.Error()}
+	var kycErr *domain.KYCError
+	if errors.As(err, &kycErr) {
+		body["code"] = codeInvestorNotVerified
+		if kycErr.Status != "" {
+			body["kyc_status"] = kycErr.Status
+		}
+	}
+	c.JSON(status, body)
+}
+
+// usecaseErrorStatus maps errors returned by the usecase to an HTTP status.
+func usecaseErrorStatus(err error) int {
+	if errors.Is(err, domain.ErrInvalidTransition) || errors.Is(err, domain.ErrRepaymentNotAllowed) ||
+		errors.Is(err, domain.ErrFundingClosed) {
+		return http.StatusConflict
+	}
+	if errors.Is(err, domain.ErrCurrencyMismatch) {
+		return http.StatusBadRequest
+	}
+	if errors.Is(err, domain.ErrBorrowerExists) || errors.Is(err, domain.ErrInvestorExists) || errors.Is(err, domain.ErrEmployeeExists) ||
+		errors.Is(err, domain.ErrInvalidKYCTransition) || errors.Is(err, domain.ErrWebhookInactive) ||
+		errors.Is(err, domain.ErrWebhookDeliveryInProgress) {
+		return http.StatusConflict
+	}
+	if errors.Is(err, domain.ErrInvestorNotVerified) || errors.Is(err, domain.ErrEmployeeInactive) ||
+		errors.Is(err, domain.ErrEmployeeForbidden) {
+		return http.StatusForbidden
+	}
+	if errors.Is(err, domain.ErrInsufficientFunds) || errors.Is(err, domain.ErrBorrowerNotFound) ||
+		errors.Is(err, domain.ErrBorrowerInactive) || errors.Is(err, domain.ErrEmployeeNotFound) {
+		return http.StatusUnprocessableEntity
+	}
+	if errors.Is(err, domain.ErrInvalidFilter) || errors.Is(err, domain.ErrInvalidWebhook) {
+		return http.StatusBadRequest
+	}
+	if errors.Is(err, domain.ErrPictureProofTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	if errors.Is(err, domain.ErrPictureProofType) {
+		return http.StatusUnsupportedMediaType
+	}
+	if errors.Is(err, domain.ErrBlobNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, domain.ErrInvalidDownloadLink) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
 }
 
 // @Summary Create a new loan
+// @Description The borrower must be registered and active.
 // @Tags Loans
 // @Accept json
 // @Produce json
 // @Param payload body dto.CreateLoanPayload true "Loan payload"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success 201 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
 // @Router /v1/loans [post]
 func (h *Handler) CreateLoan(c *gin.Context) {
 	var payload dto.CreateLoanPayload
@@ -49,13 +246,16 @@ func (h *Handler) CreateLoan(c *gin.Context) {
 		errorResponse(c, http.StatusBadRequest, err)
 		return
 	}
+	if !actingAs(c, payload.BorrowerID) {
+		return
+	}
 
 	loan, err := h.UC.CreateLoan(
 		c.Request.Context(),
 		payload,
 	)
 	if err != nil {
-		errorResponse(c, http.StatusInternalServerError, err)
+		errorResponse(c, usecaseErrorStatus(err), err)
 		return
 	}
 
@@ -63,13 +263,25 @@ func (h *Handler) CreateLoan(c *gin.Context) {
 }
 
 // @Summary Approve a loan
+// @Description Upload the field validator's picture proof of visiting the borrower as a JPEG, PNG or WebP image.
 // @Tags Loans
-// @Accept json
+// @Accept multipart/form-data
 // @Produce json
 // @Param id path int true "Loan ID"
-// @Param payload body dto.ApproveLoanPayload true "Approval payload (date format: YYYY-MM-DD)"
+// @Param picture_proof formData file true "Picture proof (JPEG, PNG or WebP, 5 MiB by default)"
+// @Param employee_id formData string true "Approving employee"
+// @Param date formData string true "Approval date (YYYY-MM-DD)"
+// @Param funding_deadline formData string false "Funding deadline (YYYY-MM-DD); defaults to the configured funding period"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 403 {object} map[string]string "Employee is inactive or not a field validator or admin"
+// @Failure 413 {object} map[string]string "Picture proof is too large"
+// @Failure 415 {object} map[string]string "Picture proof is not a JPEG, PNG or WebP image"
+// @Failure 422 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
 // @Router /v1/loans/{id}/approve [post]
 func (h *Handler) ApproveLoan(c *gin.Context) {
 	var payload dto.ApproveLoanPayload
@@ -81,10 +293,20 @@ func (h *Handler) ApproveLoan(c *gin.Context) {
 	}
 
 	payload.LoanID = id
-	if err := c.ShouldBindJSON(&payload); err != nil {
-		errorResponse(c, http.StatusBadRequest, err)
+	if err := c.ShouldBindWith(&payload, binding.FormMultipart); err != nil {
+		errorResponse(c, bodyErrorStatus(err), err)
+		return
+	}
+	if !actingAs(c, payload.EmployeeID) {
+		return
+	}
+
+	upload, err := readUpload(c, "picture_proof")
+	if err != nil {
+		errorResponse(c, bodyErrorStatus(err), err)
 		return
 	}
+	payload.PictureProof = upload
 
 	parsedDate, err := time.Parse("2006-01-02", payload.DateStr)
 	if err != nil {
@@ -93,22 +315,130 @@ func (h *Handler) ApproveLoan(c *gin.Context) {
 	}
 	payload.Date = parsedDate
 
+	if payload.FundingDeadlineStr != "" {
+		deadline, err := time.Parse("2006-01-02", payload.FundingDeadlineStr)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, fmt.Errorf("invalid funding_deadline format, must be YYYY-MM-DD"))
+			return
+		}
+		if !deadline.After(time.Now()) {
+			errorResponse(c, http.StatusBadRequest, fmt.Errorf("funding_deadline must be in the future"))
+			return
+		}
+		payload.FundingDeadline = &deadline
+	}
+
 	if err := h.UC.ApproveLoan(c, payload); err != nil {
-		errorResponse(c, http.StatusInternalServerError, err)
+		errorResponse(c, usecaseErrorStatus(err), err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Loan approved"})
 }
 
+// @Summary Reject a loan
+// @Tags Loans
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Param payload body dto.RejectLoanPayload true "Rejection payload (date format: YYYY-MM-DD)"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 403 {object} map[string]string "Employee is inactive or not a field validator or admin"
+// @Failure 422 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/loans/{id}/reject [post]
+func (h *Handler) RejectLoan(c *gin.Context) {
+	var payload dto.RejectLoanPayload
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	payload.LoanID = id
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !actingAs(c, payload.EmployeeID) {
+		return
+	}
+
+	parsedDate, err := time.Parse("2006-01-02", payload.DateStr)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Errorf("invalid date format, must be YYYY-MM-DD"))
+		return
+	}
+	payload.Date = parsedDate
+
+	if err := h.UC.RejectLoan(c, payload); err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Loan rejected"})
+}
+
+// @Summary Cancel a loan before disbursement
+// @Description Cancels a proposed, approved or invested loan and refunds any investments to the investors' wallets.
+// @Tags Loans
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Param payload body dto.CancelLoanPayload true "Cancellation payload"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/loans/{id}/cancel [post]
+func (h *Handler) CancelLoan(c *gin.Context) {
+	var payload dto.CancelLoanPayload
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	payload.LoanID = id
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !actingAs(c, payload.Actor) || !h.ownsLoan(c, id) {
+		return
+	}
+
+	if err := h.UC.CancelLoan(c, payload); err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Loan cancelled"})
+}
+
 // @Summary Invest in a loan
 // @Tags Loans
 // @Accept json
 // @Produce json
 // @Param id path int true "Loan ID"
 // @Param payload body dto.InvestLoanPayload true "Investment payload"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 403 {object} map[string]string "Investor is not KYC verified: code is investor_not_verified and kyc_status the investor's KYC status, absent when they are not registered"
+// @Failure 422 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
 // @Router /v1/loans/{id}/invest [post]
 func (h *Handler) InvestLoan(c *gin.Context) {
 	var payload dto.InvestLoanPayload
@@ -124,11 +454,15 @@ func (h *Handler) InvestLoan(c *gin.Context) {
 		errorResponse(c, http.StatusBadRequest, err)
 		return
 	}
+	if !principalFrom(c).Is(payload.InvestorEmail) {
+		errorResponse(c, http.StatusForbidden, errors.New("investors can only invest as themselves"))
+		return
+	}
 
 	if err := h.UC.InvestLoan(c,
 		payload,
 	); err != nil {
-		errorResponse(c, http.StatusInternalServerError, err)
+		errorResponse(c, usecaseErrorStatus(err), err)
 		return
 	}
 
@@ -136,13 +470,20 @@ func (h *Handler) InvestLoan(c *gin.Context) {
 }
 
 // @Summary Disburse a loan
+// @Description Generates the agreement letters of the borrower and of every investor, in HTML and PDF, and sets the loan's agreement letter link to where they are listed.
 // @Tags Loans
 // @Accept json
 // @Produce json
 // @Param id path int true "Loan ID"
 // @Param payload body dto.DisburseLoanPayload true "Disbursement payload (date format: YYYY-MM-DD)"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 403 {object} map[string]string "Employee is inactive or not a field officer or admin"
+// @Failure 422 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
 // @Router /v1/loans/{id}/disburse [post]
 func (h *Handler) DisburseLoan(c *gin.Context) {
 	var payload dto.DisburseLoanPayload
@@ -159,6 +500,9 @@ func (h *Handler) DisburseLoan(c *gin.Context) {
 		errorResponse(c, http.StatusBadRequest, err)
 		return
 	}
+	if !actingAs(c, payload.EmployeeID) {
+		return
+	}
 
 	parsedDate, err := time.Parse("2006-01-02", payload.DateStr)
 	if err != nil {
@@ -170,19 +514,74 @@ func (h *Handler) DisburseLoan(c *gin.Context) {
 	if err := h.UC.DisburseLoan(c,
 		payload,
 	); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		errorResponse(c, usecaseErrorStatus(err), err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Loan disbursed"})
 }
 
+// @Summary Record a borrower repayment
+// @Description Allocates the payment to outstanding instalments, oldest first, in the configured waterfall order (fees, interest, principal by default). Any amount left after the last instalment is settled is reported as excess.
+// @Tags Repayments
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Param payload body dto.RecordRepaymentPayload true "Repayment payload (date format: YYYY-MM-DD)"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 201 {object} dto.RepaymentResult
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/loans/{id}/repayments [post]
+func (h *Handler) RecordRepayment(c *gin.Context) {
+	var payload dto.RecordRepaymentPayload
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	payload.LoanID = id
+
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !h.ownsLoan(c, id) {
+		return
+	}
+
+	parsedDate, err := time.Parse("2006-01-02", payload.DateStr)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Errorf("invalid date format, must be YYYY-MM-DD"))
+		return
+	}
+	payload.Date = parsedDate
+
+	result, err := h.RepaymentUC.RecordRepayment(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
 // @Summary Get a loan by ID
+// @Description Borrowers may only open their own loans, investors loans open for funding and those they have invested in.
 // @Tags Loans
 // @Produce json
 // @Param id path int true "Loan ID"
 // @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
 // @Router /v1/loans/{id} [get]
 func (h *Handler) GetLoan(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -190,6 +589,9 @@ func (h *Handler) GetLoan(c *gin.Context) {
 		errorResponse(c, http.StatusBadRequest, err)
 		return
 	}
+	if !h.canViewLoan(c, id) {
+		return
+	}
 
 	loan, err := h.UC.GetLoan(c, id)
 	if err != nil {
@@ -204,3 +606,1042 @@ func (h *Handler) GetLoan(c *gin.Context) {
 
 	c.JSON(http.StatusOK, loan)
 }
+
+// @Summary List loans
+// @Description Borrowers only see their own loans, investors loans open for funding and those they have invested in.
+// @Tags Loans
+// @Produce json
+// @Param status query []string false "Filter by status (repeat or comma-separate)" collectionFormat(multi)
+// @Param borrower_id query string false "Filter by borrower"
+// @Param min_principal query string false "Minimum principal amount"
+// @Param max_principal query string false "Maximum principal amount"
+// @Param min_rate query number false "Minimum rate"
+// @Param max_rate query number false "Maximum rate"
+// @Param created_from query string false "Created at or after (YYYY-MM-DD or RFC 3339)"
+// @Param created_to query string false "Created before (YYYY-MM-DD or RFC 3339)"
+// @Param sort_by query string false "Sort field" Enums(created_at, principal_amount, rate, id)
+// @Param order query string false "Sort order (default desc)" Enums(asc, desc)
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Success 200 {object} dto.LoanPage
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/loans [get]
+func (h *Handler) ListLoans(c *gin.Context) {
+	var query dto.ListLoansQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	// Borrowers only see their own loans, investors the loans open for
+	// funding and those they have invested in.
+	if p := principalFrom(c); !p.HasRole(auth.RoleAdmin, auth.RoleFieldValidator, auth.RoleFieldOfficer) {
+		if p.HasRole(auth.RoleBorrower) {
+			query.BorrowerID = p.Subject
+		} else {
+			query.InvestorEmail = investorEmail(p)
+		}
+	}
+
+	page, err := h.UC.ListLoans(c, query)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Get the status history of a loan
+// @Tags Loans
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Success 200 {array} domain.LoanStatusHistory
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/loans/{id}/history [get]
+func (h *Handler) GetLoanHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !h.ownsLoan(c, id) {
+		return
+	}
+
+	history, err := h.UC.GetLoanHistory(c, id)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if history == nil {
+		errorResponse(c, http.StatusNotFound, errors.New("loan not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// @Summary Get the repayment schedule of a loan
+// @Tags Repayments
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Success 200 {object} dto.RepaymentSchedule
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/loans/{id}/schedule [get]
+func (h *Handler) GetRepaymentSchedule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !h.ownsLoan(c, id) {
+		return
+	}
+
+	schedule, err := h.UC.GetRepaymentSchedule(c, id)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if schedule == nil {
+		errorResponse(c, http.StatusNotFound, errors.New("loan not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// @Summary List the investments funding a loan
+// @Description Staff and the loan's borrower only; investors see their own investments under /v1/investors/{email}/investments.
+// @Tags Investments
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Success 200 {object} dto.InvestmentPage
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/loans/{id}/investments [get]
+func (h *Handler) ListLoanInvestments(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !h.ownsLoan(c, id) {
+		return
+	}
+
+	var query dto.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := h.UC.ListLoanInvestments(c, id, query)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if page == nil {
+		errorResponse(c, http.StatusNotFound, errors.New("loan not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary List an investor's portfolio
+// @Tags Investments
+// @Produce json
+// @Param email path string true "Investor email"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Success 200 {object} dto.InvestmentPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/investors/{email}/investments [get]
+func (h *Handler) ListInvestorInvestments(c *gin.Context) {
+	email := c.Param("email")
+	if _, err := mail.ParseAddress(email); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Errorf("invalid investor email"))
+		return
+	}
+	if !actingAs(c, email) {
+		return
+	}
+
+	var query dto.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := h.UC.ListInvestorInvestments(c, email, query)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Get an investor's wallet
+// @Tags Wallets
+// @Produce json
+// @Param email path string true "Investor email"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/investors/{email}/wallet [get]
+func (h *Handler) GetWallet(c *gin.Context) {
+	email := c.Param("email")
+	if _, err := mail.ParseAddress(email); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Errorf("invalid investor email"))
+		return
+	}
+	if !actingAs(c, email) {
+		return
+	}
+
+	wallet, err := h.WalletUC.GetWallet(c, email)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+// @Summary Top up an investor's wallet
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param email path string true "Investor email"
+// @Param payload body dto.WalletTransferPayload true "Top-up payload"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/investors/{email}/wallet/top-up [post]
+func (h *Handler) TopUpWallet(c *gin.Context) {
+	payload, ok := bindWalletTransfer(c)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.WalletUC.TopUp(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+// @Summary Withdraw from an investor's wallet
+// @Description Only the available balance can be withdrawn; funds held for investments in loans that are not disbursed yet stay in the wallet.
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param email path string true "Investor email"
+// @Param payload body dto.WalletTransferPayload true "Withdrawal payload"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string "Insufficient funds"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/investors/{email}/wallet/withdraw [post]
+func (h *Handler) WithdrawWallet(c *gin.Context) {
+	payload, ok := bindWalletTransfer(c)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.WalletUC.Withdraw(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+func bindWalletTransfer(c *gin.Context) (dto.WalletTransferPayload, bool) {
+	var payload dto.WalletTransferPayload
+
+	email := c.Param("email")
+	if _, err := mail.ParseAddress(email); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Errorf("invalid investor email"))
+		return payload, false
+	}
+	if !actingAs(c, email) {
+		return payload, false
+	}
+	payload.InvestorEmail = email
+
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return payload, false
+	}
+	return payload, true
+}
+
+// @Summary Register a borrower
+// @Tags Borrowers
+// @Accept json
+// @Produce json
+// @Param payload body dto.BorrowerPayload true "Borrower payload"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string "Identity number already registered"
+// @Failure 422 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/borrowers [post]
+func (h *Handler) CreateBorrower(c *gin.Context) {
+	var payload dto.BorrowerPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	borrower, err := h.BorrowerUC.CreateBorrower(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, borrower)
+}
+
+// @Summary List borrowers
+// @Tags Borrowers
+// @Produce json
+// @Param status query string false "Filter by status (active, inactive)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Success 200 {object} dto.BorrowerPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/borrowers [get]
+func (h *Handler) ListBorrowers(c *gin.Context) {
+	var query dto.ListBorrowersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := h.BorrowerUC.ListBorrowers(c, query)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Get a borrower by ID
+// @Tags Borrowers
+// @Produce json
+// @Param id path string true "Borrower ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/borrowers/{id} [get]
+func (h *Handler) GetBorrower(c *gin.Context) {
+	id := c.Param("id")
+	if !principalFrom(c).HasRole(auth.RoleAdmin, auth.RoleFieldValidator, auth.RoleFieldOfficer) && !actingAs(c, id) {
+		return
+	}
+
+	borrower, err := h.BorrowerUC.GetBorrower(c, id)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if borrower == nil {
+		errorResponse(c, http.StatusNotFound, domain.ErrBorrowerNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, borrower)
+}
+
+// @Summary Update a borrower
+// @Description Replaces the borrower's contact details and status. The identity number cannot be changed.
+// @Tags Borrowers
+// @Accept json
+// @Produce json
+// @Param id path string true "Borrower ID"
+// @Param payload body dto.UpdateBorrowerPayload true "Borrower payload"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/borrowers/{id} [put]
+func (h *Handler) UpdateBorrower(c *gin.Context) {
+	var payload dto.UpdateBorrowerPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	payload.ID = c.Param("id")
+
+	borrower, err := h.BorrowerUC.UpdateBorrower(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if borrower == nil {
+		errorResponse(c, http.StatusNotFound, domain.ErrBorrowerNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, borrower)
+}
+
+// @Summary Deactivate a borrower
+// @Description Borrowers are never deleted because their loans keep referencing them; deactivated borrowers cannot take new loans.
+// @Tags Borrowers
+// @Produce json
+// @Param id path string true "Borrower ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/borrowers/{id} [delete]
+func (h *Handler) DeactivateBorrower(c *gin.Context) {
+	borrower, err := h.BorrowerUC.DeactivateBorrower(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if borrower == nil {
+		errorResponse(c, http.StatusNotFound, domain.ErrBorrowerNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, borrower)
+}
+
+// @Summary Register an investor
+// @Description New investors start with KYC pending and cannot invest until a reviewer verifies them.
+// @Tags Investors
+// @Accept json
+// @Produce json
+// @Param payload body dto.RegisterInvestorPayload true "Investor payload"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string "Email already registered"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/investors [post]
+func (h *Handler) RegisterInvestor(c *gin.Context) {
+	var payload dto.RegisterInvestorPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !actingAs(c, payload.Email) {
+		return
+	}
+
+	investor, err := h.InvestorUC.RegisterInvestor(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, investor)
+}
+
+// @Summary List investors
+// @Tags Investors
+// @Produce json
+// @Param kyc_status query string false "Filter by KYC status (pending, verified, rejected, suspended)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Success 200 {object} dto.InvestorPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/investors [get]
+func (h *Handler) ListInvestors(c *gin.Context) {
+	var query dto.ListInvestorsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := h.InvestorUC.ListInvestors(c, query)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Get an investor
+// @Tags Investors
+// @Produce json
+// @Param email path string true "Investor email"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/investors/{email} [get]
+func (h *Handler) GetInvestor(c *gin.Context) {
+	email := c.Param("email")
+	if _, err := mail.ParseAddress(email); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Errorf("invalid investor email"))
+		return
+	}
+	if !actingAs(c, email) {
+		return
+	}
+
+	investor, err := h.InvestorUC.GetInvestor(c, email)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if investor == nil {
+		errorResponse(c, http.StatusNotFound, errors.New("investor not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, investor)
+}
+
+// @Summary Review an investor's KYC
+// @Description Allowed outcomes: pending → verified or rejected, verified → suspended, suspended → verified or rejected, rejected → pending (resubmission). The review is recorded under the caller's employee ID.
+// @Tags Investors
+// @Accept json
+// @Produce json
+// @Param email path string true "Investor email"
+// @Param payload body dto.ReviewKYCPayload true "Review payload"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "KYC status change not allowed"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/investors/{email}/kyc [post]
+func (h *Handler) ReviewKYC(c *gin.Context) {
+	var payload dto.ReviewKYCPayload
+
+	email := c.Param("email")
+	if _, err := mail.ParseAddress(email); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Errorf("invalid investor email"))
+		return
+	}
+	payload.Email = email
+
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	payload.ReviewerID = principalFrom(c).Subject
+
+	investor, err := h.InvestorUC.ReviewKYC(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if investor == nil {
+		errorResponse(c, http.StatusNotFound, errors.New("investor not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, investor)
+}
+
+// @Summary Register an employee
+// @Tags Employees
+// @Accept json
+// @Produce json
+// @Param payload body dto.EmployeePayload true "Employee payload"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string "Employee ID already taken"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/employees [post]
+func (h *Handler) CreateEmployee(c *gin.Context) {
+	var payload dto.EmployeePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	employee, err := h.EmployeeUC.CreateEmployee(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, employee)
+}
+
+// @Summary Get an employee
+// @Tags Employees
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/employees/{id} [get]
+func (h *Handler) GetEmployee(c *gin.Context) {
+	employee, err := h.EmployeeUC.GetEmployee(c, c.Param("id"))
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if employee == nil {
+		errorResponse(c, http.StatusNotFound, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, employee)
+}
+
+// @Summary Update an employee
+// @Description Changes the employee's name, role or status. Inactive employees cannot approve or disburse loans.
+// @Tags Employees
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param payload body dto.UpdateEmployeePayload true "Employee payload"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/employees/{id} [put]
+func (h *Handler) UpdateEmployee(c *gin.Context) {
+	var payload dto.UpdateEmployeePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	payload.ID = c.Param("id")
+
+	employee, err := h.EmployeeUC.UpdateEmployee(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if employee == nil {
+		errorResponse(c, http.StatusNotFound, domain.ErrEmployeeNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, employee)
+}
+
+// @Summary Get a loan's approval
+// @Description Includes a signed link to the picture proof that stays valid for the configured time.
+// @Tags Loans
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Success 200 {object} dto.LoanApprovalDetail
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/loans/{id}/approval [get]
+func (h *Handler) GetLoanApproval(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !h.ownsLoan(c, id) {
+		return
+	}
+
+	approval, err := h.UC.GetApproval(c, id)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if approval == nil {
+		errorResponse(c, http.StatusNotFound, errors.New("loan has not been approved"))
+		return
+	}
+
+	url, expiresAt := h.FileUC.DownloadURL(approval.PictureProofKey)
+	c.JSON(http.StatusOK, dto.LoanApprovalDetail{
+		Approval:                 approval,
+		PictureProofURL:          url,
+		PictureProofURLExpiresAt: expiresAt,
+	})
+}
+
+// @Summary List a loan's agreement letters
+// @Description Each letter comes with a signed download link that stays valid for the configured time. Staff see every letter, borrowers their own letter and investors theirs.
+// @Tags Loans
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Success 200 {array} dto.AgreementDocument
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string "Loan not found or not disbursed yet"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/loans/{id}/agreements [get]
+func (h *Handler) GetAgreements(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !h.canViewLoan(c, id) {
+		return
+	}
+
+	loan, err := h.UC.GetLoan(c, id)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	if loan == nil {
+		errorResponse(c, http.StatusNotFound, errors.New("loan not found"))
+		return
+	}
+
+	agreements, err := h.UC.GetAgreements(c, id)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+	if len(agreements) == 0 {
+		errorResponse(c, http.StatusNotFound, errors.New("loan has no agreement letters"))
+		return
+	}
+
+	p := principalFrom(c)
+	staff := p.HasRole(auth.RoleAdmin, auth.RoleFieldValidator, auth.RoleFieldOfficer)
+	docs := []dto.AgreementDocument{}
+	for _, a := range agreements {
+		switch {
+		case staff:
+		case a.Party == domain.AgreementBorrower && p.HasRole(auth.RoleBorrower) && p.Is(loan.BorrowerID):
+		case a.Party == domain.AgreementInvestor && p.HasRole(auth.RoleInvestor) && p.Is(a.InvestorEmail):
+		default:
+			continue
+		}
+		url, expiresAt := h.FileUC.DownloadURL(a.Key)
+		docs = append(docs, dto.AgreementDocument{Agreement: a, URL: url, URLExpiresAt: expiresAt})
+	}
+	if len(docs) == 0 {
+		errorResponse(c, http.StatusForbidden, errForbidden)
+		return
+	}
+
+	c.JSON(http.StatusOK, docs)
+}
+
+// @Summary Download a stored file
+// @Description Serves files such as picture proofs through the signed links returned by other endpoints; no bearer token is needed.
+// @Tags Files
+// @Produce octet-stream
+// @Param key path string true "File key"
+// @Param expires query int true "Link expiry (Unix seconds)"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 403 {object} map[string]string "Link is invalid or has expired"
+// @Failure 404 {object} map[string]string
+// @Router /files/{key} [get]
+func (h *Handler) DownloadFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	body, info, err := h.FileUC.Open(c.Request.Context(), key, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+	defer body.Close()
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, info.Size, info.ContentType, body, nil)
+}
+
+// @Summary Subscribe a webhook
+// @Description Deliveries are POSTed as JSON and signed with the secret: X-Webhook-Signature is "sha256=" followed by the hex HMAC-SHA256 of the X-Webhook-Timestamp value, a dot and the body.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param payload body dto.WebhookPayload true "Webhook payload"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 201 {object} domain.Webhook
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/webhooks [post]
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	var payload dto.WebhookPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	webhook, err := h.WebhookUC.CreateWebhook(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// @Summary List webhooks
+// @Tags Webhooks
+// @Produce json
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Success 200 {object} dto.WebhookPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/webhooks [get]
+func (h *Handler) ListWebhooks(c *gin.Context) {
+	var query dto.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := h.WebhookUC.ListWebhooks(c, query)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Get a webhook
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} domain.Webhook
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/webhooks/{id} [get]
+func (h *Handler) GetWebhook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	webhook, err := h.WebhookUC.GetWebhook(c, id)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if webhook == nil {
+		errorResponse(c, http.StatusNotFound, domain.ErrWebhookNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// @Summary Update a webhook
+// @Description Replaces the webhook's URL, event types and status. The secret is rotated only when a new one is given. Inactive webhooks receive no deliveries.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param payload body dto.UpdateWebhookPayload true "Webhook payload"
+// @Success 200 {object} domain.Webhook
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/webhooks/{id} [put]
+func (h *Handler) UpdateWebhook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload dto.UpdateWebhookPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	payload.ID = id
+
+	webhook, err := h.WebhookUC.UpdateWebhook(c.Request.Context(), payload)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if webhook == nil {
+		errorResponse(c, http.StatusNotFound, domain.ErrWebhookNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// @Summary Delete a webhook
+// @Description Removes the webhook and its delivery log; deliveries not yet sent are dropped.
+// @Tags Webhooks
+// @Param id path int true "Webhook ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/webhooks/{id} [delete]
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	deleted, err := h.WebhookUC.DeleteWebhook(c.Request.Context(), id)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if !deleted {
+		errorResponse(c, http.StatusNotFound, domain.ErrWebhookNotFound)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List a webhook's deliveries
+// @Description The delivery log, newest first: each delivery's event, status (pending, retrying, succeeded or failed), number of attempts and the outcome of the latest one.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param status query string false "Filter by status" Enums(pending, retrying, succeeded, failed)
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Success 200 {object} dto.WebhookDeliveryPage
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/webhooks/{id}/deliveries [get]
+func (h *Handler) ListWebhookDeliveries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var query dto.ListWebhookDeliveriesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	query.WebhookID = id
+
+	page, err := h.WebhookUC.ListDeliveries(c, query)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if page == nil {
+		errorResponse(c, http.StatusNotFound, domain.ErrWebhookNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Redeliver a webhook delivery
+// @Description Sends a succeeded or failed delivery again with the same payload and delivery ID, retrying as usual on failure.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 202 {object} domain.WebhookDelivery
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "Webhook is inactive or the delivery is still being attempted"
+// @Failure 401 {object} map[string]string "Missing or invalid token"
+// @Security BearerAuth
+// @Router /v1/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *Handler) RedeliverWebhook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	delivery, err := h.WebhookUC.Redeliver(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		errorResponse(c, usecaseErrorStatus(err), err)
+		return
+	}
+
+	if delivery == nil {
+		errorResponse(c, http.StatusNotFound, errors.New("webhook delivery not found"))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func errorBody(t *testing.T, err error) map[string]string {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	errorResponse(c, usecaseErrorStatus(err), err)
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	return body
+}
+
+func TestErrorResponse_KYCErrorCarriesCode(t *testing.T) {
+	err := fmt.Errorf("invest: %w", &domain.KYCError{InvestorEmail: "inv@example.com", Status: domain.KYCPending})
+
+	body := errorBody(t, err)
+	assert.Equal(t, "investor_not_verified", body["code"])
+	assert.Equal(t, "pending", body["kyc_status"])
+
+	body = errorBody(t, &domain.KYCError{InvestorEmail: "inv@example.com"})
+	assert.Equal(t, "investor_not_verified", body["code"])
+	assert.NotContains(t, body, "kyc_status")
+}
+
+func TestErrorResponse_OtherForbiddenErrorsHaveNoCode(t *testing.T) {
+	body := errorBody(t, domain.ErrEmployeeForbidden)
+	assert.NotContains(t, body, "code")
+}
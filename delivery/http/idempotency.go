@@ -0,0 +1,151 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/repository"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyResponseFormat = "application/json; charset=utf-8"
+)
+
+// responseRecorder tees everything the handler writes so the response can be
+// stored next to its idempotency key.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// requestHash fingerprints a request.
+func requestHash(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(path))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// callerID scopes idempotency keys, so clients that happen to pick the same
+// key do not see each other's requests.
+func callerID(c *gin.Context) string {
+	p := principalFrom(c)
+	if p == nil {
+		return ""
+	}
+	return p.Subject + "\n" + p.Email
+}
+
+// Idempotency honours the Idempotency-Key header, with keys scoped to the
+// caller. The first request with a key runs normally and its response is stored; retries with the same key and
+// the same request replay that response without running the handler again.
+// Reusing a key for a different request returns 422, and retrying while the
+// first request is still running returns 409. Server errors are not stored, so
+// the client may retry them with the same key. Requests without the header
+// are passed through untouched.
+func Idempotency(repo repository.IdempotencyRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			errorResponse(c, http.StatusBadRequest, errors.New("Idempotency-Key must be at most 255 characters"))
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			errorResponse(c, bodyErrorStatus(err), err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Bookkeeping must survive a client that hangs up mid-request.
+		ctx := context.WithoutCancel(c.Request.Context())
+		caller := callerID(c)
+		hash := requestHash(c.Request.Method, c.Request.URL.Path, body)
+
+		reserved, err := repo.Reserve(ctx, &domain.IdempotencyRecord{
+			Caller:      caller,
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: hash,
+		})
+		if err != nil {
+			errorResponse(c, http.StatusInternalServerError, err)
+			c.Abort()
+			return
+		}
+
+		if !reserved {
+			rec, err := repo.GetByKey(ctx, caller, key)
+			switch {
+			case err != nil:
+				errorResponse(c, http.StatusInternalServerError, err)
+			case rec == nil:
+				// The original request failed and released the key between
+				// our insert and read; let the client retry.
+				errorResponse(c, http.StatusConflict, errors.New("request with this Idempotency-Key is being retried, try again"))
+			case rec.RequestHash != hash:
+				errorResponse(c, http.StatusUnprocessableEntity, errors.New("Idempotency-Key was already used with a different request"))
+			case !rec.Completed:
+				errorResponse(c, http.StatusConflict, errors.New("request with this Idempotency-Key is still in progress"))
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(rec.StatusCode, idempotencyResponseFormat, rec.ResponseBody)
+			}
+			c.Abort()
+			return
+		}
+
+		stored := false
+		defer func() {
+			if !stored {
+				if err := repo.Release(ctx, caller, key); err != nil {
+					log.Printf("failed to release idempotency key %q: %v", key, err)
+				}
+			}
+		}()
+
+		rec := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+		c.Next()
+
+		if status := rec.Status(); status < http.StatusInternalServerError {
+			if err := repo.SaveResponse(ctx, caller, key, status, rec.body.Bytes()); err != nil {
+				log.Printf("failed to store idempotent response for key %q: %v", key, err)
+				return
+			}
+			stored = true
+		}
+	}
+}
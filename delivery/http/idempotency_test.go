@@ -0,0 +1,155 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryIdempotencyRepo struct {
+	mu      sync.Mutex
+	records map[[2]string]*domain.IdempotencyRecord
+}
+
+func newMemoryIdempotencyRepo() *memoryIdempotencyRepo {
+	return &memoryIdempotencyRepo{records: map[[2]string]*domain.IdempotencyRecord{}}
+}
+
+func (r *memoryIdempotencyRepo) Reserve(_ context.Context, rec *domain.IdempotencyRecord) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := [2]string{rec.Caller, rec.Key}
+	if _, ok := r.records[id]; ok {
+		return false, nil
+	}
+	cp := *rec
+	r.records[id] = &cp
+	return true, nil
+}
+
+func (r *memoryIdempotencyRepo) GetByKey(_ context.Context, caller, key string) (*domain.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.records[[2]string{caller, key}]
+	if !ok {
+		return nil, nil
+	}
+	cp := *rec
+	return &cp, nil
+}
+
+func (r *memoryIdempotencyRepo) SaveResponse(_ context.Context, caller, key string, statusCode int, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := r.records[[2]string{caller, key}]
+	rec.StatusCode = statusCode
+	rec.ResponseBody = append([]byte(nil), body...)
+	rec.Completed = true
+	return nil
+}
+
+func (r *memoryIdempotencyRepo) Release(_ context.Context, caller, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec, ok := r.records[[2]string{caller, key}]; ok && !rec.Completed {
+		delete(r.records, [2]string{caller, key})
+	}
+	return nil
+}
+
+func newIdempotencyTestRouter(repo *memoryIdempotencyRepo, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/v1/loans/:id/invest", Idempotency(repo), handler)
+	return r
+}
+
+func doIdempotentRequest(r http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/loans/1/invest", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	calls := 0
+	r := newIdempotencyTestRouter(newMemoryIdempotencyRepo(), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, gin.H{"message": "Investment accepted", "call": calls})
+	})
+
+	first := doIdempotentRequest(r, "key-1", `{"amount":100}`)
+	second := doIdempotentRequest(r, "key-1", `{"amount":100}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotency_DifferentBodyIsRejected(t *testing.T) {
+	r := newIdempotencyTestRouter(newMemoryIdempotencyRepo(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "Investment accepted"})
+	})
+
+	doIdempotentRequest(r, "key-1", `{"amount":100}`)
+	w := doIdempotentRequest(r, "key-1", `{"amount":200}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestIdempotency_InFlightRequestConflicts(t *testing.T) {
+	repo := newMemoryIdempotencyRepo()
+	r := newIdempotencyTestRouter(repo, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "Investment accepted"})
+	})
+
+	body := `{"amount":100}`
+	_, _ = repo.Reserve(context.Background(), &domain.IdempotencyRecord{
+		Key:         "key-1",
+		RequestHash: requestHash(http.MethodPost, "/v1/loans/1/invest", []byte(body)),
+	})
+	w := doIdempotentRequest(r, "key-1", body)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	calls := 0
+	r := newIdempotencyTestRouter(newMemoryIdempotencyRepo(), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "boom"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Investment accepted"})
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, doIdempotentRequest(r, "key-1", `{"amount":100}`).Code)
+	assert.Equal(t, http.StatusOK, doIdempotentRequest(r, "key-1", `{"amount":100}`).Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_NoHeaderPassesThrough(t *testing.T) {
+	calls := 0
+	r := newIdempotencyTestRouter(newMemoryIdempotencyRepo(), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, gin.H{"message": "Investment accepted"})
+	})
+
+	doIdempotentRequest(r, "", `{"amount":100}`)
+	doIdempotentRequest(r, "", `{"amount":100}`)
+
+	assert.Equal(t, 2, calls)
+}
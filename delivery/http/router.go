@@ -4,14 +4,16 @@ import (
 	_ "github.com/martinusiron/loan-service/docs"
 
 	"github.com/gin-gonic/gin"
+	"github.com/martinusiron/loan-service/auth"
+	"github.com/martinusiron/loan-service/repository"
 	"github.com/martinusiron/loan-service/usecase"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func InitRouter(uc *usecase.LoanUsecase) *gin.Engine {
+func InitRouter(authn auth.Authenticator, uc *usecase.LoanUsecase, repaymentUC *usecase.RepaymentUsecase, walletUC *usecase.WalletUsecase, borrowerUC *usecase.BorrowerUsecase, investorUC *usecase.InvestorUsecase, employeeUC *usecase.EmployeeUsecase, fileUC *usecase.FileUsecase, webhookUC *usecase.WebhookUsecase, idempotencyRepo repository.IdempotencyRepository) *gin.Engine {
 	r := gin.Default()
-	NewHandler(r, uc)
+	NewHandler(r, authn, uc, repaymentUC, walletUC, borrowerUC, investorUC, employeeUC, fileUC, webhookUC, idempotencyRepo)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	return r
 }
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/martinusiron/loan-service/dto"
+)
+
+// FileRoute is where signed download links point; see Handler.DownloadFile.
+const FileRoute = "/files"
+
+// multipartOverhead leaves room for the form fields and part headers that
+// travel with an uploaded file.
+const multipartOverhead = 64 << 10
+
+// LimitBody rejects request bodies larger than n bytes. Handlers reading a
+// larger body get an error that bodyErrorStatus maps to 413.
+func LimitBody(n int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > n {
+			errorResponse(c, http.StatusRequestEntityTooLarge, fmt.Errorf("request body must be at most %d bytes", n))
+			c.Abort()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, n)
+		c.Next()
+	}
+}
+
+// bodyErrorStatus maps an error from reading the request body to 413 when
+// the body was too large and to 400 otherwise.
+func bodyErrorStatus(err error) int {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// readUpload reads the file sent in the named multipart field.
+func readUpload(c *gin.Context, field string) (dto.Upload, error) {
+	fh, err := c.FormFile(field)
+	if err != nil {
+		return dto.Upload{}, fmt.Errorf("%s file is required: %w", field, err)
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return dto.Upload{}, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return dto.Upload{}, err
+	}
+	return dto.Upload{Filename: fh.Filename, Data: data}, nil
+}
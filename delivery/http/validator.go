@@ -0,0 +1,32 @@
+package http
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/martinusiron/loan-service/domain"
+)
+
+var registerValidatorsOnce sync.Once
+
+// registerValidators teaches gin's validator about domain types so payload
+// tags such as `binding:"required,gt=0"` keep working on them.
+func registerValidators() {
+	registerValidatorsOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+
+		// Money is validated on its minor units, so gt=0 rejects zero and
+		// negative amounts exactly.
+		v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+			if m, ok := field.Interface().(domain.Money); ok {
+				return m.Amount
+			}
+			return nil
+		}, domain.Money{})
+	})
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// AgreementParty is who an agreement letter is addressed to.
+type AgreementParty string
+
+const (
+	AgreementBorrower AgreementParty = "borrower"
+	AgreementInvestor AgreementParty = "investor"
+)
+
+type AgreementFormat string
+
+const (
+	AgreementHTML AgreementFormat = "html"
+	AgreementPDF  AgreementFormat = "pdf"
+)
+
+// Agreement is one generated agreement letter of a disbursed loan. The letter
+// itself lives in the blob store under Key; Checksum is its hex encoded
+// SHA-256. InvestorEmail is empty for the borrower's letter.
+type Agreement struct {
+	ID            int
+	LoanID        int
+	Party         AgreementParty
+	InvestorEmail string
+	Format        AgreementFormat
+	Key           string
+	Checksum      string
+	ContentType   string
+	CreatedAt     time.Time
+}
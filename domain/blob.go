@@ -0,0 +1,29 @@
+package domain
+
+import "errors"
+
+var (
+	ErrBlobNotFound         = errors.New("file not found")
+	ErrPictureProofTooLarge = errors.New("picture proof is too large")
+	ErrPictureProofType     = errors.New("picture proof must be a JPEG, PNG or WebP image")
+	ErrInvalidDownloadLink  = errors.New("download link is invalid or has expired")
+)
+
+// DefaultMaxPictureProofSize caps picture proof uploads at 5 MiB unless the
+// config sets another limit.
+const DefaultMaxPictureProofSize int64 = 5 << 20
+
+// PictureProofTypes maps the accepted picture proof content types to the
+// file extension they are stored under.
+var PictureProofTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// BlobInfo describes a stored file.
+type BlobInfo struct {
+	Key         string
+	ContentType string
+	Size        int64
+}
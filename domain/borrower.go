@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrBorrowerNotFound = errors.New("borrower not found")
+	ErrBorrowerInactive = errors.New("borrower is not active")
+	ErrBorrowerExists   = errors.New("borrower with this identity number already exists")
+)
+
+type BorrowerStatus string
+
+const (
+	BorrowerActive   BorrowerStatus = "active"
+	BorrowerInactive BorrowerStatus = "inactive"
+)
+
+// Borrower is a person the platform lends to. Loans reference borrowers by
+// ID; the identity number is unique so one person is registered only once.
+type Borrower struct {
+	ID             string
+	IdentityNumber string
+	Name           string
+	Phone          string
+	Email          string
+	Address        string
+	Status         BorrowerStatus
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// BorrowerFilter selects borrowers by status, ordered by id.
+type BorrowerFilter struct {
+	Status  BorrowerStatus
+	AfterID string
+	Limit   int
+}
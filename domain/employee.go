@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrEmployeeNotFound  = errors.New("employee not found")
+	ErrEmployeeInactive  = errors.New("employee is not active")
+	ErrEmployeeForbidden = errors.New("employee role is not allowed to perform this action")
+	ErrEmployeeExists    = errors.New("employee already exists")
+)
+
+type EmployeeRole string
+
+const (
+	RoleFieldValidator EmployeeRole = "field_validator"
+	RoleFieldOfficer   EmployeeRole = "field_officer"
+	RoleAdmin          EmployeeRole = "admin"
+)
+
+type EmployeeStatus string
+
+const (
+	EmployeeActive   EmployeeStatus = "active"
+	EmployeeInactive EmployeeStatus = "inactive"
+)
+
+// EmployeeAction is a loan operation that only certain roles may perform.
+type EmployeeAction string
+
+const (
+	ActionApproveLoan  EmployeeAction = "approve_loan"
+	ActionRejectLoan   EmployeeAction = "reject_loan"
+	ActionDisburseLoan EmployeeAction = "disburse_loan"
+)
+
+// actionRoles lists the roles allowed to perform each action. Field
+// validators visit the borrower and approve or reject the loan; field
+// officers hand over the money with the signed agreement.
+var actionRoles = map[EmployeeAction][]EmployeeRole{
+	ActionApproveLoan:  {RoleFieldValidator, RoleAdmin},
+	ActionRejectLoan:   {RoleFieldValidator, RoleAdmin},
+	ActionDisburseLoan: {RoleFieldOfficer, RoleAdmin},
+}
+
+type Employee struct {
+	ID        string
+	Name      string
+	Role      EmployeeRole
+	Status    EmployeeStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CanPerform reports why the employee may not perform the action, or nil if
+// they may.
+func (e Employee) CanPerform(action EmployeeAction) error {
+	if e.Status != EmployeeActive {
+		return fmt.Errorf("%w: %s", ErrEmployeeInactive, e.ID)
+	}
+	if !slices.Contains(actionRoles[action], e.Role) {
+		return fmt.Errorf("%w: %s is %s, %s needs one of %v", ErrEmployeeForbidden, e.ID, e.Role, action, actionRoles[action])
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmployee_CanPerform(t *testing.T) {
+	tests := []struct {
+		name    string
+		e       Employee
+		action  EmployeeAction
+		wantErr error
+	}{
+		{"validator approves", Employee{Role: RoleFieldValidator, Status: EmployeeActive}, ActionApproveLoan, nil},
+		{"officer disburses", Employee{Role: RoleFieldOfficer, Status: EmployeeActive}, ActionDisburseLoan, nil},
+		{"admin approves", Employee{Role: RoleAdmin, Status: EmployeeActive}, ActionApproveLoan, nil},
+		{"admin disburses", Employee{Role: RoleAdmin, Status: EmployeeActive}, ActionDisburseLoan, nil},
+		{"validator rejects", Employee{Role: RoleFieldValidator, Status: EmployeeActive}, ActionRejectLoan, nil},
+		{"officer approves", Employee{Role: RoleFieldOfficer, Status: EmployeeActive}, ActionApproveLoan, ErrEmployeeForbidden},
+		{"officer rejects", Employee{Role: RoleFieldOfficer, Status: EmployeeActive}, ActionRejectLoan, ErrEmployeeForbidden},
+		{"validator disburses", Employee{Role: RoleFieldValidator, Status: EmployeeActive}, ActionDisburseLoan, ErrEmployeeForbidden},
+		{"inactive validator", Employee{Role: RoleFieldValidator, Status: EmployeeInactive}, ActionApproveLoan, ErrEmployeeInactive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.e.CanPerform(tt.action)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventName identifies the type of a domain event.
+type EventName string
+
+const (
+	EventLoanCreated           EventName = "loan.created"
+	EventLoanApproved          EventName = "loan.approved"
+	EventLoanRejected          EventName = "loan.rejected"
+	EventInvestmentAdded       EventName = "loan.investment_added"
+	EventLoanFullyFunded       EventName = "loan.fully_funded"
+	EventLoanDisbursed         EventName = "loan.disbursed"
+	EventLoanRepaymentRecorded EventName = "loan.repayment_recorded"
+	EventLoanCompleted         EventName = "loan.completed"
+	EventLoanExpired           EventName = "loan.expired"
+	EventLoanCancelled         EventName = "loan.cancelled"
+)
+
+// EventNames lists every event the loan lifecycle emits.
+var EventNames = []EventName{
+	EventLoanCreated,
+	EventLoanApproved,
+	EventLoanRejected,
+	EventInvestmentAdded,
+	EventLoanFullyFunded,
+	EventLoanDisbursed,
+	EventLoanRepaymentRecorded,
+	EventLoanCompleted,
+	EventLoanExpired,
+	EventLoanCancelled,
+}
+
+// Event is something that happened to a loan. Events are emitted inside the
+// transaction making the change.
+type Event interface {
+	EventName() EventName
+	// AggregateID is the ID of the loan the event belongs to.
+	AggregateID() int
+	OccurredAt() time.Time
+}
+
+// EventRecord is an event as streamed to other systems: its name, loan and
+// time, with the event itself as JSON in Data. ID is unique per event, so
+// consumers can drop the duplicates at-least-once delivery may cause.
+type EventRecord struct {
+	ID         int64           `json:"id"`
+	Name       EventName       `json:"name"`
+	LoanID     int             `json:"loan_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// NewEventRecord encodes e. The ID is left for the caller to assign.
+func NewEventRecord(e Event) (*EventRecord, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return &EventRecord{Name: e.EventName(), LoanID: e.AggregateID(), OccurredAt: e.OccurredAt(), Data: data}, nil
+}
+
+// LoanEvent holds what every loan event carries.
+type LoanEvent struct {
+	LoanID int       `json:"loan_id"`
+	At     time.Time `json:"occurred_at"`
+}
+
+func (e LoanEvent) AggregateID() int      { return e.LoanID }
+func (e LoanEvent) OccurredAt() time.Time { return e.At }
+
+// NewLoanEvent returns the common part of an event that happened to the
+// loan at the given time.
+func NewLoanEvent(loanID int, at time.Time) LoanEvent {
+	return LoanEvent{LoanID: loanID, At: at}
+}
+
+// InvestorAmount is what one investor has at stake in an event.
+type InvestorAmount struct {
+	InvestorEmail string `json:"investor_email"`
+	Amount        Money  `json:"amount"`
+}
+
+// InvestorAmounts adds up the investments of each investor, in the order
+// they first invested.
+func InvestorAmounts(investments []Investment) []InvestorAmount {
+	out := []InvestorAmount{}
+	for _, inv := range TotalsByInvestor(investments) {
+		out = append(out, InvestorAmount{InvestorEmail: inv.InvestorEmail, Amount: inv.Amount})
+	}
+	return out
+}
+
+type LoanCreated struct {
+	LoanEvent
+	BorrowerID      string          `json:"borrower_id"`
+	PrincipalAmount Money           `json:"principal_amount"`
+	Rate            float64         `json:"rate"`
+	ROI             float64         `json:"roi"`
+	TenorMonths     int             `json:"tenor_months"`
+	RepaymentMethod RepaymentMethod `json:"repayment_method"`
+}
+
+type LoanApproved struct {
+	LoanEvent
+	EmployeeID      string    `json:"employee_id"`
+	ApprovedAt      time.Time `json:"approved_at"`
+	FundingDeadline time.Time `json:"funding_deadline"`
+}
+
+type LoanRejected struct {
+	LoanEvent
+	EmployeeID string          `json:"employee_id"`
+	ReasonCode RejectionReason `json:"reason_code"`
+	RejectedAt time.Time       `json:"rejected_at"`
+}
+
+type InvestmentAdded struct {
+	LoanEvent
+	InvestorEmail string `json:"investor_email"`
+	Amount        Money  `json:"amount"`
+	TotalInvested Money  `json:"total_invested"`
+}
+
+// LoanFullyFunded is emitted with the investment that completes the
+// funding. Investors holds how much each investor put in.
+type LoanFullyFunded struct {
+	LoanEvent
+	PrincipalAmount Money            `json:"principal_amount"`
+	Investors       []InvestorAmount `json:"investors"`
+}
+
+type LoanDisbursed struct {
+	LoanEvent
+	EmployeeID    string    `json:"employee_id"`
+	DisbursedAt   time.Time `json:"disbursed_at"`
+	AgreementLink string    `json:"agreement_link"`
+}
+
+// LoanRepaymentRecorded is emitted for every borrower payment. LoanStatus
+// is the loan's status after the payment: the first one moves a disbursed
+// loan to repaying.
+type LoanRepaymentRecorded struct {
+	LoanEvent
+	RepaymentID int        `json:"repayment_id"`
+	Amount      Money      `json:"amount"`
+	Reference   string     `json:"reference,omitempty"`
+	PaidAt      time.Time  `json:"paid_at"`
+	LoanStatus  LoanStatus `json:"loan_status"`
+}
+
+// LoanCompleted is emitted when a payment settles the last instalment. It
+// follows the LoanRepaymentRecorded event of that payment.
+type LoanCompleted struct {
+	LoanEvent
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// LoanExpired is emitted when a loan missed its funding deadline.
+// Refunded holds how much was returned to each investor.
+type LoanExpired struct {
+	LoanEvent
+	FundingDeadline time.Time        `json:"funding_deadline"`
+	TotalInvested   Money            `json:"total_invested"`
+	Refunded        []InvestorAmount `json:"refunded"`
+}
+
+// LoanCancelled is emitted when a loan is withdrawn before disbursement.
+// Refunded holds how much was returned to each investor.
+type LoanCancelled struct {
+	LoanEvent
+	Actor    string           `json:"actor"`
+	Reason   string           `json:"reason"`
+	Refunded []InvestorAmount `json:"refunded"`
+}
+
+func (LoanCreated) EventName() EventName           { return EventLoanCreated }
+func (LoanApproved) EventName() EventName          { return EventLoanApproved }
+func (LoanRejected) EventName() EventName          { return EventLoanRejected }
+func (InvestmentAdded) EventName() EventName       { return EventInvestmentAdded }
+func (LoanFullyFunded) EventName() EventName       { return EventLoanFullyFunded }
+func (LoanDisbursed) EventName() EventName         { return EventLoanDisbursed }
+func (LoanRepaymentRecorded) EventName() EventName { return EventLoanRepaymentRecorded }
+func (LoanCompleted) EventName() EventName         { return EventLoanCompleted }
+func (LoanExpired) EventName() EventName           { return EventLoanExpired }
+func (LoanCancelled) EventName() EventName         { return EventLoanCancelled }
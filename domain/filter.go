@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+type LoanSortField string
+
+const (
+	SortByCreatedAt       LoanSortField = "created_at"
+	SortByPrincipalAmount LoanSortField = "principal_amount"
+	SortByRate            LoanSortField = "rate"
+	SortByID              LoanSortField = "id"
+)
+
+func (f LoanSortField) IsValid() bool {
+	switch f {
+	case SortByCreatedAt, SortByPrincipalAmount, SortByRate, SortByID:
+		return true
+	}
+	return false
+}
+
+// LoanFilter narrows and orders a loan listing. Nil bounds are not applied.
+type LoanFilter struct {
+	Statuses     []LoanStatus
+	BorrowerID   string
+	MinPrincipal *Money
+	MaxPrincipal *Money
+	MinRate      *float64
+	MaxRate      *float64
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	SortBy       LoanSortField
+	SortDesc     bool
+	Limit        int
+	After        *LoanCursor
+
+	// InvestorEmail limits the listing to loans open for funding and loans
+	// the investor has invested in.
+	InvestorEmail string
+}
+
+// LoanCursor marks the last row of a page for keyset pagination. It records
+// the sort it was produced for so it cannot be replayed against another one.
+type LoanCursor struct {
+	SortBy   LoanSortField `json:"s"`
+	SortDesc bool          `json:"d"`
+	Value    string        `json:"v"`
+	ID       int           `json:"i"`
+}
+
+// NewLoanCursor builds the cursor pointing after the given loan.
+func NewLoanCursor(l Loan, sortBy LoanSortField, desc bool) LoanCursor {
+	c := LoanCursor{SortBy: sortBy, SortDesc: desc, ID: l.ID}
+	switch sortBy {
+	case SortByCreatedAt:
+		c.Value = l.CreatedAt.Format(time.RFC3339Nano)
+	case SortByPrincipalAmount:
+		c.Value = l.PrincipalAmount.String()
+	case SortByRate:
+		c.Value = RatFromFloat(l.Rate).FloatString(4)
+	}
+	return c
+}
+
+func (c LoanCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeLoanCursor(s string) (*LoanCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	var c LoanCursor
+	if err := json.Unmarshal(b, &c); err != nil || !c.SortBy.IsValid() {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	return &c, nil
+}
+
+// InvestmentFilter selects investments by loan or investor, ordered by id.
+type InvestmentFilter struct {
+	LoanID        int
+	InvestorEmail string
+	AfterID       int
+	Limit         int
+}
+
+type idCursor struct {
+	ID int `json:"i"`
+}
+
+// EncodeIDCursor returns an opaque cursor pointing after the given id.
+func EncodeIDCursor(id int) string {
+	b, _ := json.Marshal(idCursor{ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeIDCursor(s string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	var c idCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID <= 0 {
+		return 0, fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	return c.ID, nil
+}
+
+type keyCursor struct {
+	Key string `json:"k"`
+}
+
+// EncodeKeyCursor returns an opaque cursor pointing after the given string key.
+func EncodeKeyCursor(key string) string {
+	b, _ := json.Marshal(keyCursor{Key: key})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeKeyCursor(s string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	var c keyCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Key == "" {
+		return "", fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	return c.Key, nil
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvestorNotVerified  = errors.New("investor is not KYC verified")
+	ErrInvestorExists       = errors.New("investor is already registered")
+	ErrInvalidKYCTransition = errors.New("invalid KYC status transition")
+)
+
+// KYCError is returned when an investor who has not passed KYC tries to
+// invest. It matches ErrInvestorNotVerified with errors.Is.
+type KYCError struct {
+	InvestorEmail string
+	// Status is empty when the investor is not registered at all.
+	Status KYCStatus
+}
+
+func (e *KYCError) Error() string {
+	if e.Status == "" {
+		return fmt.Sprintf("investor %s is not registered", e.InvestorEmail)
+	}
+	return fmt.Sprintf("investor %s is not KYC verified (status %q)", e.InvestorEmail, e.Status)
+}
+
+func (e *KYCError) Is(target error) bool {
+	return target == ErrInvestorNotVerified
+}
+
+type KYCStatus string
+
+const (
+	KYCPending   KYCStatus = "pending"
+	KYCVerified  KYCStatus = "verified"
+	KYCRejected  KYCStatus = "rejected"
+	KYCSuspended KYCStatus = "suspended"
+)
+
+// kycTransitions lists the review outcomes allowed from each KYC status.
+// Rejected investors may resubmit, which puts them back to pending.
+var kycTransitions = map[KYCStatus][]KYCStatus{
+	KYCPending:   {KYCVerified, KYCRejected},
+	KYCVerified:  {KYCSuspended},
+	KYCSuspended: {KYCVerified, KYCRejected},
+	KYCRejected:  {KYCPending},
+}
+
+// ValidateKYCTransition checks that a review may move an investor from one
+// KYC status to another.
+func ValidateKYCTransition(from, to KYCStatus) error {
+	for _, allowed := range kycTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: cannot move investor from %q to %q", ErrInvalidKYCTransition, from, to)
+}
+
+// Investor is a registered lender. Only investors with verified KYC may
+// invest in loans.
+type Investor struct {
+	Email          string
+	Name           string
+	Phone          string
+	IdentityNumber string
+	KYCStatus      KYCStatus
+	KYCNote        string
+	KYCReviewedBy  string
+	KYCReviewedAt  *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// CanInvest returns a *KYCError unless the investor's KYC is verified.
+func (i Investor) CanInvest() error {
+	if i.KYCStatus != KYCVerified {
+		return &KYCError{InvestorEmail: i.Email, Status: i.KYCStatus}
+	}
+	return nil
+}
+
+// InvestorFilter selects investors by KYC status, ordered by email.
+type InvestorFilter struct {
+	KYCStatus KYCStatus
+	AfterKey  string
+	Limit     int
+}
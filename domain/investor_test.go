@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKYCTransition(t *testing.T) {
+	assert.NoError(t, ValidateKYCTransition(KYCPending, KYCVerified))
+	assert.NoError(t, ValidateKYCTransition(KYCPending, KYCRejected))
+	assert.NoError(t, ValidateKYCTransition(KYCVerified, KYCSuspended))
+	assert.NoError(t, ValidateKYCTransition(KYCSuspended, KYCVerified))
+	assert.NoError(t, ValidateKYCTransition(KYCRejected, KYCPending))
+
+	assert.ErrorIs(t, ValidateKYCTransition(KYCRejected, KYCVerified), ErrInvalidKYCTransition)
+	assert.ErrorIs(t, ValidateKYCTransition(KYCVerified, KYCVerified), ErrInvalidKYCTransition)
+	assert.ErrorIs(t, ValidateKYCTransition(KYCPending, KYCSuspended), ErrInvalidKYCTransition)
+}
+
+func TestInvestor_CanInvest(t *testing.T) {
+	assert.NoError(t, Investor{Email: "a@a.com", KYCStatus: KYCVerified}.CanInvest())
+
+	for _, st := range []KYCStatus{KYCPending, KYCRejected, KYCSuspended} {
+		err := Investor{Email: "a@a.com", KYCStatus: st}.CanInvest()
+		assert.ErrorIs(t, err, ErrInvestorNotVerified)
+		var kycErr *KYCError
+		if assert.ErrorAs(t, err, &kycErr) {
+			assert.Equal(t, st, kycErr.Status)
+		}
+	}
+}
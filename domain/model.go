@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 type LoanStatus string
 
@@ -9,32 +12,126 @@ const (
 	StatusApproved  LoanStatus = "approved"
 	StatusInvested  LoanStatus = "invested"
 	StatusDisbursed LoanStatus = "disbursed"
+	StatusRejected  LoanStatus = "rejected"
+	StatusRepaying  LoanStatus = "repaying"
+	StatusCompleted LoanStatus = "completed"
+	StatusExpired   LoanStatus = "expired"
+	StatusCancelled LoanStatus = "cancelled"
+)
+
+// DefaultFundingPeriod is how long an approved loan stays open for investment
+// unless configured otherwise.
+const DefaultFundingPeriod = 30 * 24 * time.Hour
+
+var ErrFundingClosed = errors.New("loan funding deadline has passed")
+
+type RejectionReason string
+
+const (
+	ReasonIncompleteDocuments RejectionReason = "incomplete_documents"
+	ReasonFailedVerification  RejectionReason = "failed_verification"
+	ReasonPoorCreditHistory   RejectionReason = "poor_credit_history"
+	ReasonSuspectedFraud      RejectionReason = "suspected_fraud"
+	ReasonOther               RejectionReason = "other"
 )
 
 type Loan struct {
 	ID                  int
 	BorrowerID          string
-	PrincipalAmount     float64
+	PrincipalAmount     Money
 	Rate                float64
 	ROI                 float64
+	TenorMonths         int
+	RepaymentMethod     RepaymentMethod
 	Status              LoanStatus
 	AgreementLetterLink string
+	FundingDeadline     *time.Time
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 }
 
+// FundingClosed reports whether the loan's funding deadline has passed.
+// Loans without a deadline never close.
+func (l Loan) FundingClosed(now time.Time) bool {
+	return l.FundingDeadline != nil && !now.Before(*l.FundingDeadline)
+}
+
 type LoanApproval struct {
-	ID           int
-	LoanID       int
-	PictureProof string
-	EmployeeID   string
-	ApprovedAt   time.Time
+	ID     int
+	LoanID int
+	// PictureProofKey is the blob store key of the uploaded photo and
+	// PictureProofChecksum its hex encoded SHA-256.
+	PictureProofKey         string
+	PictureProofChecksum    string
+	PictureProofContentType string
+	EmployeeID              string
+	ApprovedAt              time.Time
+}
+
+type LoanRejection struct {
+	ID         int
+	LoanID     int
+	EmployeeID string
+	ReasonCode RejectionReason
+	Note       string
+	RejectedAt time.Time
+}
+
+type LoanStatusHistory struct {
+	ID         int
+	LoanID     int
+	FromStatus LoanStatus
+	ToStatus   LoanStatus
+	Actor      string
+	Metadata   map[string]any
+	ChangedAt  time.Time
 }
 
 type Investment struct {
 	ID            int
 	LoanID        int
 	InvestorEmail string
-	Amount        float64
+	Amount        Money
 	InvestedAt    time.Time
+	RefundedAt    *time.Time
+}
+
+// TotalsByInvestor adds up the investments of each investor, in the order
+// they first invested.
+func TotalsByInvestor(investments []Investment) []Investment {
+	var (
+		out   []Investment
+		index = map[string]int{}
+	)
+	for _, inv := range investments {
+		if i, ok := index[inv.InvestorEmail]; ok {
+			out[i].Amount = out[i].Amount.Add(inv.Amount)
+			continue
+		}
+		index[inv.InvestorEmail] = len(out)
+		out = append(out, inv)
+	}
+	return out
+}
+
+// InvestmentDetail is an investment joined with the loan it funds.
+type InvestmentDetail struct {
+	Investment
+	LoanStatus      LoanStatus
+	PrincipalAmount Money
+	ROI             float64
+}
+
+// IdempotencyRecord is a request stored under the Idempotency-Key its
+// caller sent. Keys are scoped to the caller, so clients cannot collide.
+type IdempotencyRecord struct {
+	Caller       string
+	Key          string
+	Method       string
+	Path         string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	Completed    bool
+	CreatedAt    time.Time
 }
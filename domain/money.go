@@ -0,0 +1,377 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type Currency string
+
+const CurrencyIDR Currency = "IDR"
+
+// DefaultCurrency is assumed when an amount is given without a currency.
+const DefaultCurrency = CurrencyIDR
+
+// minorUnitScale is the number of minor units per major unit. Every supported
+// currency is stored with two decimal places, matching NUMERIC(12,2).
+const (
+	minorUnitDigits = 2
+	minorUnitScale  = 100
+)
+
+// supportedCurrencies lists the currencies loans can be denominated in.
+var supportedCurrencies = map[Currency]bool{
+	CurrencyIDR: true,
+}
+
+func (c Currency) IsSupported() bool {
+	return supportedCurrencies[c]
+}
+
+var (
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrInvalidAmount       = errors.New("invalid money amount")
+	ErrSubCentAmount       = errors.New("money amount has more than 2 decimal places")
+	ErrCurrencyMismatch    = errors.New("money currency mismatch")
+)
+
+// RoundingMode decides how results that fall between two minor units are
+// brought back to a whole minor unit.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest minor unit, ties to the even one.
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest minor unit, ties away from zero.
+	RoundHalfUp
+	// RoundDown truncates towards zero.
+	RoundDown
+)
+
+// Money is an exact amount held as an integer number of minor units
+// (e.g. cents) together with its currency. Arithmetic never goes through
+// float64, so amounts that add up on paper add up here as well.
+//
+// Input is never rounded: parsing rejects anything finer than a minor unit.
+// Operations that can produce fractions of a minor unit (percentages, ratios)
+// take an explicit RoundingMode.
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func NewMoney(minor int64, currency Currency) Money {
+	return Money{Amount: minor, Currency: currency}
+}
+
+func Zero(currency Currency) Money {
+	return Money{Currency: currency}
+}
+
+// ParseMoney parses a decimal string such as "1500000" or "0.30". Values
+// with more than two decimal places are rejected rather than rounded.
+func ParseMoney(s string, currency Currency) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Money{}, ErrInvalidAmount
+	}
+
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" && frac == "" || hasFrac && frac == "" {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if len(frac) > minorUnitDigits {
+		if strings.TrimRight(frac[minorUnitDigits:], "0") != "" {
+			return Money{}, fmt.Errorf("%w: %q", ErrSubCentAmount, s)
+		}
+		frac = frac[:minorUnitDigits]
+	}
+	frac += strings.Repeat("0", minorUnitDigits-len(frac))
+	if whole == "" {
+		whole = "0"
+	}
+
+	for _, r := range whole + frac {
+		if r < '0' || r > '9' {
+			return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+	}
+
+	minor, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if neg {
+		minor = -minor
+	}
+
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// MustParseMoney is like ParseMoney but panics on invalid input. It is meant
+// for constants and tests.
+func MustParseMoney(s string, currency Currency) Money {
+	m, err := ParseMoney(s, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// String renders the amount as a decimal with exactly two places, without
+// the currency, e.g. "1500000.00".
+func (m Money) String() string {
+	sign := ""
+	minor := m.Amount
+	if minor < 0 {
+		sign = "-"
+	}
+	abs := new(big.Int).Abs(big.NewInt(minor))
+	q, r := new(big.Int).QuoRem(abs, big.NewInt(minorUnitScale), new(big.Int))
+	return fmt.Sprintf("%s%s.%0*d", sign, q.String(), minorUnitDigits, r.Int64())
+}
+
+// Format renders the amount for people to read, with its currency and
+// thousands separators, e.g. "IDR 1,500,000.00".
+func (m Money) Format() string {
+	s := m.String()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	return fmt.Sprintf("%s %s%s.%s", m.Currency, sign, whole, frac)
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// CheckCurrency returns ErrCurrencyMismatch when o is in another currency.
+// Callers validate currencies at the boundary; the arithmetic below assumes
+// they already agree.
+func (m Money) CheckCurrency(o Money) error {
+	if m.Currency != o.Currency {
+		return fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return nil
+}
+
+func (m Money) mustMatch(o Money) {
+	if err := m.CheckCurrency(o); err != nil {
+		panic(err)
+	}
+}
+
+func (m Money) Add(o Money) Money {
+	m.mustMatch(o)
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}
+}
+
+func (m Money) Sub(o Money) Money {
+	m.mustMatch(o)
+	return Money{Amount: m.Amount - o.Amount, Currency: m.Currency}
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or
+// greater than o.
+func (m Money) Cmp(o Money) int {
+	m.mustMatch(o)
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (m Money) Min(o Money) Money {
+	if m.Cmp(o) <= 0 {
+		return m
+	}
+	return o
+}
+
+// MulRat multiplies the amount by an exact ratio and rounds the result to a
+// whole minor unit using the given mode.
+func (m Money) MulRat(r *big.Rat, mode RoundingMode) Money {
+	x := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), r)
+	return Money{Amount: roundRat(x, mode), Currency: m.Currency}
+}
+
+// Percent returns pct percent of the amount, e.g. Percent(12.5, RoundHalfEven).
+func (m Money) Percent(pct float64, mode RoundingMode) Money {
+	r := RatFromFloat(pct)
+	return m.MulRat(r.Quo(r, big.NewRat(100, 1)), mode)
+}
+
+// Rat returns the amount in major units as an exact rational number.
+func (m Money) Rat() *big.Rat {
+	return big.NewRat(m.Amount, minorUnitScale)
+}
+
+// RatFromFloat converts a decimal-looking float such as a percentage rate
+// into an exact rational using its shortest decimal representation, so 0.1
+// becomes exactly 1/10 rather than the nearest binary fraction.
+func RatFromFloat(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
+
+func roundRat(x *big.Rat, mode RoundingMode) int64 {
+	num := new(big.Int).Set(x.Num())
+	den := x.Denom()
+
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 || mode == RoundDown {
+		return q.Int64()
+	}
+
+	// Compare twice the remainder with the denominator to find out whether
+	// we are below, at, or above the halfway point.
+	twice := new(big.Int).Abs(r)
+	twice.Lsh(twice, 1)
+	half := twice.Cmp(den)
+
+	away := half > 0
+	if half == 0 {
+		switch mode {
+		case RoundHalfUp:
+			away = true
+		case RoundHalfEven:
+			away = q.Bit(0) == 1
+		}
+	}
+	if away {
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q.Int64()
+}
+
+type moneyJSON struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency Currency        `json:"currency"`
+}
+
+// MarshalJSON encodes money as {"amount": "1500000.00", "currency": "IDR"}.
+// The amount is a string so that no JSON client parses it into a float.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string   `json:"amount"`
+		Currency Currency `json:"currency"`
+	}{m.String(), m.Currency})
+}
+
+// UnmarshalJSON accepts the object form produced by MarshalJSON as well as a
+// bare JSON number or string in the default currency. Numbers are parsed from
+// their literal text, never through float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	raw := json.RawMessage(data)
+	currency := DefaultCurrency
+
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		var obj moneyJSON
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		raw = obj.Amount
+		if obj.Currency != "" {
+			currency = Currency(strings.ToUpper(string(obj.Currency)))
+		}
+		if !currency.IsSupported() {
+			return fmt.Errorf("%w: %q", ErrUnsupportedCurrency, obj.Currency)
+		}
+	}
+
+	text := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return err
+		}
+	}
+	if strings.ContainsAny(text, "eE") {
+		return fmt.Errorf("%w: exponent notation is not accepted", ErrInvalidAmount)
+	}
+
+	parsed, err := ParseMoney(text, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value stores the amount as a decimal string so NUMERIC columns receive the
+// exact value. The currency is persisted in its own column.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads a NUMERIC column. The currency is left untouched, or set to the
+// default when empty, so it can be scanned from a separate column.
+func (m *Money) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return ErrInvalidAmount
+		}
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		text = "0"
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+
+	currency := m.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	parsed, err := ParseMoney(text, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
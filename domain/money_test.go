@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr error
+	}{
+		{"1500000", 150000000, nil},
+		{"0.1", 10, nil},
+		{"0.30", 30, nil},
+		{".5", 50, nil},
+		{"12.500", 1250, nil},
+		{"-3.25", -325, nil},
+		{"0.001", 0, ErrSubCentAmount},
+		{"1.005", 0, ErrSubCentAmount},
+		{"", 0, ErrInvalidAmount},
+		{"1.", 0, ErrInvalidAmount},
+		{"abc", 0, ErrInvalidAmount},
+		{"1,000", 0, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			m, err := ParseMoney(tt.in, CurrencyIDR)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, m.Amount)
+			assert.Equal(t, CurrencyIDR, m.Currency)
+		})
+	}
+}
+
+func TestMoney_ExactAddition(t *testing.T) {
+	a := MustParseMoney("0.1", CurrencyIDR)
+	b := MustParseMoney("0.2", CurrencyIDR)
+
+	assert.Equal(t, 0, a.Add(b).Cmp(MustParseMoney("0.3", CurrencyIDR)))
+	assert.Equal(t, "0.30", a.Add(b).String())
+	assert.Equal(t, "-0.10", a.Sub(b).String())
+}
+
+func TestMoney_Format(t *testing.T) {
+	assert.Equal(t, "IDR 0.50", MustParseMoney("0.5", CurrencyIDR).Format())
+	assert.Equal(t, "IDR 999.00", MustParseMoney("999", CurrencyIDR).Format())
+	assert.Equal(t, "IDR 1,500,000.00", MustParseMoney("1500000", CurrencyIDR).Format())
+	assert.Equal(t, "IDR -12,345.67", MustParseMoney("-12345.67", CurrencyIDR).Format())
+}
+
+func TestMoney_CurrencyMismatch(t *testing.T) {
+	a := MustParseMoney("1", CurrencyIDR)
+	b := MustParseMoney("1", Currency("USD"))
+
+	assert.ErrorIs(t, a.CheckCurrency(b), ErrCurrencyMismatch)
+	assert.Panics(t, func() { a.Add(b) })
+}
+
+func TestMoney_Rounding(t *testing.T) {
+	m := NewMoney(5, CurrencyIDR) // 0.05
+
+	half := big.NewRat(1, 2)
+	assert.Equal(t, int64(2), m.MulRat(half, RoundHalfEven).Amount)
+	assert.Equal(t, int64(3), m.MulRat(half, RoundHalfUp).Amount)
+	assert.Equal(t, int64(2), m.MulRat(half, RoundDown).Amount)
+
+	neg := NewMoney(-5, CurrencyIDR)
+	assert.Equal(t, int64(-2), neg.MulRat(half, RoundHalfEven).Amount)
+	assert.Equal(t, int64(-3), neg.MulRat(half, RoundHalfUp).Amount)
+
+	principal := MustParseMoney("1000000", CurrencyIDR)
+	assert.Equal(t, "125000.00", principal.Percent(12.5, RoundHalfEven).String())
+	assert.Equal(t, "3.33", MustParseMoney("10", CurrencyIDR).MulRat(big.NewRat(1, 3), RoundHalfEven).String())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	out, err := json.Marshal(MustParseMoney("1500000.5", CurrencyIDR))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"1500000.50","currency":"IDR"}`, string(out))
+
+	var m Money
+	require.NoError(t, json.Unmarshal(out, &m))
+	assert.Equal(t, int64(150000050), m.Amount)
+
+	require.NoError(t, json.Unmarshal([]byte(`0.3`), &m))
+	assert.Equal(t, int64(30), m.Amount)
+	assert.Equal(t, DefaultCurrency, m.Currency)
+
+	require.NoError(t, json.Unmarshal([]byte(`"250000.25"`), &m))
+	assert.Equal(t, int64(25000025), m.Amount)
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`0.001`), &m), ErrSubCentAmount)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`1e3`), &m), ErrInvalidAmount)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"amount":"1","currency":"XYZ"}`), &m), ErrUnsupportedCurrency)
+}
+
+func TestMoney_Scan(t *testing.T) {
+	var m Money
+	require.NoError(t, m.Scan([]byte("1234.50")))
+	assert.Equal(t, int64(123450), m.Amount)
+	assert.Equal(t, DefaultCurrency, m.Currency)
+
+	v, err := m.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "1234.50", v)
+}
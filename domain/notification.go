@@ -0,0 +1,24 @@
+package domain
+
+type NotificationKind string
+
+const (
+	// NotifyLoanFunded tells investors the loan they invested in is fully
+	// funded.
+	NotifyLoanFunded NotificationKind = "loan_funded"
+	// NotifyLoanExpired tells investors the loan missed its funding deadline
+	// and their money was returned.
+	NotifyLoanExpired NotificationKind = "loan_expired"
+	// NotifyLoanCancelled tells investors the loan was cancelled before
+	// disbursement and their money was returned.
+	NotifyLoanCancelled NotificationKind = "loan_cancelled"
+)
+
+// Notification is a message to one recipient. Data fills in the template of
+// its kind; it is queued in the outbox as JSON, so it should hold plain
+// strings and numbers.
+type Notification struct {
+	Kind NotificationKind `json:"kind"`
+	To   string           `json:"to"`
+	Data map[string]any   `json:"data"`
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxTopic names the kind of an outbox message; the dispatcher hands each
+// message to the handler registered for its topic.
+type OutboxTopic string
+
+const (
+	// TopicNotification carries a Notification to send.
+	TopicNotification OutboxTopic = "notification"
+	// TopicWebhook carries a WebhookDeliveryJob.
+	TopicWebhook OutboxTopic = "webhook"
+	// TopicEvent carries an EventRecord to stream.
+	TopicEvent OutboxTopic = "event"
+)
+
+// WebhookDeliveryJob asks the dispatcher to attempt a webhook delivery.
+type WebhookDeliveryJob struct {
+	DeliveryID int `json:"delivery_id"`
+}
+
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending"
+	OutboxDone    OutboxStatus = "done"
+	// OutboxDead marks a message that failed permanently or ran out of
+	// attempts. It is kept for inspection and never delivered again.
+	OutboxDead OutboxStatus = "dead"
+)
+
+// OutboxMessage is a side effect recorded in the same transaction as the
+// change causing it, and carried out by the outbox dispatcher once that
+// transaction has committed. Messages sharing a topic and a non-empty Key,
+// such as the events of one loan, are delivered in the order they were
+// written; a message that keeps failing holds back the later ones until it
+// is delivered or dead-lettered.
+type OutboxMessage struct {
+	ID            int64
+	Topic         OutboxTopic
+	Key           string
+	Payload       json.RawMessage
+	Status        OutboxStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	ProcessedAt   *time.Time
+}
+
+// NewOutboxMessage builds a pending message with payload encoded as JSON.
+func NewOutboxMessage(topic OutboxTopic, key string, payload any, now time.Time) (*OutboxMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxMessage{
+		Topic:         topic,
+		Key:           key,
+		Payload:       data,
+		Status:        OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
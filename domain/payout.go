@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+var ErrNoInvestments = errors.New("loan has no investments to distribute to")
+
+// InvestorPayout is one investor's share of a repayment: the principal
+// returned to them and the return earned at the loan's ROI.
+type InvestorPayout struct {
+	ID            int
+	RepaymentID   int
+	InvestmentID  int
+	LoanID        int
+	InvestorEmail string
+	Principal     Money
+	Return        Money
+	CreatedAt     time.Time
+}
+
+// Distribution is how a repayment is shared between investors and the
+// platform.
+type Distribution struct {
+	Payouts        []InvestorPayout
+	PlatformMargin Money
+}
+
+// DistributeRepayment splits the principal and interest of a repayment across
+// the loan's investments in proportion to their amounts.
+//
+// The borrower pays interest at Rate while investors earn ROI, so investors
+// receive ROI/Rate of the interest and the platform keeps the spread along
+// with all fees. The investor pool is rounded down, so rounding dust from the
+// spread always stays with the platform. An ROI above Rate is capped: the
+// platform never pays out more interest than it collected. Excess payments
+// are not distributed.
+func DistributeRepayment(loan Loan, r Repayment, investments []Investment) (*Distribution, error) {
+	if len(investments) == 0 {
+		return nil, ErrNoInvestments
+	}
+
+	share := big.NewRat(0, 1)
+	if loan.Rate > 0 {
+		share = new(big.Rat).Quo(RatFromFloat(loan.ROI), RatFromFloat(loan.Rate))
+		if share.Cmp(big.NewRat(1, 1)) > 0 {
+			share = big.NewRat(1, 1)
+		}
+	}
+	investorReturn := r.Interest.MulRat(share, RoundDown)
+
+	weights := make([]Money, len(investments))
+	for i, inv := range investments {
+		if err := inv.Amount.CheckCurrency(r.Amount); err != nil {
+			return nil, err
+		}
+		weights[i] = inv.Amount
+	}
+
+	principal, err := SplitProRata(r.Principal, weights)
+	if err != nil {
+		return nil, err
+	}
+	returns, err := SplitProRata(investorReturn, weights)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Distribution{
+		Payouts:        make([]InvestorPayout, len(investments)),
+		PlatformMargin: r.Interest.Sub(investorReturn).Add(r.Fee),
+	}
+	for i, inv := range investments {
+		d.Payouts[i] = InvestorPayout{
+			RepaymentID:   r.ID,
+			InvestmentID:  inv.ID,
+			LoanID:        inv.LoanID,
+			InvestorEmail: inv.InvestorEmail,
+			Principal:     principal[i],
+			Return:        returns[i],
+		}
+	}
+	return d, nil
+}
+
+// SplitProRata divides total into parts proportional to weights using the
+// largest remainder method: every part is first rounded down, then the minor
+// units left over go one each to the parts with the largest discarded
+// fractions. Ties go to the earlier weight, so the result only depends on the
+// order of the weights and the parts always add up to total exactly.
+func SplitProRata(total Money, weights []Money) ([]Money, error) {
+	sum := Zero(total.Currency)
+	for _, w := range weights {
+		if w.IsNegative() {
+			return nil, fmt.Errorf("%w: negative weight", ErrInvalidAmount)
+		}
+		sum = sum.Add(w)
+	}
+	if !sum.IsPositive() {
+		return nil, fmt.Errorf("%w: weights must add up to a positive amount", ErrInvalidAmount)
+	}
+
+	type remainder struct {
+		idx  int
+		frac *big.Rat
+	}
+	parts := make([]Money, len(weights))
+	rems := make([]remainder, len(weights))
+	allocated := Zero(total.Currency)
+	for i, w := range weights {
+		exact := new(big.Rat).Mul(big.NewRat(total.Amount, 1), big.NewRat(w.Amount, sum.Amount))
+		parts[i] = Money{Amount: roundRat(exact, RoundDown), Currency: total.Currency}
+		rems[i] = remainder{idx: i, frac: exact.Sub(exact, big.NewRat(parts[i].Amount, 1))}
+		allocated = allocated.Add(parts[i])
+	}
+
+	sort.SliceStable(rems, func(a, b int) bool {
+		return rems[a].frac.Cmp(rems[b].frac) > 0
+	})
+	left := total.Sub(allocated).Amount
+	for i := int64(0); i < left; i++ {
+		parts[rems[i].idx].Amount++
+	}
+	return parts, nil
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func idr(s string) Money {
+	return MustParseMoney(s, CurrencyIDR)
+}
+
+func TestSplitProRata_DistributesRemainderDeterministically(t *testing.T) {
+	parts, err := SplitProRata(idr("100.00"), []Money{idr("1"), idr("1"), idr("1")})
+	require.NoError(t, err)
+
+	// 10000 minor units over three equal weights: the single leftover unit
+	// goes to the first weight.
+	assert.Equal(t, []string{"33.34", "33.33", "33.33"}, []string{parts[0].String(), parts[1].String(), parts[2].String()})
+}
+
+func TestSplitProRata_LargestRemainderWins(t *testing.T) {
+	parts, err := SplitProRata(idr("0.10"), []Money{idr("1"), idr("2"), idr("4")})
+	require.NoError(t, err)
+
+	// Exact shares are 1.43, 2.86 and 5.71 cents.
+	assert.Equal(t, "0.01", parts[0].String())
+	assert.Equal(t, "0.03", parts[1].String())
+	assert.Equal(t, "0.06", parts[2].String())
+}
+
+func TestSplitProRata_RejectsZeroWeights(t *testing.T) {
+	_, err := SplitProRata(idr("1"), []Money{Zero(CurrencyIDR)})
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestDistributeRepayment(t *testing.T) {
+	loan := Loan{ID: 1, Rate: 12, ROI: 8}
+	r := Repayment{
+		Amount:    idr("101000.01"),
+		Principal: idr("100000.00"),
+		Interest:  idr("1000.01"),
+		Fee:       idr("0"),
+	}
+	investments := []Investment{
+		{ID: 1, LoanID: 1, InvestorEmail: "a@example.com", Amount: idr("100000")},
+		{ID: 2, LoanID: 1, InvestorEmail: "b@example.com", Amount: idr("200000")},
+	}
+
+	d, err := DistributeRepayment(loan, r, investments)
+	require.NoError(t, err)
+	require.Len(t, d.Payouts, 2)
+
+	// Investors get 8/12 of 1000.01 = 666.673..., rounded down to 666.67.
+	assert.Equal(t, "33333.33", d.Payouts[0].Principal.String())
+	assert.Equal(t, "66666.67", d.Payouts[1].Principal.String())
+	assert.Equal(t, "222.22", d.Payouts[0].Return.String())
+	assert.Equal(t, "444.45", d.Payouts[1].Return.String())
+	assert.Equal(t, "333.34", d.PlatformMargin.String())
+
+	total := d.PlatformMargin
+	for _, p := range d.Payouts {
+		total = total.Add(p.Principal).Add(p.Return)
+	}
+	assert.Equal(t, 0, total.Cmp(r.Principal.Add(r.Interest)))
+}
+
+func TestDistributeRepayment_CapsROIAtRate(t *testing.T) {
+	loan := Loan{Rate: 10, ROI: 15}
+	r := Repayment{Amount: idr("100"), Principal: idr("0"), Interest: idr("100"), Fee: idr("0")}
+
+	d, err := DistributeRepayment(loan, r, []Investment{{ID: 1, Amount: idr("50")}})
+	require.NoError(t, err)
+	assert.Equal(t, "100.00", d.Payouts[0].Return.String())
+	assert.True(t, d.PlatformMargin.IsZero())
+}
+
+func TestDistributeRepayment_NoInvestments(t *testing.T) {
+	_, err := DistributeRepayment(Loan{Rate: 10}, Repayment{Amount: idr("1")}, nil)
+	assert.ErrorIs(t, err, ErrNoInvestments)
+}
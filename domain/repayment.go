@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrRepaymentNotAllowed = errors.New("loan does not accept repayments")
+
+// AllocationComponent is one part of an instalment a payment can settle.
+type AllocationComponent string
+
+const (
+	ComponentFee       AllocationComponent = "fee"
+	ComponentInterest  AllocationComponent = "interest"
+	ComponentPrincipal AllocationComponent = "principal"
+)
+
+// Waterfall is the order in which a payment settles the components of each
+// instalment. Instalments themselves are always settled oldest first.
+type Waterfall []AllocationComponent
+
+var DefaultWaterfall = Waterfall{ComponentFee, ComponentInterest, ComponentPrincipal}
+
+// ParseWaterfall validates a configured order. It must name every component
+// exactly once; "fees" is accepted as an alias for "fee".
+func ParseWaterfall(names []string) (Waterfall, error) {
+	if len(names) == 0 {
+		return DefaultWaterfall, nil
+	}
+
+	seen := map[AllocationComponent]bool{}
+	w := make(Waterfall, 0, len(names))
+	for _, name := range names {
+		c := AllocationComponent(name)
+		if name == "fees" {
+			c = ComponentFee
+		}
+		switch c {
+		case ComponentFee, ComponentInterest, ComponentPrincipal:
+		default:
+			return nil, fmt.Errorf("unknown waterfall component %q", name)
+		}
+		if seen[c] {
+			return nil, fmt.Errorf("waterfall component %q listed twice", name)
+		}
+		seen[c] = true
+		w = append(w, c)
+	}
+	if len(w) != len(DefaultWaterfall) {
+		return nil, fmt.Errorf("waterfall must list fee, interest and principal")
+	}
+	return w, nil
+}
+
+type RepaymentAllocation struct {
+	ID            int
+	RepaymentID   int
+	InstallmentID int
+	Component     AllocationComponent
+	Amount        Money
+}
+
+type Repayment struct {
+	ID             int
+	LoanID         int
+	Amount         Money
+	Principal      Money
+	Interest       Money
+	Fee            Money
+	Excess         Money
+	PlatformMargin Money
+	Reference      string
+	PaidAt         time.Time
+	CreatedAt      time.Time
+	Allocations    []RepaymentAllocation
+	Payouts        []InvestorPayout
+}
+
+// Outstanding returns what is still owed on the instalment for a component.
+func (i Installment) Outstanding(c AllocationComponent) Money {
+	switch c {
+	case ComponentFee:
+		return i.FeeDue.Sub(i.FeePaid)
+	case ComponentInterest:
+		return i.InterestDue.Sub(i.InterestPaid)
+	default:
+		return i.PrincipalDue.Sub(i.PrincipalPaid)
+	}
+}
+
+func (i *Installment) pay(c AllocationComponent, m Money) {
+	switch c {
+	case ComponentFee:
+		i.FeePaid = i.FeePaid.Add(m)
+	case ComponentInterest:
+		i.InterestPaid = i.InterestPaid.Add(m)
+	default:
+		i.PrincipalPaid = i.PrincipalPaid.Add(m)
+	}
+}
+
+func (i Installment) IsSettled() bool {
+	return i.FeePaid.Cmp(i.FeeDue) >= 0 &&
+		i.InterestPaid.Cmp(i.InterestDue) >= 0 &&
+		i.PrincipalPaid.Cmp(i.PrincipalDue) >= 0
+}
+
+func (i *Installment) refreshStatus() {
+	switch {
+	case i.IsSettled():
+		i.Status = InstallmentPaid
+	case i.FeePaid.IsPositive() || i.InterestPaid.IsPositive() || i.PrincipalPaid.IsPositive():
+		i.Status = InstallmentPartial
+	default:
+		i.Status = InstallmentPending
+	}
+}
+
+// ScheduleSettled reports whether every instalment is fully paid.
+func ScheduleSettled(installments []Installment) bool {
+	for _, inst := range installments {
+		if !inst.IsSettled() {
+			return false
+		}
+	}
+	return true
+}
+
+// AllocateRepayment applies a payment to the instalments, oldest first, and
+// within each instalment in waterfall order. It updates the instalments in
+// place and returns the repayment broken down per component. Whatever is left
+// once every instalment is settled is reported as excess.
+func AllocateRepayment(installments []Installment, amount Money, waterfall Waterfall) (*Repayment, []int, error) {
+	if !amount.IsPositive() {
+		return nil, nil, fmt.Errorf("%w: repayment amount must be positive", ErrInvalidAmount)
+	}
+
+	zero := Zero(amount.Currency)
+	r := &Repayment{
+		Amount:         amount,
+		Principal:      zero,
+		Interest:       zero,
+		Fee:            zero,
+		Excess:         zero,
+		PlatformMargin: zero,
+	}
+
+	var touched []int
+	remaining := amount
+	for idx := range installments {
+		if !remaining.IsPositive() {
+			break
+		}
+		inst := &installments[idx]
+		if inst.IsSettled() {
+			continue
+		}
+		if err := inst.PrincipalDue.CheckCurrency(amount); err != nil {
+			return nil, nil, err
+		}
+
+		for _, c := range waterfall {
+			due := inst.Outstanding(c)
+			if !due.IsPositive() || !remaining.IsPositive() {
+				continue
+			}
+			paid := due.Min(remaining)
+			inst.pay(c, paid)
+			remaining = remaining.Sub(paid)
+
+			switch c {
+			case ComponentFee:
+				r.Fee = r.Fee.Add(paid)
+			case ComponentInterest:
+				r.Interest = r.Interest.Add(paid)
+			default:
+				r.Principal = r.Principal.Add(paid)
+			}
+			r.Allocations = append(r.Allocations, RepaymentAllocation{
+				InstallmentID: inst.ID,
+				Component:     c,
+				Amount:        paid,
+			})
+		}
+		inst.refreshStatus()
+		touched = append(touched, idx)
+	}
+
+	r.Excess = remaining
+	return r, touched, nil
+}
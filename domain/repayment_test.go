@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInstallments() []Installment {
+	zero := Zero(CurrencyIDR)
+	mk := func(id int, principal, interest, fee string) Installment {
+		return Installment{
+			ID:            id,
+			Number:        id,
+			PrincipalDue:  MustParseMoney(principal, CurrencyIDR),
+			InterestDue:   MustParseMoney(interest, CurrencyIDR),
+			FeeDue:        MustParseMoney(fee, CurrencyIDR),
+			PrincipalPaid: zero,
+			InterestPaid:  zero,
+			FeePaid:       zero,
+			Status:        InstallmentPending,
+		}
+	}
+	return []Installment{
+		mk(1, "100000", "10000", "5000"),
+		mk(2, "100000", "10000", "5000"),
+	}
+}
+
+func TestAllocateRepayment_Partial(t *testing.T) {
+	insts := testInstallments()
+
+	r, touched, err := AllocateRepayment(insts, MustParseMoney("12000", CurrencyIDR), DefaultWaterfall)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{0}, touched)
+	assert.Equal(t, "5000.00", r.Fee.String())
+	assert.Equal(t, "7000.00", r.Interest.String())
+	assert.Equal(t, "0.00", r.Principal.String())
+	assert.True(t, r.Excess.IsZero())
+	assert.Equal(t, InstallmentPartial, insts[0].Status)
+	assert.Equal(t, "3000.00", insts[0].Outstanding(ComponentInterest).String())
+	assert.Equal(t, InstallmentPending, insts[1].Status)
+}
+
+func TestAllocateRepayment_SpillsIntoNextInstallment(t *testing.T) {
+	insts := testInstallments()
+
+	r, touched, err := AllocateRepayment(insts, MustParseMoney("120000", CurrencyIDR), DefaultWaterfall)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{0, 1}, touched)
+	assert.Equal(t, InstallmentPaid, insts[0].Status)
+	assert.Equal(t, InstallmentPartial, insts[1].Status)
+	assert.Equal(t, "5000.00", insts[1].FeePaid.String())
+	assert.Len(t, r.Allocations, 4)
+	assert.Equal(t, 2, r.Allocations[3].InstallmentID)
+	assert.Equal(t, ComponentFee, r.Allocations[3].Component)
+}
+
+func TestAllocateRepayment_Overpayment(t *testing.T) {
+	insts := testInstallments()
+
+	r, _, err := AllocateRepayment(insts, MustParseMoney("250000", CurrencyIDR), DefaultWaterfall)
+	require.NoError(t, err)
+
+	assert.True(t, ScheduleSettled(insts))
+	assert.Equal(t, "200000.00", r.Principal.String())
+	assert.Equal(t, "20000.00", r.Interest.String())
+	assert.Equal(t, "10000.00", r.Fee.String())
+	assert.Equal(t, "20000.00", r.Excess.String())
+}
+
+func TestAllocateRepayment_CustomWaterfall(t *testing.T) {
+	insts := testInstallments()
+	w, err := ParseWaterfall([]string{"principal", "interest", "fees"})
+	require.NoError(t, err)
+
+	r, _, err := AllocateRepayment(insts, MustParseMoney("105000", CurrencyIDR), w)
+	require.NoError(t, err)
+
+	assert.Equal(t, "100000.00", r.Principal.String())
+	assert.Equal(t, "5000.00", r.Interest.String())
+	assert.True(t, r.Fee.IsZero())
+}
+
+func TestAllocateRepayment_RejectsNonPositive(t *testing.T) {
+	_, _, err := AllocateRepayment(testInstallments(), Zero(CurrencyIDR), DefaultWaterfall)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestParseWaterfall(t *testing.T) {
+	w, err := ParseWaterfall(nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultWaterfall, w)
+
+	_, err = ParseWaterfall([]string{"interest", "principal"})
+	assert.Error(t, err)
+	_, err = ParseWaterfall([]string{"interest", "interest", "fee"})
+	assert.Error(t, err)
+	_, err = ParseWaterfall([]string{"interest", "penalty", "fee"})
+	assert.Error(t, err)
+}
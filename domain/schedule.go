@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+type RepaymentMethod string
+
+const (
+	// RepaymentFlat charges interest on the original principal every month
+	// and repays the principal in equal parts.
+	RepaymentFlat RepaymentMethod = "flat"
+	// RepaymentAnnuity charges interest on the outstanding balance and keeps
+	// every instalment the same size (effective rate).
+	RepaymentAnnuity RepaymentMethod = "annuity"
+	// RepaymentBullet charges interest monthly and repays the whole
+	// principal with the last instalment.
+	RepaymentBullet RepaymentMethod = "bullet"
+)
+
+const DefaultRepaymentMethod = RepaymentFlat
+
+func (m RepaymentMethod) IsValid() bool {
+	switch m {
+	case RepaymentFlat, RepaymentAnnuity, RepaymentBullet:
+		return true
+	}
+	return false
+}
+
+var ErrInvalidSchedule = errors.New("cannot generate repayment schedule")
+
+type InstallmentStatus string
+
+const (
+	InstallmentPending InstallmentStatus = "pending"
+	InstallmentPartial InstallmentStatus = "partial"
+	InstallmentPaid    InstallmentStatus = "paid"
+)
+
+type Installment struct {
+	ID            int
+	LoanID        int
+	Number        int
+	DueDate       time.Time
+	PrincipalDue  Money
+	InterestDue   Money
+	FeeDue        Money
+	PrincipalPaid Money
+	InterestPaid  Money
+	FeePaid       Money
+	Status        InstallmentStatus
+}
+
+// TotalDue is what the borrower owes for this instalment in total.
+func (i Installment) TotalDue() Money {
+	return i.PrincipalDue.Add(i.InterestDue).Add(i.FeeDue)
+}
+
+// GenerateSchedule splits a loan into monthly instalments starting one month
+// after disbursement. Rate is the annual interest rate in percent. Interest
+// amounts are rounded half-even to the minor unit; principal rounding
+// differences are absorbed by the last instalment so the principal parts
+// always add up to the loan principal exactly.
+func GenerateSchedule(loan Loan, disbursedAt time.Time) ([]Installment, error) {
+	n := loan.TenorMonths
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: tenor must be at least one month", ErrInvalidSchedule)
+	}
+	if !loan.PrincipalAmount.IsPositive() {
+		return nil, fmt.Errorf("%w: principal must be positive", ErrInvalidSchedule)
+	}
+
+	monthlyRate := RatFromFloat(loan.Rate)
+	monthlyRate.Quo(monthlyRate, big.NewRat(1200, 1))
+
+	var (
+		principal []Money
+		interest  []Money
+	)
+	switch loan.RepaymentMethod {
+	case RepaymentFlat:
+		principal, interest = flatSchedule(loan.PrincipalAmount, monthlyRate, n)
+	case RepaymentAnnuity:
+		principal, interest = annuitySchedule(loan.PrincipalAmount, monthlyRate, n)
+	case RepaymentBullet:
+		principal, interest = bulletSchedule(loan.PrincipalAmount, monthlyRate, n)
+	default:
+		return nil, fmt.Errorf("%w: unknown repayment method %q", ErrInvalidSchedule, loan.RepaymentMethod)
+	}
+
+	zero := Zero(loan.PrincipalAmount.Currency)
+	installments := make([]Installment, n)
+	for i := range installments {
+		installments[i] = Installment{
+			LoanID:        loan.ID,
+			Number:        i + 1,
+			DueDate:       AddMonths(disbursedAt, i+1),
+			PrincipalDue:  principal[i],
+			InterestDue:   interest[i],
+			FeeDue:        zero,
+			PrincipalPaid: zero,
+			InterestPaid:  zero,
+			FeePaid:       zero,
+			Status:        InstallmentPending,
+		}
+	}
+	return installments, nil
+}
+
+func flatSchedule(p Money, monthlyRate *big.Rat, n int) ([]Money, []Money) {
+	principal := make([]Money, n)
+	interest := make([]Money, n)
+
+	part := p.MulRat(big.NewRat(1, int64(n)), RoundDown)
+	monthlyInterest := p.MulRat(monthlyRate, RoundHalfEven)
+	remaining := p
+	for i := 0; i < n; i++ {
+		principal[i] = part
+		if i == n-1 {
+			principal[i] = remaining
+		}
+		remaining = remaining.Sub(principal[i])
+		interest[i] = monthlyInterest
+	}
+	return principal, interest
+}
+
+func annuitySchedule(p Money, monthlyRate *big.Rat, n int) ([]Money, []Money) {
+	if monthlyRate.Sign() == 0 {
+		return flatSchedule(p, monthlyRate, n)
+	}
+
+	principal := make([]Money, n)
+	interest := make([]Money, n)
+
+	// payment = P * r / (1 - (1+r)^-n), computed exactly and rounded once.
+	growth := new(big.Rat).Add(big.NewRat(1, 1), monthlyRate)
+	compound := big.NewRat(1, 1)
+	for i := 0; i < n; i++ {
+		compound.Mul(compound, growth)
+	}
+	discount := new(big.Rat).Sub(big.NewRat(1, 1), new(big.Rat).Inv(compound))
+	payment := p.MulRat(new(big.Rat).Quo(monthlyRate, discount), RoundHalfUp)
+
+	balance := p
+	for i := 0; i < n; i++ {
+		interest[i] = balance.MulRat(monthlyRate, RoundHalfEven)
+		principal[i] = payment.Sub(interest[i])
+		if i == n-1 || principal[i].Cmp(balance) > 0 {
+			principal[i] = balance
+		}
+		balance = balance.Sub(principal[i])
+	}
+	return principal, interest
+}
+
+func bulletSchedule(p Money, monthlyRate *big.Rat, n int) ([]Money, []Money) {
+	principal := make([]Money, n)
+	interest := make([]Money, n)
+
+	monthlyInterest := p.MulRat(monthlyRate, RoundHalfEven)
+	for i := 0; i < n; i++ {
+		principal[i] = Zero(p.Currency)
+		interest[i] = monthlyInterest
+	}
+	principal[n-1] = p
+	return principal, interest
+}
+
+// AddMonths moves t forward by the given number of calendar months, clamping
+// to the last day of the target month (Jan 31 + 1 month = Feb 28/29).
+func AddMonths(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	if d > last {
+		d = last
+	}
+	return time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, t.Location())
+}
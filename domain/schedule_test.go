@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sumPrincipal(insts []Installment) Money {
+	total := Zero(CurrencyIDR)
+	for _, i := range insts {
+		total = total.Add(i.PrincipalDue)
+	}
+	return total
+}
+
+func TestGenerateSchedule_Flat(t *testing.T) {
+	loan := Loan{
+		ID:              1,
+		PrincipalAmount: MustParseMoney("1000000", CurrencyIDR),
+		Rate:            12,
+		TenorMonths:     3,
+		RepaymentMethod: RepaymentFlat,
+	}
+	disbursed := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	insts, err := GenerateSchedule(loan, disbursed)
+	require.NoError(t, err)
+	require.Len(t, insts, 3)
+
+	assert.Equal(t, "333333.33", insts[0].PrincipalDue.String())
+	assert.Equal(t, "333333.34", insts[2].PrincipalDue.String())
+	assert.Equal(t, "10000.00", insts[0].InterestDue.String())
+	assert.Equal(t, "10000.00", insts[2].InterestDue.String())
+	assert.Equal(t, 0, sumPrincipal(insts).Cmp(loan.PrincipalAmount))
+
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), insts[0].DueDate)
+	assert.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), insts[1].DueDate)
+	assert.Equal(t, InstallmentPending, insts[0].Status)
+}
+
+func TestGenerateSchedule_Annuity(t *testing.T) {
+	loan := Loan{
+		PrincipalAmount: MustParseMoney("1000000", CurrencyIDR),
+		Rate:            12,
+		TenorMonths:     12,
+		RepaymentMethod: RepaymentAnnuity,
+	}
+
+	insts, err := GenerateSchedule(loan, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, insts, 12)
+
+	assert.Equal(t, "10000.00", insts[0].InterestDue.String())
+	payment := insts[0].TotalDue()
+	assert.Equal(t, "88848.79", payment.String())
+	for _, inst := range insts[:11] {
+		assert.Equal(t, 0, inst.TotalDue().Cmp(payment))
+	}
+	// The last instalment settles the remaining balance, which differs from
+	// the regular payment by at most a few minor units of rounding.
+	assert.InDelta(t, payment.Amount, insts[11].TotalDue().Amount, 12)
+	assert.Equal(t, 0, sumPrincipal(insts).Cmp(loan.PrincipalAmount))
+}
+
+func TestGenerateSchedule_Bullet(t *testing.T) {
+	loan := Loan{
+		PrincipalAmount: MustParseMoney("500000", CurrencyIDR),
+		Rate:            10,
+		TenorMonths:     6,
+		RepaymentMethod: RepaymentBullet,
+	}
+
+	insts, err := GenerateSchedule(loan, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, insts, 6)
+
+	for _, inst := range insts[:5] {
+		assert.True(t, inst.PrincipalDue.IsZero())
+		assert.Equal(t, "4166.67", inst.InterestDue.String())
+	}
+	assert.Equal(t, "500000.00", insts[5].PrincipalDue.String())
+}
+
+func TestGenerateSchedule_Invalid(t *testing.T) {
+	loan := Loan{PrincipalAmount: MustParseMoney("1000", CurrencyIDR), RepaymentMethod: RepaymentFlat}
+	_, err := GenerateSchedule(loan, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidSchedule)
+
+	loan.TenorMonths = 3
+	loan.RepaymentMethod = "balloon"
+	_, err = GenerateSchedule(loan, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidSchedule)
+}
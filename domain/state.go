@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidTransition = errors.New("invalid loan status transition")
+
+// TransitionError describes a status change the state machine refused.
+// It unwraps to ErrInvalidTransition so callers can match on the kind.
+type TransitionError struct {
+	From   LoanStatus
+	To     LoanStatus
+	Reason string
+}
+
+func (e *TransitionError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("cannot move loan from %q to %q: %s", e.From, e.To, e.Reason)
+	}
+	return fmt.Sprintf("cannot move loan from %q to %q", e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// TransitionContext carries the facts guards need to decide on a transition.
+type TransitionContext struct {
+	Loan            *Loan
+	TotalInvested   Money
+	ScheduleSettled bool
+	Now             time.Time
+}
+
+// Guard returns a non-empty reason when the transition must be refused.
+type Guard func(TransitionContext) string
+
+func fullyFunded(tc TransitionContext) string {
+	if tc.Loan == nil || tc.TotalInvested.CheckCurrency(tc.Loan.PrincipalAmount) != nil ||
+		tc.TotalInvested.Cmp(tc.Loan.PrincipalAmount) != 0 {
+		return "loan is not fully funded"
+	}
+	return ""
+}
+
+func fullyRepaid(tc TransitionContext) string {
+	if !tc.ScheduleSettled {
+		return "repayment schedule is not settled"
+	}
+	return ""
+}
+
+func fundingLapsed(tc TransitionContext) string {
+	if tc.Loan == nil || !tc.Loan.FundingClosed(tc.Now) {
+		return "funding deadline has not passed"
+	}
+	if fullyFunded(tc) == "" {
+		return "loan is fully funded"
+	}
+	return ""
+}
+
+// loanTransitions is the single source of truth for allowed status changes.
+// A nil guard means the transition is unconditional; statuses without an
+// entry are terminal.
+var loanTransitions = map[LoanStatus]map[LoanStatus]Guard{
+	StatusProposed: {
+		StatusApproved:  nil,
+		StatusRejected:  nil,
+		StatusCancelled: nil,
+	},
+	StatusApproved: {
+		StatusInvested:  fullyFunded,
+		StatusExpired:   fundingLapsed,
+		StatusCancelled: nil,
+	},
+	StatusInvested: {
+		StatusDisbursed: nil,
+		StatusCancelled: nil,
+	},
+	StatusDisbursed: {
+		StatusRepaying:  nil,
+		StatusCompleted: fullyRepaid,
+	},
+	StatusRepaying: {
+		StatusCompleted: fullyRepaid,
+	},
+}
+
+// ValidateTransition checks that moving from one status to another is declared
+// in the transition table and that its guard, if any, passes.
+func ValidateTransition(from, to LoanStatus, tc TransitionContext) error {
+	guard, ok := loanTransitions[from][to]
+	if !ok {
+		return &TransitionError{From: from, To: to}
+	}
+	if guard != nil {
+		if reason := guard(tc); reason != "" {
+			return &TransitionError{From: from, To: to, Reason: reason}
+		}
+	}
+	return nil
+}
+
+// IsTerminal reports whether no transition leaves the given status.
+func (s LoanStatus) IsTerminal() bool {
+	return len(loanTransitions[s]) == 0
+}
+
+// IsValid reports whether the status takes part in the state machine.
+func (s LoanStatus) IsValid() bool {
+	if _, ok := loanTransitions[s]; ok {
+		return true
+	}
+	for _, targets := range loanTransitions {
+		if _, ok := targets[s]; ok {
+			return true
+		}
+	}
+	return false
+}
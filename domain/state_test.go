@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTransition(t *testing.T) {
+	loan := &Loan{PrincipalAmount: MustParseMoney("1000", CurrencyIDR)}
+	deadline := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	open := &Loan{PrincipalAmount: MustParseMoney("1000", CurrencyIDR), FundingDeadline: &deadline}
+
+	tests := []struct {
+		name    string
+		from    LoanStatus
+		to      LoanStatus
+		tc      TransitionContext
+		wantErr bool
+	}{
+		{"approve proposed", StatusProposed, StatusApproved, TransitionContext{}, false},
+		{"reject proposed", StatusProposed, StatusRejected, TransitionContext{}, false},
+		{"invest fully funded", StatusApproved, StatusInvested, TransitionContext{Loan: loan, TotalInvested: MustParseMoney("1000", CurrencyIDR)}, false},
+		{"invest partially funded", StatusApproved, StatusInvested, TransitionContext{Loan: loan, TotalInvested: MustParseMoney("999.99", CurrencyIDR)}, true},
+		{"disburse invested", StatusInvested, StatusDisbursed, TransitionContext{}, false},
+		{"skip approval", StatusProposed, StatusInvested, TransitionContext{Loan: loan, TotalInvested: MustParseMoney("1000", CurrencyIDR)}, true},
+		{"reject approved", StatusApproved, StatusRejected, TransitionContext{}, true},
+		{"approve rejected", StatusRejected, StatusApproved, TransitionContext{}, true},
+		{"reopen disbursed", StatusDisbursed, StatusProposed, TransitionContext{}, true},
+		{"start repaying", StatusDisbursed, StatusRepaying, TransitionContext{}, false},
+		{"complete settled", StatusRepaying, StatusCompleted, TransitionContext{ScheduleSettled: true}, false},
+		{"complete unsettled", StatusRepaying, StatusCompleted, TransitionContext{}, true},
+		{"complete in one payment", StatusDisbursed, StatusCompleted, TransitionContext{ScheduleSettled: true}, false},
+		{"expire after deadline", StatusApproved, StatusExpired, TransitionContext{Loan: open, TotalInvested: MustParseMoney("500", CurrencyIDR), Now: deadline}, false},
+		{"expire before deadline", StatusApproved, StatusExpired, TransitionContext{Loan: open, TotalInvested: MustParseMoney("500", CurrencyIDR), Now: deadline.Add(-time.Second)}, true},
+		{"expire fully funded", StatusApproved, StatusExpired, TransitionContext{Loan: open, TotalInvested: MustParseMoney("1000", CurrencyIDR), Now: deadline}, true},
+		{"expire without deadline", StatusApproved, StatusExpired, TransitionContext{Loan: loan, Now: deadline}, true},
+		{"cancel proposed", StatusProposed, StatusCancelled, TransitionContext{}, false},
+		{"cancel approved", StatusApproved, StatusCancelled, TransitionContext{}, false},
+		{"cancel invested", StatusInvested, StatusCancelled, TransitionContext{}, false},
+		{"cancel disbursed", StatusDisbursed, StatusCancelled, TransitionContext{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransition(tt.from, tt.to, tt.tc)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var te *TransitionError
+			assert.True(t, errors.As(err, &te))
+			assert.ErrorIs(t, err, ErrInvalidTransition)
+			assert.Equal(t, tt.from, te.From)
+			assert.Equal(t, tt.to, te.To)
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	assert.True(t, StatusRejected.IsTerminal())
+	assert.True(t, StatusCompleted.IsTerminal())
+	assert.True(t, StatusExpired.IsTerminal())
+	assert.True(t, StatusCancelled.IsTerminal())
+	assert.False(t, StatusDisbursed.IsTerminal())
+	assert.False(t, StatusProposed.IsTerminal())
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// InsufficientFundsError is returned when a wallet cannot cover a withdrawal
+// or an investment. It matches ErrInsufficientFunds with errors.Is.
+type InsufficientFundsError struct {
+	InvestorEmail string
+	Available     Money
+	Requested     Money
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("insufficient funds in wallet of %s: available %s, requested %s", e.InvestorEmail, e.Available, e.Requested)
+}
+
+func (e *InsufficientFundsError) Is(target error) bool {
+	return target == ErrInsufficientFunds
+}
+
+// Wallet is the money the platform holds for an investor. Held is the part of
+// the balance reserved for investments in loans that have not been disbursed
+// yet; only the rest is available to invest or withdraw.
+type Wallet struct {
+	InvestorEmail string
+	Balance       Money
+	Held          Money
+	UpdatedAt     time.Time
+}
+
+func (w Wallet) Available() Money {
+	return w.Balance.Sub(w.Held)
+}
+
+// Reserve checks that the wallet can spare amount, returning an
+// *InsufficientFundsError when it cannot.
+func (w Wallet) Reserve(amount Money) error {
+	if err := w.Balance.CheckCurrency(amount); err != nil {
+		return err
+	}
+	if w.Available().Cmp(amount) < 0 {
+		return &InsufficientFundsError{
+			InvestorEmail: w.InvestorEmail,
+			Available:     w.Available(),
+			Requested:     amount,
+		}
+	}
+	return nil
+}
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "active"
+	HoldCaptured HoldStatus = "captured"
+	HoldReleased HoldStatus = "released"
+)
+
+// WalletHold reserves part of a wallet for an investment in a loan. It is
+// captured, turning into a debit, when the loan is disbursed, or released
+// when the loan does not go ahead.
+type WalletHold struct {
+	ID            int
+	InvestorEmail string
+	LoanID        int
+	Amount        Money
+	Status        HoldStatus
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound           = errors.New("webhook subscription not found")
+	ErrInvalidWebhook            = errors.New("invalid webhook subscription")
+	ErrWebhookInactive           = errors.New("webhook subscription is not active")
+	ErrWebhookDeliveryInProgress = errors.New("webhook delivery is still being attempted")
+)
+
+type WebhookStatus string
+
+const (
+	WebhookActive   WebhookStatus = "active"
+	WebhookInactive WebhookStatus = "inactive"
+)
+
+// Webhook is a partner endpoint subscribed to loan events. Every delivery
+// is signed with Secret, which is never returned by the API.
+type Webhook struct {
+	ID         int
+	URL        string
+	EventTypes []EventName
+	Secret     string `json:"-"`
+	Status     WebhookStatus
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Validate checks that the URL is an absolute http(s) URL and that every
+// event type is one the loan lifecycle emits.
+func (w Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if len(w.EventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", ErrInvalidWebhook)
+	}
+	for _, name := range w.EventTypes {
+		if !slices.Contains(EventNames, name) {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, name)
+		}
+	}
+	return nil
+}
+
+// WebhookFilter pages through webhooks ordered by id.
+type WebhookFilter struct {
+	AfterID int
+	Limit   int
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	// DeliveryPending has not been attempted yet.
+	DeliveryPending WebhookDeliveryStatus = "pending"
+	// DeliveryRetrying failed at least once and will be attempted again.
+	DeliveryRetrying  WebhookDeliveryStatus = "retrying"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// DeliveryFailed ran out of attempts; it is only sent again when
+	// redelivered by hand.
+	DeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent, or to be sent, to one webhook. Payload
+// is the event as JSON; it is fixed when the delivery is created, so a
+// redelivery sends exactly the same event. ResponseStatus and LastError
+// describe the latest attempt.
+type WebhookDelivery struct {
+	ID             int
+	WebhookID      int
+	EventName      EventName
+	LoanID         int
+	Payload        json.RawMessage `swaggertype:"object"`
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+	LastAttemptAt  *time.Time
+	DeliveredAt    *time.Time
+}
+
+// WebhookDeliveryFilter selects the deliveries of one webhook, newest first.
+type WebhookDeliveryFilter struct {
+	WebhookID int
+	Status    WebhookDeliveryStatus
+	BeforeID  int
+	Limit     int
+}
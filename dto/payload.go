@@ -1,32 +1,252 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+)
 
 type CreateLoanPayload struct {
-	BorrowerID      string  `json:"borrower_id" binding:"required"`
-	PrincipalAmount float64 `json:"principal_amount" binding:"required,gt=0"`
-	Rate            float64 `json:"rate" binding:"required,gt=0"`
-	ROI             float64 `json:"roi" binding:"required,gte=0"`
+	BorrowerID      string       `json:"borrower_id" binding:"required"`
+	PrincipalAmount domain.Money `json:"principal_amount" binding:"required,gt=0" swaggertype:"string" example:"1500000.00"`
+	Rate            float64      `json:"rate" binding:"required,gt=0"`
+	ROI             float64      `json:"roi" binding:"required,gte=0"`
+	TenorMonths     int          `json:"tenor_months" binding:"required,gte=1,lte=360"`
+	RepaymentMethod string       `json:"repayment_method" binding:"omitempty,oneof=flat annuity bullet"`
+}
+
+type BorrowerPayload struct {
+	IdentityNumber string `json:"identity_number" binding:"required,max=32" example:"3174012501900001"`
+	Name           string `json:"name" binding:"required,max=150"`
+	Phone          string `json:"phone" binding:"required,max=20"`
+	Email          string `json:"email" binding:"omitempty,email,max=100"`
+	Address        string `json:"address" binding:"required,max=500"`
+}
+
+// UpdateBorrowerPayload replaces a borrower's details. The identity number
+// cannot be changed.
+type UpdateBorrowerPayload struct {
+	ID      string                `json:"-"`
+	Name    string                `json:"name" binding:"required,max=150"`
+	Phone   string                `json:"phone" binding:"required,max=20"`
+	Email   string                `json:"email" binding:"omitempty,email,max=100"`
+	Address string                `json:"address" binding:"required,max=500"`
+	Status  domain.BorrowerStatus `json:"status" binding:"required,oneof=active inactive"`
+}
+
+type ListBorrowersQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=active inactive"`
+	Limit  int    `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Cursor string `form:"cursor"`
+}
+
+type BorrowerPage struct {
+	Data       []domain.Borrower `json:"data"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+type RegisterInvestorPayload struct {
+	Email          string `json:"email" binding:"required,email,max=100"`
+	Name           string `json:"name" binding:"required,max=150"`
+	Phone          string `json:"phone" binding:"required,max=20"`
+	IdentityNumber string `json:"identity_number" binding:"required,max=32" example:"3174012501900002"`
+}
+
+// ReviewKYCPayload records the outcome of a KYC review. The reviewer is
+// the caller, never taken from the request body.
+type ReviewKYCPayload struct {
+	Email      string           `json:"-"`
+	Status     domain.KYCStatus `json:"status" binding:"required,oneof=pending verified rejected suspended"`
+	ReviewerID string           `json:"-"`
+	Note       string           `json:"note" binding:"max=1000"`
+}
+
+type ListInvestorsQuery struct {
+	KYCStatus string `form:"kyc_status" binding:"omitempty,oneof=pending verified rejected suspended"`
+	Limit     int    `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Cursor    string `form:"cursor"`
 }
 
+type InvestorPage struct {
+	Data       []domain.Investor `json:"data"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+type EmployeePayload struct {
+	ID   string              `json:"id" binding:"required,max=50" example:"EMP001"`
+	Name string              `json:"name" binding:"required,max=150"`
+	Role domain.EmployeeRole `json:"role" binding:"required,oneof=field_validator field_officer admin"`
+}
+
+type UpdateEmployeePayload struct {
+	ID     string                `json:"-"`
+	Name   string                `json:"name" binding:"required,max=150"`
+	Role   domain.EmployeeRole   `json:"role" binding:"required,oneof=field_validator field_officer admin"`
+	Status domain.EmployeeStatus `json:"status" binding:"required,oneof=active inactive"`
+}
+
+// Upload is a file received with a multipart request.
+type Upload struct {
+	Filename string
+	Data     []byte
+}
+
+// ApproveLoanPayload is sent as multipart/form-data together with the
+// picture_proof file.
 type ApproveLoanPayload struct {
-	LoanID       int       `json:"-"`
-	PictureProof string    `json:"picture_proof" binding:"required"`
-	EmployeeID   string    `json:"employee_id" binding:"required"`
-	DateStr      string    `json:"date" binding:"required"`
-	Date         time.Time `json:"-"`
+	LoanID             int        `form:"-"`
+	EmployeeID         string     `form:"employee_id" binding:"required"`
+	DateStr            string     `form:"date" binding:"required"`
+	Date               time.Time  `form:"-"`
+	FundingDeadlineStr string     `form:"funding_deadline"`
+	FundingDeadline    *time.Time `form:"-"`
+	PictureProof       Upload     `form:"-"`
+}
+
+type LoanApprovalDetail struct {
+	Approval                 *domain.LoanApproval `json:"approval"`
+	PictureProofURL          string               `json:"picture_proof_url"`
+	PictureProofURLExpiresAt time.Time            `json:"picture_proof_url_expires_at"`
+}
+
+type RejectLoanPayload struct {
+	LoanID     int                    `json:"-"`
+	EmployeeID string                 `json:"employee_id" binding:"required"`
+	ReasonCode domain.RejectionReason `json:"reason_code" binding:"required,oneof=incomplete_documents failed_verification poor_credit_history suspected_fraud other"`
+	Note       string                 `json:"note" binding:"max=1000"`
+	DateStr    string                 `json:"date" binding:"required"`
+	Date       time.Time              `json:"-"`
+}
+
+type CancelLoanPayload struct {
+	LoanID int    `json:"-"`
+	Actor  string `json:"actor" binding:"required"`
+	Reason string `json:"reason" binding:"required,max=1000"`
 }
 
 type InvestLoanPayload struct {
-	LoanID        int     `json:"-"`
-	InvestorEmail string  `json:"investor_email" binding:"required,email"`
-	Amount        float64 `json:"amount" binding:"required,gt=0"`
+	LoanID        int          `json:"-"`
+	InvestorEmail string       `json:"investor_email" binding:"required,email"`
+	Amount        domain.Money `json:"amount" binding:"required,gt=0" swaggertype:"string" example:"250000.00"`
 }
 
 type DisburseLoanPayload struct {
-	LoanID        int       `json:"-"`
-	AgreementLink string    `json:"agreement_letter_link" binding:"required,url"`
-	EmployeeID    string    `json:"employee_id" binding:"required"`
-	DateStr       string    `json:"date" binding:"required"`
-	Date          time.Time `json:"-"`
+	LoanID     int       `json:"-"`
+	EmployeeID string    `json:"employee_id" binding:"required"`
+	DateStr    string    `json:"date" binding:"required"`
+	Date       time.Time `json:"-"`
+}
+
+type AgreementDocument struct {
+	Agreement    domain.Agreement `json:"agreement"`
+	URL          string           `json:"url"`
+	URLExpiresAt time.Time        `json:"url_expires_at"`
+}
+
+type WalletTransferPayload struct {
+	InvestorEmail string       `json:"-"`
+	Amount        domain.Money `json:"amount" binding:"required,gt=0" swaggertype:"string" example:"500000.00"`
+	Reference     string       `json:"reference" binding:"max=100"`
+}
+
+type RecordRepaymentPayload struct {
+	LoanID    int          `json:"-"`
+	Amount    domain.Money `json:"amount" binding:"required,gt=0" swaggertype:"string" example:"137500.00"`
+	Reference string       `json:"reference" binding:"max=100"`
+	DateStr   string       `json:"date" binding:"required"`
+	Date      time.Time    `json:"-"`
+}
+
+type ListLoansQuery struct {
+	Status       []string `form:"status"`
+	BorrowerID   string   `form:"borrower_id"`
+	MinPrincipal string   `form:"min_principal"`
+	MaxPrincipal string   `form:"max_principal"`
+	MinRate      *float64 `form:"min_rate" binding:"omitempty,gte=0"`
+	MaxRate      *float64 `form:"max_rate" binding:"omitempty,gte=0"`
+	CreatedFrom  string   `form:"created_from"`
+	CreatedTo    string   `form:"created_to"`
+	SortBy       string   `form:"sort_by" binding:"omitempty,oneof=created_at principal_amount rate id"`
+	Order        string   `form:"order" binding:"omitempty,oneof=asc desc"`
+	Limit        int      `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Cursor       string   `form:"cursor"`
+	// InvestorEmail is set by the handler, not the caller, to narrow an
+	// investor's listing.
+	InvestorEmail string `form:"-"`
+}
+
+type LoanPage struct {
+	Data       []domain.Loan `json:"data"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+type PageQuery struct {
+	Limit  int    `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Cursor string `form:"cursor"`
+}
+
+type InvestmentSummary struct {
+	ID               int               `json:"id"`
+	LoanID           int               `json:"loan_id"`
+	LoanStatus       domain.LoanStatus `json:"loan_status"`
+	InvestorEmail    string            `json:"investor_email"`
+	Amount           domain.Money      `json:"amount"`
+	ShareOfPrincipal string            `json:"share_of_principal" example:"25.00"`
+	ROI              float64           `json:"roi"`
+	ExpectedReturn   domain.Money      `json:"expected_return"`
+	InvestedAt       time.Time         `json:"invested_at"`
+	RefundedAt       *time.Time        `json:"refunded_at,omitempty"`
+}
+
+type InvestmentPage struct {
+	Data       []InvestmentSummary `json:"data"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+type RepaymentSchedule struct {
+	LoanID          int                    `json:"loan_id"`
+	RepaymentMethod domain.RepaymentMethod `json:"repayment_method"`
+	TenorMonths     int                    `json:"tenor_months"`
+	TotalPrincipal  domain.Money           `json:"total_principal"`
+	TotalInterest   domain.Money           `json:"total_interest"`
+	Installments    []domain.Installment   `json:"installments"`
+}
+
+type RepaymentResult struct {
+	Repayment  *domain.Repayment `json:"repayment"`
+	LoanStatus domain.LoanStatus `json:"loan_status"`
+}
+
+type WebhookPayload struct {
+	URL        string             `json:"url" binding:"required,url,max=500" example:"https://partner.example.com/hooks/loans"`
+	EventTypes []domain.EventName `json:"event_types" binding:"required,min=1,dive,required" example:"loan.approved,loan.disbursed"`
+	// Secret signs every delivery; it is never returned.
+	Secret string `json:"secret" binding:"required,min=16,max=200"`
+}
+
+// UpdateWebhookPayload replaces a webhook's endpoint, events and status.
+// The secret is only changed when one is given.
+type UpdateWebhookPayload struct {
+	ID         int                  `json:"-"`
+	URL        string               `json:"url" binding:"required,url,max=500"`
+	EventTypes []domain.EventName   `json:"event_types" binding:"required,min=1,dive,required"`
+	Secret     string               `json:"secret" binding:"omitempty,min=16,max=200"`
+	Status     domain.WebhookStatus `json:"status" binding:"required,oneof=active inactive"`
+}
+
+type WebhookPage struct {
+	Data       []domain.Webhook `json:"data"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+type ListWebhookDeliveriesQuery struct {
+	WebhookID int    `form:"-"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending retrying succeeded failed"`
+	Limit     int    `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Cursor    string `form:"cursor"`
+}
+
+type WebhookDeliveryPage struct {
+	Data       []domain.WebhookDelivery `json:"data"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,174 @@
+// Package events dispatches domain events to the modules subscribed to
+// them, in process.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+// Handler reacts to an event.
+type Handler func(ctx context.Context, e domain.Event) error
+
+// Bus is an in-memory event bus.
+//
+// Synchronous subscribers run inside Publish, in the publisher's
+// transaction: their writes commit or roll back with the change, and an
+// error fails it. Asynchronous subscribers get the event on their own
+// goroutine once the transaction has committed, and never slow the
+// publisher down; their errors are only logged.
+type Bus struct {
+	mu        sync.RWMutex
+	subs      []subscription
+	asyncSubs []*asyncSubscriber
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+type subscription struct {
+	handler Handler
+	// names filters the events; empty means every event.
+	names map[domain.EventName]bool
+}
+
+func (s subscription) wants(e domain.Event) bool {
+	return len(s.names) == 0 || s.names[e.EventName()]
+}
+
+type asyncSubscriber struct {
+	subscription
+	queue chan domain.Event
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func nameSet(names []domain.EventName) map[domain.EventName]bool {
+	set := map[domain.EventName]bool{}
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
+
+// Subscribe runs h synchronously for the named events, or for every event
+// when no name is given.
+func (b *Bus) Subscribe(h Handler, names ...domain.EventName) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, subscription{handler: h, names: nameSet(names)})
+}
+
+// SubscribeAsync runs h on its own goroutine for the named events, or for
+// every event when no name is given. Up to buffer events wait for h; events
+// arriving while the buffer is full are dropped and logged.
+func (b *Bus) SubscribeAsync(h Handler, buffer int, names ...domain.EventName) {
+	s := &asyncSubscriber{
+		subscription: subscription{handler: h, names: nameSet(names)},
+		queue:        make(chan domain.Event, buffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.asyncSubs = append(b.asyncSubs, s)
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for e := range s.queue {
+			if err := s.handler(context.Background(), e); err != nil {
+				log.Printf("events: async subscriber failed on %s of loan %d: %v", e.EventName(), e.AggregateID(), err)
+			}
+		}
+	}()
+}
+
+// Publish runs the synchronous subscribers of e and schedules it for the
+// asynchronous ones. It returns the synchronous subscribers' errors.
+func (b *Bus) Publish(ctx context.Context, e domain.Event) error {
+	b.mu.RLock()
+	subs, async := b.subs, b.asyncSubs
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subs {
+		if !s.wants(e) {
+			continue
+		}
+		if err := s.handler(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("%s subscriber: %w", e.EventName(), err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if len(async) > 0 {
+		utils.AfterCommit(ctx, func() { b.enqueue(async, e) })
+	}
+	return nil
+}
+
+func (b *Bus) enqueue(subs []*asyncSubscriber, e domain.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	for _, s := range subs {
+		if !s.wants(e) {
+			continue
+		}
+		select {
+		case s.queue <- e:
+		default:
+			log.Printf("events: async subscriber is full, dropping %s of loan %d", e.EventName(), e.AggregateID())
+		}
+	}
+}
+
+// Close stops delivering to asynchronous subscribers once they have handled
+// the events already queued, or when ctx is done.
+func (b *Bus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, s := range b.asyncSubs {
+			close(s.queue)
+		}
+	}
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// LogHandler logs every event it gets as JSON. Subscribed asynchronously,
+// it stands in for an analytics pipeline.
+func LogHandler(_ context.Context, e domain.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	log.Printf("[EVENT] %s %s", e.EventName(), data)
+	return nil
+}
@@ -0,0 +1,122 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func created(id int) domain.Event {
+	return domain.LoanCreated{LoanEvent: domain.NewLoanEvent(id, time.Now())}
+}
+
+func approved(id int) domain.Event {
+	return domain.LoanApproved{LoanEvent: domain.NewLoanEvent(id, time.Now())}
+}
+
+func TestBus_Subscribe(t *testing.T) {
+	b := NewBus()
+
+	var all, onlyApproved []domain.EventName
+	b.Subscribe(func(_ context.Context, e domain.Event) error {
+		all = append(all, e.EventName())
+		return nil
+	})
+	b.Subscribe(func(_ context.Context, e domain.Event) error {
+		onlyApproved = append(onlyApproved, e.EventName())
+		return nil
+	}, domain.EventLoanApproved)
+
+	require.NoError(t, b.Publish(context.TODO(), created(1)))
+	require.NoError(t, b.Publish(context.TODO(), approved(1)))
+
+	assert.Equal(t, []domain.EventName{domain.EventLoanCreated, domain.EventLoanApproved}, all)
+	assert.Equal(t, []domain.EventName{domain.EventLoanApproved}, onlyApproved)
+}
+
+func TestBus_SubscriberErrorFailsPublish(t *testing.T) {
+	b := NewBus()
+	b.Subscribe(func(context.Context, domain.Event) error { return errors.New("outbox is down") })
+
+	delivered := make(chan domain.Event, 1)
+	b.SubscribeAsync(func(_ context.Context, e domain.Event) error {
+		delivered <- e
+		return nil
+	}, 1)
+
+	err := b.Publish(context.TODO(), created(1))
+	assert.EqualError(t, err, "loan.created subscriber: outbox is down")
+
+	require.NoError(t, b.Close(context.TODO()))
+	assert.Empty(t, delivered, "a failed change must not reach asynchronous subscribers")
+}
+
+func TestBus_SubscribeAsync(t *testing.T) {
+	b := NewBus()
+
+	delivered := make(chan domain.Event, 2)
+	b.SubscribeAsync(func(_ context.Context, e domain.Event) error {
+		delivered <- e
+		return nil
+	}, 2, domain.EventLoanApproved)
+
+	require.NoError(t, b.Publish(context.TODO(), created(1)))
+	require.NoError(t, b.Publish(context.TODO(), approved(1)))
+	require.NoError(t, b.Close(context.TODO()))
+
+	require.Len(t, delivered, 1)
+	assert.Equal(t, domain.EventLoanApproved, (<-delivered).EventName())
+
+	// Nothing is delivered once the bus is closed.
+	require.NoError(t, b.Publish(context.TODO(), approved(2)))
+	assert.Empty(t, delivered)
+}
+
+func TestBus_SubscribeAsyncDropsWhenFull(t *testing.T) {
+	b := NewBus()
+
+	release := make(chan struct{})
+	var handled []int
+	b.SubscribeAsync(func(_ context.Context, e domain.Event) error {
+		<-release
+		handled = append(handled, e.AggregateID())
+		return nil
+	}, 1)
+
+	// The first event is being handled, the second waits in the buffer and
+	// the third has nowhere to go.
+	require.NoError(t, b.Publish(context.TODO(), created(1)))
+	require.Eventually(t, func() bool {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		return len(b.asyncSubs[0].queue) == 0
+	}, time.Second, time.Millisecond)
+	require.NoError(t, b.Publish(context.TODO(), created(2)))
+	require.NoError(t, b.Publish(context.TODO(), created(3)))
+
+	close(release)
+	require.NoError(t, b.Close(context.TODO()))
+	assert.Equal(t, []int{1, 2}, handled)
+}
+
+func TestBus_CloseTimesOut(t *testing.T) {
+	b := NewBus()
+
+	release := make(chan struct{})
+	defer close(release)
+	b.SubscribeAsync(func(context.Context, domain.Event) error {
+		<-release
+		return nil
+	}, 1)
+	require.NoError(t, b.Publish(context.TODO(), created(1)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.Close(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// FundingExpirer expires approved loans whose funding deadline has passed.
+type FundingExpirer interface {
+	ExpireOverdueLoans(ctx context.Context, now time.Time) (int, error)
+}
+
+// RunFundingExpiry checks for overdue loans once at start and then on every
+// tick of interval until ctx is cancelled.
+func RunFundingExpiry(ctx context.Context, uc FundingExpirer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := uc.ExpireOverdueLoans(ctx, time.Now())
+		if err != nil {
+			log.Printf("funding expiry: %v", err)
+		}
+		if n > 0 {
+			log.Printf("funding expiry: expired %d loan(s)", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// OutboxDispatcher delivers the outbox messages that are due.
+type OutboxDispatcher interface {
+	DispatchDue(ctx context.Context, now time.Time) (int, error)
+}
+
+// RunOutboxDispatcher dispatches due messages on every tick of interval
+// until ctx is cancelled. While messages keep coming it carries on without
+// waiting for the next tick.
+func RunOutboxDispatcher(ctx context.Context, d OutboxDispatcher, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := d.DispatchDue(ctx, time.Now())
+		if err != nil {
+			log.Printf("outbox: %v", err)
+		}
+		if n > 0 && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
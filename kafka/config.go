@@ -0,0 +1,44 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/martinusiron/loan-service/configs"
+)
+
+// NewFromConfig returns a producer for the configured topic, or nil when no
+// brokers are configured.
+func NewFromConfig(cfg configs.KafkaConfig) (*Producer, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, nil
+	}
+	s, err := NewSerializer(cfg.Serialization)
+	if err != nil {
+		return nil, err
+	}
+	topic := cfg.Topic
+	if topic == "" {
+		topic = "loan-events"
+	}
+
+	p := NewProducer(cfg.Brokers, topic, s)
+	if cfg.ClientID != "" {
+		p.ClientID = cfg.ClientID
+	}
+	switch cfg.Acks {
+	case "", "all":
+		p.RequiredAcks = -1
+	case "leader":
+		p.RequiredAcks = 1
+	default:
+		return nil, fmt.Errorf("unknown kafka acks %q", cfg.Acks)
+	}
+	if cfg.Timeout != "" {
+		p.Timeout, err = time.ParseDuration(cfg.Timeout)
+		if err != nil || p.Timeout <= 0 {
+			return nil, fmt.Errorf("invalid kafka timeout: %q", cfg.Timeout)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,56 @@
+package kafka
+
+import "fmt"
+
+// Error is an error code returned by the broker.
+type Error int16
+
+// Error codes the producer handles specially.
+const (
+	ErrNone                    Error = 0
+	ErrCorruptMessage          Error = 2
+	ErrUnknownTopicOrPartition Error = 3
+	ErrLeaderNotAvailable      Error = 5
+	ErrNotLeaderOrFollower     Error = 6
+	ErrRequestTimedOut         Error = 7
+	ErrMessageTooLarge         Error = 10
+	ErrNotEnoughReplicas       Error = 19
+	ErrTopicAuthorization      Error = 29
+	ErrInvalidRecord           Error = 87
+)
+
+func (e Error) Error() string {
+	switch e {
+	case ErrCorruptMessage:
+		return "kafka: corrupt message"
+	case ErrUnknownTopicOrPartition:
+		return "kafka: unknown topic or partition"
+	case ErrLeaderNotAvailable:
+		return "kafka: leader not available"
+	case ErrNotLeaderOrFollower:
+		return "kafka: not leader or follower"
+	case ErrRequestTimedOut:
+		return "kafka: request timed out"
+	case ErrMessageTooLarge:
+		return "kafka: message too large"
+	case ErrNotEnoughReplicas:
+		return "kafka: not enough replicas"
+	case ErrTopicAuthorization:
+		return "kafka: topic authorization failed"
+	case ErrInvalidRecord:
+		return "kafka: invalid record"
+	}
+	return fmt.Sprintf("kafka: broker error %d", int16(e))
+}
+
+// Permanent reports whether producing the same record again cannot succeed,
+// so the outbox dispatcher does not retry it.
+func (e Error) Permanent() bool {
+	return e == ErrMessageTooLarge || e == ErrInvalidRecord
+}
+
+// staleMetadata reports whether the error means the producer's view of the
+// partition leaders is out of date.
+func (e Error) staleMetadata() bool {
+	return e == ErrUnknownTopicOrPartition || e == ErrLeaderNotAvailable || e == ErrNotLeaderOrFollower
+}
@@ -0,0 +1,230 @@
+package wire
+
+// MetadataRequest is a Metadata v4 request.
+type MetadataRequest struct {
+	Topics                 []string
+	AllowAutoTopicCreation bool
+}
+
+func (m MetadataRequest) Encode() []byte {
+	var w Writer
+	w.ArrayLen(len(m.Topics))
+	for _, t := range m.Topics {
+		w.String(t)
+	}
+	w.Bool(m.AllowAutoTopicCreation)
+	return w.Bytes()
+}
+
+func DecodeMetadataRequest(b []byte) (MetadataRequest, error) {
+	r := NewReader(b)
+	var m MetadataRequest
+	for range r.ArrayLen() {
+		m.Topics = append(m.Topics, r.String())
+	}
+	m.AllowAutoTopicCreation = r.Bool()
+	return m, r.Err()
+}
+
+type BrokerMetadata struct {
+	NodeID int32
+	Host   string
+	Port   int32
+	Rack   *string
+}
+
+type PartitionMetadata struct {
+	ErrorCode int16
+	Partition int32
+	Leader    int32
+	Replicas  []int32
+	ISR       []int32
+}
+
+type TopicMetadata struct {
+	ErrorCode  int16
+	Name       string
+	Internal   bool
+	Partitions []PartitionMetadata
+}
+
+// MetadataResponse is a Metadata v4 response.
+type MetadataResponse struct {
+	ThrottleTimeMs int32
+	Brokers        []BrokerMetadata
+	ClusterID      *string
+	ControllerID   int32
+	Topics         []TopicMetadata
+}
+
+func (m MetadataResponse) Encode() []byte {
+	var w Writer
+	w.Int32(m.ThrottleTimeMs)
+	w.ArrayLen(len(m.Brokers))
+	for _, b := range m.Brokers {
+		w.Int32(b.NodeID)
+		w.String(b.Host)
+		w.Int32(b.Port)
+		w.NullableString(b.Rack)
+	}
+	w.NullableString(m.ClusterID)
+	w.Int32(m.ControllerID)
+	w.ArrayLen(len(m.Topics))
+	for _, t := range m.Topics {
+		w.Int16(t.ErrorCode)
+		w.String(t.Name)
+		w.Bool(t.Internal)
+		w.ArrayLen(len(t.Partitions))
+		for _, p := range t.Partitions {
+			w.Int16(p.ErrorCode)
+			w.Int32(p.Partition)
+			w.Int32(p.Leader)
+			writeInt32s(&w, p.Replicas)
+			writeInt32s(&w, p.ISR)
+		}
+	}
+	return w.Bytes()
+}
+
+func DecodeMetadataResponse(b []byte) (MetadataResponse, error) {
+	r := NewReader(b)
+	var m MetadataResponse
+	m.ThrottleTimeMs = r.Int32()
+	for range r.ArrayLen() {
+		m.Brokers = append(m.Brokers, BrokerMetadata{NodeID: r.Int32(), Host: r.String(), Port: r.Int32(), Rack: r.NullableString()})
+	}
+	m.ClusterID = r.NullableString()
+	m.ControllerID = r.Int32()
+	for range r.ArrayLen() {
+		t := TopicMetadata{ErrorCode: r.Int16(), Name: r.String(), Internal: r.Bool()}
+		for range r.ArrayLen() {
+			t.Partitions = append(t.Partitions, PartitionMetadata{
+				ErrorCode: r.Int16(),
+				Partition: r.Int32(),
+				Leader:    r.Int32(),
+				Replicas:  readInt32s(r),
+				ISR:       readInt32s(r),
+			})
+		}
+		m.Topics = append(m.Topics, t)
+	}
+	return m, r.Err()
+}
+
+// ProduceRequest is a Produce v3 request.
+type ProduceRequest struct {
+	TransactionalID *string
+	Acks            int16
+	TimeoutMs       int32
+	Topics          []ProduceTopic
+}
+
+type ProduceTopic struct {
+	Name       string
+	Partitions []ProducePartition
+}
+
+type ProducePartition struct {
+	Partition int32
+	// Records holds encoded record batches.
+	Records []byte
+}
+
+func (p ProduceRequest) Encode() []byte {
+	var w Writer
+	w.NullableString(p.TransactionalID)
+	w.Int16(p.Acks)
+	w.Int32(p.TimeoutMs)
+	w.ArrayLen(len(p.Topics))
+	for _, t := range p.Topics {
+		w.String(t.Name)
+		w.ArrayLen(len(t.Partitions))
+		for _, part := range t.Partitions {
+			w.Int32(part.Partition)
+			w.BytesField(part.Records)
+		}
+	}
+	return w.Bytes()
+}
+
+func DecodeProduceRequest(b []byte) (ProduceRequest, error) {
+	r := NewReader(b)
+	p := ProduceRequest{TransactionalID: r.NullableString(), Acks: r.Int16(), TimeoutMs: r.Int32()}
+	for range r.ArrayLen() {
+		t := ProduceTopic{Name: r.String()}
+		for range r.ArrayLen() {
+			t.Partitions = append(t.Partitions, ProducePartition{Partition: r.Int32(), Records: r.BytesField()})
+		}
+		p.Topics = append(p.Topics, t)
+	}
+	return p, r.Err()
+}
+
+// ProduceResponse is a Produce v3 response.
+type ProduceResponse struct {
+	Topics         []ProduceTopicResponse
+	ThrottleTimeMs int32
+}
+
+type ProduceTopicResponse struct {
+	Name       string
+	Partitions []ProducePartitionResponse
+}
+
+type ProducePartitionResponse struct {
+	Partition       int32
+	ErrorCode       int16
+	BaseOffset      int64
+	LogAppendTimeMs int64
+}
+
+func (p ProduceResponse) Encode() []byte {
+	var w Writer
+	w.ArrayLen(len(p.Topics))
+	for _, t := range p.Topics {
+		w.String(t.Name)
+		w.ArrayLen(len(t.Partitions))
+		for _, part := range t.Partitions {
+			w.Int32(part.Partition)
+			w.Int16(part.ErrorCode)
+			w.Int64(part.BaseOffset)
+			w.Int64(part.LogAppendTimeMs)
+		}
+	}
+	w.Int32(p.ThrottleTimeMs)
+	return w.Bytes()
+}
+
+func DecodeProduceResponse(b []byte) (ProduceResponse, error) {
+	r := NewReader(b)
+	var p ProduceResponse
+	for range r.ArrayLen() {
+		t := ProduceTopicResponse{Name: r.String()}
+		for range r.ArrayLen() {
+			t.Partitions = append(t.Partitions, ProducePartitionResponse{
+				Partition:       r.Int32(),
+				ErrorCode:       r.Int16(),
+				BaseOffset:      r.Int64(),
+				LogAppendTimeMs: r.Int64(),
+			})
+		}
+		p.Topics = append(p.Topics, t)
+	}
+	p.ThrottleTimeMs = r.Int32()
+	return p, r.Err()
+}
+
+func writeInt32s(w *Writer, vs []int32) {
+	w.ArrayLen(len(vs))
+	for _, v := range vs {
+		w.Int32(v)
+	}
+}
+
+func readInt32s(r *Reader) []int32 {
+	var vs []int32
+	for range r.ArrayLen() {
+		vs = append(vs, r.Int32())
+	}
+	return vs
+}
@@ -0,0 +1,143 @@
+package wire
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"time"
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Record is one message of a record batch. Offset is only set on decoded
+// records.
+type Record struct {
+	Offset    int64
+	Timestamp time.Time
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+}
+
+// batchHeaderSize is the size of a v2 batch header, from the base offset
+// up to the record count.
+const batchHeaderSize = 61
+
+// crcOffset is where the CRC sits in a batch; it covers everything after it.
+const crcOffset = 17
+
+// EncodeRecordBatch encodes records as one uncompressed v2 record batch
+// from a producer without idempotence or transactions.
+func EncodeRecordBatch(records []Record) []byte {
+	first, last := records[0].Timestamp.UnixMilli(), records[0].Timestamp.UnixMilli()
+	for _, rec := range records {
+		first = min(first, rec.Timestamp.UnixMilli())
+		last = max(last, rec.Timestamp.UnixMilli())
+	}
+
+	var w Writer
+	w.Int64(0)  // base offset, assigned by the broker
+	w.Int32(0)  // batch length, filled in below
+	w.Int32(-1) // partition leader epoch
+	w.Int8(2)   // magic
+	w.Int32(0)  // CRC, filled in below
+	w.Int16(0)  // attributes: no compression, create time
+	w.Int32(int32(len(records) - 1))
+	w.Int64(first)
+	w.Int64(last)
+	w.Int64(-1) // producer ID
+	w.Int16(-1) // producer epoch
+	w.Int32(-1) // base sequence
+	w.ArrayLen(len(records))
+
+	for i, rec := range records {
+		var body Writer
+		body.Int8(0) // attributes
+		body.Varint(rec.Timestamp.UnixMilli() - first)
+		body.Varint(int64(i))
+		body.VarintBytes(rec.Key)
+		body.VarintBytes(rec.Value)
+		body.Varint(int64(len(rec.Headers)))
+		for _, h := range rec.Headers {
+			body.VarintBytes([]byte(h.Key))
+			body.VarintBytes(h.Value)
+		}
+		w.Varint(int64(body.Len()))
+		w.Raw(body.Bytes())
+	}
+
+	batch := w.Bytes()
+	binary.BigEndian.PutUint32(batch[8:], uint32(len(batch)-12))
+	binary.BigEndian.PutUint32(batch[crcOffset:], crc32.Checksum(batch[crcOffset+4:], castagnoli))
+	return batch
+}
+
+// DecodeRecordBatches decodes the uncompressed v2 record batches in b,
+// checking their CRCs.
+func DecodeRecordBatches(b []byte) ([]Record, error) {
+	var records []Record
+	for len(b) > 0 {
+		if len(b) < batchHeaderSize {
+			return nil, ErrMalformed
+		}
+		length := int(int32(binary.BigEndian.Uint32(b[8:])))
+		if length < batchHeaderSize-12 || 12+length > len(b) {
+			return nil, ErrMalformed
+		}
+		batch := b[:12+length]
+		b = b[12+length:]
+
+		r := NewReader(batch)
+		baseOffset := r.Int64()
+		r.Int32() // batch length
+		r.Int32() // partition leader epoch
+		if magic := r.Int8(); magic != 2 {
+			return nil, fmt.Errorf("%w: record batch magic %d", ErrMalformed, magic)
+		}
+		crc := uint32(r.Int32())
+		if crc != crc32.Checksum(batch[crcOffset+4:], castagnoli) {
+			return nil, fmt.Errorf("%w: record batch CRC mismatch", ErrMalformed)
+		}
+		if attributes := r.Int16(); attributes&0x07 != 0 {
+			return nil, fmt.Errorf("%w: compressed record batches are not supported", ErrMalformed)
+		}
+		r.Int32() // last offset delta
+		firstTimestamp := r.Int64()
+		r.Int64() // max timestamp
+		r.Int64() // producer ID
+		r.Int16() // producer epoch
+		r.Int32() // base sequence
+		count := r.ArrayLen()
+
+		for range count {
+			body := NewReader(r.take(int(r.Varint())))
+			body.Int8() // attributes
+			rec := Record{
+				Timestamp: time.UnixMilli(firstTimestamp + body.Varint()),
+			}
+			rec.Offset = baseOffset + body.Varint()
+			rec.Key = body.VarintBytes()
+			rec.Value = body.VarintBytes()
+			headers := body.Varint()
+			if headers < 0 || headers > int64(body.Remaining()) {
+				return nil, ErrMalformed
+			}
+			for range headers {
+				rec.Headers = append(rec.Headers, Header{Key: string(body.VarintBytes()), Value: body.VarintBytes()})
+			}
+			if body.Err() != nil {
+				return nil, body.Err()
+			}
+			records = append(records, rec)
+		}
+		if r.Err() != nil {
+			return nil, r.Err()
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,295 @@
+// Package wire encodes and decodes the parts of the Kafka protocol the
+// producer and the test broker speak: primitive types, request and response
+// framing, and v2 record batches.
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// API keys and the versions used for them. Produce v3 is the oldest version
+// current brokers accept and the first to carry v2 record batches.
+const (
+	APIKeyProduce  int16 = 0
+	APIKeyMetadata int16 = 3
+
+	ProduceVersion  int16 = 3
+	MetadataVersion int16 = 4
+)
+
+// ErrMalformed reports a message that does not decode.
+var ErrMalformed = errors.New("kafka: malformed message")
+
+// maxMessageSize bounds the size prefix read off the network.
+const maxMessageSize = 64 << 20
+
+// Writer appends protocol primitives to a buffer.
+type Writer struct {
+	buf []byte
+}
+
+func (w *Writer) Bytes() []byte { return w.buf }
+func (w *Writer) Len() int      { return len(w.buf) }
+
+func (w *Writer) Int8(v int8)   { w.buf = append(w.buf, byte(v)) }
+func (w *Writer) Int16(v int16) { w.buf = binary.BigEndian.AppendUint16(w.buf, uint16(v)) }
+func (w *Writer) Int32(v int32) { w.buf = binary.BigEndian.AppendUint32(w.buf, uint32(v)) }
+func (w *Writer) Int64(v int64) { w.buf = binary.BigEndian.AppendUint64(w.buf, uint64(v)) }
+
+func (w *Writer) Bool(v bool) {
+	if v {
+		w.Int8(1)
+	} else {
+		w.Int8(0)
+	}
+}
+
+func (w *Writer) String(s string) {
+	w.Int16(int16(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// NullableString writes s, or null when s is nil.
+func (w *Writer) NullableString(s *string) {
+	if s == nil {
+		w.Int16(-1)
+		return
+	}
+	w.String(*s)
+}
+
+// BytesField writes b with a 32-bit length, or null when b is nil.
+func (w *Writer) BytesField(b []byte) {
+	if b == nil {
+		w.Int32(-1)
+		return
+	}
+	w.Int32(int32(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+// ArrayLen starts an array of n elements.
+func (w *Writer) ArrayLen(n int) { w.Int32(int32(n)) }
+
+// Varint writes v zigzag encoded, as record fields are.
+func (w *Writer) Varint(v int64) { w.buf = binary.AppendVarint(w.buf, v) }
+
+// VarintBytes writes b with a varint length, or null when b is nil.
+func (w *Writer) VarintBytes(b []byte) {
+	if b == nil {
+		w.Varint(-1)
+		return
+	}
+	w.Varint(int64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+func (w *Writer) Raw(b []byte) { w.buf = append(w.buf, b...) }
+
+// Reader consumes protocol primitives from a buffer. The first failure is
+// kept in Err and makes every later read return zero values.
+type Reader struct {
+	buf []byte
+	err error
+}
+
+func NewReader(b []byte) *Reader { return &Reader{buf: b} }
+
+func (r *Reader) Err() error     { return r.err }
+func (r *Reader) Remaining() int { return len(r.buf) }
+
+func (r *Reader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.buf) {
+		r.err = ErrMalformed
+		return nil
+	}
+	b := r.buf[:n:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *Reader) Int8() int8 {
+	b := r.take(1)
+	if b == nil {
+		return 0
+	}
+	return int8(b[0])
+}
+
+func (r *Reader) Int16() int16 {
+	b := r.take(2)
+	if b == nil {
+		return 0
+	}
+	return int16(binary.BigEndian.Uint16(b))
+}
+
+func (r *Reader) Int32() int32 {
+	b := r.take(4)
+	if b == nil {
+		return 0
+	}
+	return int32(binary.BigEndian.Uint32(b))
+}
+
+func (r *Reader) Int64() int64 {
+	b := r.take(8)
+	if b == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(b))
+}
+
+func (r *Reader) Bool() bool { return r.Int8() != 0 }
+
+func (r *Reader) String() string {
+	n := r.Int16()
+	if n < 0 {
+		r.fail()
+		return ""
+	}
+	return string(r.take(int(n)))
+}
+
+func (r *Reader) NullableString() *string {
+	n := r.Int16()
+	if n < 0 {
+		return nil
+	}
+	s := string(r.take(int(n)))
+	return &s
+}
+
+func (r *Reader) BytesField() []byte {
+	n := r.Int32()
+	if n < 0 {
+		return nil
+	}
+	return r.take(int(n))
+}
+
+// ArrayLen reads the length of an array; a null array has length 0.
+func (r *Reader) ArrayLen() int {
+	n := r.Int32()
+	if n < 0 {
+		return 0
+	}
+	if int(n) > len(r.buf) {
+		// Every element takes at least a byte.
+		r.fail()
+		return 0
+	}
+	return int(n)
+}
+
+func (r *Reader) Varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *Reader) VarintBytes() []byte {
+	n := r.Varint()
+	if n < 0 {
+		return nil
+	}
+	return r.take(int(n))
+}
+
+func (r *Reader) fail() {
+	if r.err == nil {
+		r.err = ErrMalformed
+	}
+}
+
+// RequestHeader is the v1 request header, used by every non-flexible API
+// version.
+type RequestHeader struct {
+	APIKey        int16
+	APIVersion    int16
+	CorrelationID int32
+	ClientID      *string
+}
+
+// WriteRequest frames a request: size, header, body.
+func WriteRequest(out io.Writer, h RequestHeader, body []byte) error {
+	var w Writer
+	w.Int32(0) // size, filled in below
+	w.Int16(h.APIKey)
+	w.Int16(h.APIVersion)
+	w.Int32(h.CorrelationID)
+	w.NullableString(h.ClientID)
+	w.Raw(body)
+	msg := w.Bytes()
+	binary.BigEndian.PutUint32(msg, uint32(len(msg)-4))
+	_, err := out.Write(msg)
+	return err
+}
+
+// ReadRequest reads one framed request and returns its header and body.
+func ReadRequest(in io.Reader) (RequestHeader, []byte, error) {
+	msg, err := readFrame(in)
+	if err != nil {
+		return RequestHeader{}, nil, err
+	}
+	r := NewReader(msg)
+	h := RequestHeader{APIKey: r.Int16(), APIVersion: r.Int16(), CorrelationID: r.Int32(), ClientID: r.NullableString()}
+	if r.Err() != nil {
+		return RequestHeader{}, nil, r.Err()
+	}
+	return h, r.buf, nil
+}
+
+// WriteResponse frames a response: size, correlation ID, body.
+func WriteResponse(out io.Writer, correlationID int32, body []byte) error {
+	var w Writer
+	w.Int32(int32(4 + len(body)))
+	w.Int32(correlationID)
+	w.Raw(body)
+	_, err := out.Write(w.Bytes())
+	return err
+}
+
+// ReadResponse reads one framed response and returns its correlation ID
+// and body.
+func ReadResponse(in io.Reader) (int32, []byte, error) {
+	msg, err := readFrame(in)
+	if err != nil {
+		return 0, nil, err
+	}
+	r := NewReader(msg)
+	id := r.Int32()
+	if r.Err() != nil {
+		return 0, nil, r.Err()
+	}
+	return id, r.buf, nil
+}
+
+func readFrame(in io.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(in, size[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxMessageSize {
+		return nil, fmt.Errorf("%w: message of %d bytes", ErrMalformed, n)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(in, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
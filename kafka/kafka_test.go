@@ -0,0 +1,222 @@
+package kafka
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/martinusiron/loan-service/configs"
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/kafka/internal/wire"
+	"github.com/martinusiron/loan-service/kafka/kafkatest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMurmur2MatchesJavaClient(t *testing.T) {
+	for key, want := range map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+		"abc": 479470107,
+	} {
+		assert.Equal(t, want, murmur2([]byte(key)), key)
+	}
+}
+
+func TestRecordBatchRoundTrip(t *testing.T) {
+	at := time.UnixMilli(1754006400123)
+	in := []wire.Record{
+		{Timestamp: at, Key: []byte("7"), Value: []byte("one"), Headers: []wire.Header{{Key: "event", Value: []byte("loan.approved")}}},
+		{Timestamp: at.Add(time.Second), Value: []byte("two")},
+	}
+
+	out, err := wire.DecodeRecordBatches(wire.EncodeRecordBatch(in))
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	assert.Equal(t, int64(1), out[1].Offset)
+	assert.True(t, out[0].Timestamp.Equal(at))
+	assert.Equal(t, []byte("7"), out[0].Key)
+	assert.Nil(t, out[1].Key)
+	assert.Equal(t, in[0].Headers, out[0].Headers)
+
+	corrupt := wire.EncodeRecordBatch(in)
+	corrupt[len(corrupt)-1] ^= 0xff
+	_, err = wire.DecodeRecordBatches(corrupt)
+	assert.ErrorIs(t, err, wire.ErrMalformed)
+}
+
+func sampleRecord() domain.EventRecord {
+	return domain.EventRecord{
+		ID:         42,
+		Name:       domain.EventLoanApproved,
+		LoanID:     7,
+		OccurredAt: time.Date(2025, 8, 1, 9, 30, 0, 500, time.UTC),
+		Data:       json.RawMessage(`{"loan_id":7}`),
+	}
+}
+
+// decodeProto splits a protobuf message into its varint and bytes fields.
+func decodeProto(t *testing.T, b []byte) map[int]any {
+	fields := map[int]any{}
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		require.Positive(t, n)
+		b = b[n:]
+		switch tag & 7 {
+		case wireVarint:
+			v, n := binary.Uvarint(b)
+			require.Positive(t, n)
+			fields[int(tag>>3)], b = v, b[n:]
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			require.Positive(t, n)
+			fields[int(tag>>3)], b = b[n:n+int(l)], b[n+int(l):]
+		default:
+			t.Fatalf("unexpected wire type %d", tag&7)
+		}
+	}
+	return fields
+}
+
+func TestProtobufSerializer(t *testing.T) {
+	rec := sampleRecord()
+	b, err := ProtobufSerializer{}.Serialize(rec)
+	require.NoError(t, err)
+
+	fields := decodeProto(t, b)
+	assert.Equal(t, uint64(42), fields[1])
+	assert.Equal(t, []byte("loan.approved"), fields[2])
+	assert.Equal(t, uint64(7), fields[3])
+	assert.Equal(t, []byte(`{"loan_id":7}`), fields[5])
+	ts := decodeProto(t, fields[4].([]byte))
+	assert.Equal(t, uint64(rec.OccurredAt.Unix()), ts[1])
+	assert.Equal(t, uint64(500), ts[2])
+}
+
+func startBroker(t *testing.T, partitions int32) *kafkatest.Broker {
+	b, err := kafkatest.Start(map[string]int32{"loan-events": partitions})
+	require.NoError(t, err)
+	t.Cleanup(func() { b.Close() })
+	return b
+}
+
+func TestProducer_StreamsKeyedByLoan(t *testing.T) {
+	broker := startBroker(t, 3)
+	p := NewProducer([]string{broker.Addr()}, "loan-events", JSONSerializer{})
+	defer p.Close()
+
+	for _, loanID := range []int{7, 8, 7} {
+		rec := sampleRecord()
+		rec.LoanID = loanID
+		require.NoError(t, p.Stream(context.Background(), rec))
+	}
+
+	msgs := broker.Messages("loan-events")
+	require.Len(t, msgs, 3)
+	for _, m := range msgs {
+		assert.Equal(t, partitionFor(m.Key, 3), m.Partition)
+		assert.Equal(t, "loan.approved", m.Headers["event"])
+		assert.Equal(t, "application/json", m.Headers["content-type"])
+		assert.True(t, m.Timestamp.Equal(sampleRecord().OccurredAt.Truncate(time.Millisecond)))
+	}
+	assert.Equal(t, "7", string(msgs[0].Key))
+	assert.Equal(t, "7", string(msgs[2].Key))
+	assert.Equal(t, msgs[0].Partition, msgs[2].Partition)
+	assert.Greater(t, msgs[2].Offset, msgs[0].Offset)
+
+	var got domain.EventRecord
+	require.NoError(t, json.Unmarshal(msgs[1].Value, &got))
+	assert.Equal(t, int64(42), got.ID)
+	assert.Equal(t, 8, got.LoanID)
+}
+
+func TestProducer_ProtobufValues(t *testing.T) {
+	broker := startBroker(t, 1)
+	s, err := NewSerializer("protobuf")
+	require.NoError(t, err)
+	p := NewProducer([]string{broker.Addr()}, "loan-events", s)
+	defer p.Close()
+
+	require.NoError(t, p.Stream(context.Background(), sampleRecord()))
+
+	msgs := broker.Messages("loan-events")
+	require.Len(t, msgs, 1)
+	assert.Equal(t, s.ContentType(), msgs[0].Headers["content-type"])
+	assert.Equal(t, uint64(7), decodeProto(t, msgs[0].Value)[3])
+}
+
+func TestProducer_RetriesOnceWhenLeaderMoved(t *testing.T) {
+	broker := startBroker(t, 1)
+	p := NewProducer([]string{broker.Addr()}, "loan-events", JSONSerializer{})
+	defer p.Close()
+
+	broker.FailProduce(int16(ErrNotLeaderOrFollower), 1)
+	require.NoError(t, p.Stream(context.Background(), sampleRecord()))
+	assert.Len(t, broker.Messages("loan-events"), 1)
+
+	broker.FailProduce(int16(ErrNotLeaderOrFollower), 2)
+	err := p.Stream(context.Background(), sampleRecord())
+	assert.ErrorIs(t, err, ErrNotLeaderOrFollower)
+	assert.Len(t, broker.Messages("loan-events"), 1)
+}
+
+func TestProducer_Errors(t *testing.T) {
+	broker := startBroker(t, 1)
+
+	t.Run("unknown topic", func(t *testing.T) {
+		p := NewProducer([]string{broker.Addr()}, "other", JSONSerializer{})
+		defer p.Close()
+		assert.ErrorIs(t, p.Stream(context.Background(), sampleRecord()), ErrUnknownTopicOrPartition)
+	})
+
+	t.Run("oversized records are permanent", func(t *testing.T) {
+		p := NewProducer([]string{broker.Addr()}, "loan-events", JSONSerializer{})
+		defer p.Close()
+		broker.FailProduce(int16(ErrMessageTooLarge), 1)
+
+		err := p.Stream(context.Background(), sampleRecord())
+		var kerr Error
+		require.True(t, errors.As(err, &kerr))
+		assert.True(t, kerr.Permanent())
+	})
+
+	t.Run("broker gone", func(t *testing.T) {
+		gone := startBroker(t, 1)
+		p := NewProducer([]string{gone.Addr()}, "loan-events", JSONSerializer{})
+		defer p.Close()
+		require.NoError(t, p.Stream(context.Background(), sampleRecord()))
+		gone.Close()
+
+		assert.Error(t, p.Stream(context.Background(), sampleRecord()))
+	})
+}
+
+func TestNewFromConfig(t *testing.T) {
+	p, err := NewFromConfig(configs.KafkaConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	p, err = NewFromConfig(configs.KafkaConfig{Brokers: []string{"kafka:9092"}, Serialization: "protobuf", Acks: "leader", Timeout: "3s"})
+	require.NoError(t, err)
+	assert.Equal(t, "loan-events", p.Topic)
+	assert.Equal(t, int16(1), p.RequiredAcks)
+	assert.Equal(t, 3*time.Second, p.Timeout)
+	assert.IsType(t, ProtobufSerializer{}, p.Serializer)
+
+	for _, cfg := range []configs.KafkaConfig{
+		{Brokers: []string{"kafka:9092"}, Serialization: "avro"},
+		{Brokers: []string{"kafka:9092"}, Acks: "none"},
+		{Brokers: []string{"kafka:9092"}, Timeout: "soon"},
+	} {
+		_, err := NewFromConfig(cfg)
+		assert.Error(t, err, strconv.Quote(cfg.Serialization+cfg.Acks+cfg.Timeout))
+	}
+}
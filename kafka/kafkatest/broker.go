@@ -0,0 +1,222 @@
+// Package kafkatest provides an in-process, single-node Kafka broker for
+// tests. It answers the Metadata and Produce requests the kafka package
+// sends and keeps the produced records in memory.
+package kafkatest
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/martinusiron/loan-service/kafka/internal/wire"
+)
+
+// Message is a record stored by the broker.
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Timestamp time.Time
+}
+
+// Broker is a fake Kafka broker listening on a local port.
+type Broker struct {
+	ln net.Listener
+
+	mu         sync.Mutex
+	partitions map[string]int32
+	logs       map[string][]Message
+	failCode   int16
+	failTimes  int
+	wg         sync.WaitGroup
+	conns      map[net.Conn]struct{}
+}
+
+const nodeID = 1
+
+// Start starts a broker holding the given topics, by partition count.
+// Producing to any other topic fails with UNKNOWN_TOPIC_OR_PARTITION.
+func Start(topics map[string]int32) (*Broker, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	b := &Broker{ln: ln, partitions: topics, logs: map[string][]Message{}, conns: map[net.Conn]struct{}{}}
+	b.wg.Add(1)
+	go b.serve()
+	return b, nil
+}
+
+// Addr is the broker's host:port.
+func (b *Broker) Addr() string { return b.ln.Addr().String() }
+
+// Close stops the broker and drops its connections.
+func (b *Broker) Close() error {
+	err := b.ln.Close()
+	b.mu.Lock()
+	for c := range b.conns {
+		c.Close()
+	}
+	b.mu.Unlock()
+	b.wg.Wait()
+	return err
+}
+
+// FailProduce makes the next n produce requests fail with the Kafka error
+// code.
+func (b *Broker) FailProduce(code int16, n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failCode, b.failTimes = code, n
+}
+
+// Messages returns the records produced to topic, in the order the broker
+// stored them.
+func (b *Broker) Messages(topic string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Message(nil), b.logs[topic]...)
+}
+
+func (b *Broker) serve() {
+	defer b.wg.Done()
+	for {
+		c, err := b.ln.Accept()
+		if err != nil {
+			return
+		}
+		b.mu.Lock()
+		b.conns[c] = struct{}{}
+		b.mu.Unlock()
+		b.wg.Add(1)
+		go b.handle(c)
+	}
+}
+
+func (b *Broker) handle(c net.Conn) {
+	defer b.wg.Done()
+	defer func() {
+		b.mu.Lock()
+		delete(b.conns, c)
+		b.mu.Unlock()
+		c.Close()
+	}()
+	for {
+		h, body, err := wire.ReadRequest(c)
+		if err != nil {
+			return
+		}
+		var resp []byte
+		switch {
+		case h.APIKey == wire.APIKeyMetadata && h.APIVersion == wire.MetadataVersion:
+			resp, err = b.metadata(body)
+		case h.APIKey == wire.APIKeyProduce && h.APIVersion == wire.ProduceVersion:
+			resp, err = b.produce(body)
+		default:
+			err = errors.New("unsupported request")
+		}
+		if err != nil {
+			return
+		}
+		if err := wire.WriteResponse(c, h.CorrelationID, resp); err != nil {
+			return
+		}
+	}
+}
+
+func (b *Broker) metadata(body []byte) ([]byte, error) {
+	req, err := wire.DecodeMetadataRequest(body)
+	if err != nil {
+		return nil, err
+	}
+	host, portStr, _ := net.SplitHostPort(b.Addr())
+	port, _ := strconv.Atoi(portStr)
+	resp := wire.MetadataResponse{
+		Brokers:      []wire.BrokerMetadata{{NodeID: nodeID, Host: host, Port: int32(port)}},
+		ControllerID: nodeID,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, name := range req.Topics {
+		n, ok := b.partitions[name]
+		if !ok {
+			resp.Topics = append(resp.Topics, wire.TopicMetadata{ErrorCode: 3, Name: name})
+			continue
+		}
+		t := wire.TopicMetadata{Name: name}
+		for p := int32(0); p < n; p++ {
+			t.Partitions = append(t.Partitions, wire.PartitionMetadata{
+				Partition: p, Leader: nodeID, Replicas: []int32{nodeID}, ISR: []int32{nodeID},
+			})
+		}
+		resp.Topics = append(resp.Topics, t)
+	}
+	return resp.Encode(), nil
+}
+
+func (b *Broker) produce(body []byte) ([]byte, error) {
+	req, err := wire.DecodeProduceRequest(body)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var failCode int16
+	if b.failTimes > 0 {
+		failCode = b.failCode
+		b.failTimes--
+	}
+
+	var resp wire.ProduceResponse
+	for _, t := range req.Topics {
+		tr := wire.ProduceTopicResponse{Name: t.Name}
+		for _, p := range t.Partitions {
+			pr := wire.ProducePartitionResponse{Partition: p.Partition, BaseOffset: -1, LogAppendTimeMs: -1}
+			switch n, ok := b.partitions[t.Name]; {
+			case failCode != 0:
+				pr.ErrorCode = failCode
+			case !ok || p.Partition < 0 || p.Partition >= n:
+				pr.ErrorCode = 3
+			default:
+				pr.BaseOffset, pr.ErrorCode = b.append(t.Name, p.Partition, p.Records)
+			}
+			tr.Partitions = append(tr.Partitions, pr)
+		}
+		resp.Topics = append(resp.Topics, tr)
+	}
+	return resp.Encode(), nil
+}
+
+// append stores a partition's records and returns the offset of the first.
+func (b *Broker) append(topic string, partition int32, batches []byte) (int64, int16) {
+	records, err := wire.DecodeRecordBatches(batches)
+	if err != nil || len(records) == 0 {
+		return -1, 2
+	}
+	var next int64
+	for _, m := range b.logs[topic] {
+		if m.Partition == partition {
+			next = m.Offset + 1
+		}
+	}
+	base := next
+	for _, r := range records {
+		headers := map[string]string{}
+		for _, h := range r.Headers {
+			headers[h.Key] = string(h.Value)
+		}
+		b.logs[topic] = append(b.logs[topic], Message{
+			Topic: topic, Partition: partition, Offset: next,
+			Key: r.Key, Value: r.Value, Headers: headers, Timestamp: r.Timestamp,
+		})
+		next++
+	}
+	return base, 0
+}
@@ -0,0 +1,288 @@
+// Package kafka streams loan events to a Kafka topic. It speaks the small
+// part of the Kafka protocol a producer needs (Metadata and Produce, with
+// uncompressed v2 record batches), so it works with Kafka and compatible
+// brokers such as Redpanda without a client library.
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/kafka/internal/wire"
+)
+
+// Header is a record header.
+type Header = wire.Header
+
+// Message is a record to produce.
+type Message struct {
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+	Timestamp time.Time
+}
+
+// Producer writes records to one topic. Keyed records go to the partition
+// Kafka's default partitioner would pick, so all events of a loan land on
+// one partition, in order. A produce is tried once, plus once more right
+// away when the partition's leader moved; retrying later is left to the
+// caller, usually the outbox dispatcher.
+type Producer struct {
+	// Brokers are the host:port addresses metadata is bootstrapped from.
+	Brokers    []string
+	Topic      string
+	ClientID   string
+	Serializer Serializer
+	// RequiredAcks is -1 to wait for every in-sync replica (the default) or
+	// 1 to wait for the leader only.
+	RequiredAcks int16
+	// Timeout bounds connecting and every request.
+	Timeout time.Duration
+
+	mu            sync.Mutex
+	correlationID int32
+	meta          *topicMetadata
+	conns         map[string]net.Conn
+}
+
+type topicMetadata struct {
+	partitions int
+	// leaders maps partitions to their leader's address.
+	leaders map[int32]string
+}
+
+func NewProducer(brokers []string, topic string, s Serializer) *Producer {
+	return &Producer{
+		Brokers:      brokers,
+		Topic:        topic,
+		ClientID:     "loan-service",
+		Serializer:   s,
+		RequiredAcks: -1,
+		Timeout:      10 * time.Second,
+		conns:        map[string]net.Conn{},
+	}
+}
+
+// Stream produces rec keyed by its loan ID, with its name in the "event"
+// header.
+func (p *Producer) Stream(ctx context.Context, rec domain.EventRecord) error {
+	value, err := p.Serializer.Serialize(rec)
+	if err != nil {
+		return permanentError{fmt.Errorf("serialize %s event: %w", rec.Name, err)}
+	}
+	_, _, err = p.Produce(ctx, Message{
+		Key:   []byte(strconv.Itoa(rec.LoanID)),
+		Value: value,
+		Headers: []Header{
+			{Key: "event", Value: []byte(rec.Name)},
+			{Key: "content-type", Value: []byte(p.Serializer.ContentType())},
+		},
+		Timestamp: rec.OccurredAt,
+	})
+	return err
+}
+
+// Produce writes m and returns the partition and offset it was stored at.
+func (p *Producer) Produce(ctx context.Context, m Message) (int32, int64, error) {
+	if m.Timestamp.IsZero() {
+		m.Timestamp = time.Now()
+	}
+	batch := wire.EncodeRecordBatch([]wire.Record{{Timestamp: m.Timestamp, Key: m.Key, Value: m.Value, Headers: m.Headers}})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		partition, offset, err := p.produce(ctx, m.Key, batch)
+		var kerr Error
+		if err != nil && errors.As(err, &kerr) && kerr.staleMetadata() && attempt == 1 {
+			continue
+		}
+		return partition, offset, err
+	}
+}
+
+func (p *Producer) produce(ctx context.Context, key, batch []byte) (int32, int64, error) {
+	if p.meta == nil {
+		meta, err := p.fetchMetadata(ctx)
+		if err != nil {
+			return 0, 0, err
+		}
+		p.meta = meta
+	}
+
+	var partition int32
+	if key != nil {
+		partition = partitionFor(key, p.meta.partitions)
+	}
+	leader, ok := p.meta.leaders[partition]
+	if !ok {
+		p.meta = nil
+		return 0, 0, ErrLeaderNotAvailable
+	}
+
+	req := wire.ProduceRequest{
+		Acks:      p.RequiredAcks,
+		TimeoutMs: int32(p.Timeout / time.Millisecond),
+		Topics: []wire.ProduceTopic{{
+			Name:       p.Topic,
+			Partitions: []wire.ProducePartition{{Partition: partition, Records: batch}},
+		}},
+	}
+	body, err := p.roundTrip(ctx, leader, wire.APIKeyProduce, wire.ProduceVersion, req.Encode())
+	if err != nil {
+		p.meta = nil
+		return 0, 0, err
+	}
+	resp, err := wire.DecodeProduceResponse(body)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, t := range resp.Topics {
+		for _, part := range t.Partitions {
+			if t.Name != p.Topic || part.Partition != partition {
+				continue
+			}
+			if code := Error(part.ErrorCode); code != ErrNone {
+				if code.staleMetadata() {
+					p.meta = nil
+				}
+				return 0, 0, code
+			}
+			return partition, part.BaseOffset, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("kafka: no produce response for %s/%d", p.Topic, partition)
+}
+
+// fetchMetadata asks the bootstrap brokers in turn for the topic's
+// partitions and their leaders.
+func (p *Producer) fetchMetadata(ctx context.Context) (*topicMetadata, error) {
+	req := wire.MetadataRequest{Topics: []string{p.Topic}, AllowAutoTopicCreation: true}
+
+	var errs []error
+	for _, addr := range p.Brokers {
+		body, err := p.roundTrip(ctx, addr, wire.APIKeyMetadata, wire.MetadataVersion, req.Encode())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resp, err := wire.DecodeMetadataResponse(body)
+		if err != nil {
+			return nil, err
+		}
+		return p.topicMetadata(resp)
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("kafka: no brokers configured")
+	}
+	return nil, fmt.Errorf("kafka: no broker answered: %w", errors.Join(errs...))
+}
+
+func (p *Producer) topicMetadata(resp wire.MetadataResponse) (*topicMetadata, error) {
+	brokers := map[int32]string{}
+	for _, b := range resp.Brokers {
+		brokers[b.NodeID] = net.JoinHostPort(b.Host, strconv.Itoa(int(b.Port)))
+	}
+
+	for _, t := range resp.Topics {
+		if t.Name != p.Topic {
+			continue
+		}
+		if t.ErrorCode != 0 {
+			return nil, Error(t.ErrorCode)
+		}
+		if len(t.Partitions) == 0 {
+			return nil, ErrLeaderNotAvailable
+		}
+		meta := &topicMetadata{partitions: len(t.Partitions), leaders: map[int32]string{}}
+		for _, part := range t.Partitions {
+			if addr, ok := brokers[part.Leader]; ok && part.ErrorCode == 0 {
+				meta.leaders[part.Partition] = addr
+			}
+		}
+		return meta, nil
+	}
+	return nil, ErrUnknownTopicOrPartition
+}
+
+// roundTrip sends a request to the broker at addr and waits for the
+// response. The connection is dropped on any error, since its stream may
+// be out of step.
+func (p *Producer) roundTrip(ctx context.Context, addr string, apiKey, version int16, body []byte) ([]byte, error) {
+	conn, err := p.conn(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(p.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	p.correlationID++
+	id := p.correlationID
+	header := wire.RequestHeader{APIKey: apiKey, APIVersion: version, CorrelationID: id, ClientID: &p.ClientID}
+	if err := wire.WriteRequest(conn, header, body); err != nil {
+		p.dropConn(addr)
+		return nil, fmt.Errorf("kafka: send to %s: %w", addr, err)
+	}
+	gotID, resp, err := wire.ReadResponse(conn)
+	if err != nil {
+		p.dropConn(addr)
+		return nil, fmt.Errorf("kafka: read from %s: %w", addr, err)
+	}
+	if gotID != id {
+		p.dropConn(addr)
+		return nil, fmt.Errorf("kafka: %s answered request %d instead of %d", addr, gotID, id)
+	}
+	return resp, nil
+}
+
+func (p *Producer) conn(ctx context.Context, addr string) (net.Conn, error) {
+	if c, ok := p.conns[addr]; ok {
+		return c, nil
+	}
+	d := net.Dialer{Timeout: p.Timeout}
+	c, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: %w", err)
+	}
+	if p.conns == nil {
+		p.conns = map[string]net.Conn{}
+	}
+	p.conns[addr] = c
+	return c, nil
+}
+
+func (p *Producer) dropConn(addr string) {
+	if c, ok := p.conns[addr]; ok {
+		c.Close()
+		delete(p.conns, addr)
+	}
+}
+
+// Close closes the producer's connections.
+func (p *Producer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for addr := range p.conns {
+		p.dropConn(addr)
+	}
+	return nil
+}
+
+// permanentError marks a failure that producing again cannot fix.
+type permanentError struct{ error }
+
+func (e permanentError) Unwrap() error   { return e.error }
+func (e permanentError) Permanent() bool { return true }
@@ -0,0 +1,85 @@
+package kafka
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/martinusiron/loan-service/domain"
+)
+
+// Serializer turns an event into a record value.
+type Serializer interface {
+	Serialize(rec domain.EventRecord) ([]byte, error)
+	// ContentType is sent in the content-type header of every record.
+	ContentType() string
+}
+
+// NewSerializer returns the serializer for format: "json" (the default) or
+// "protobuf".
+func NewSerializer(format string) (Serializer, error) {
+	switch format {
+	case "", "json":
+		return JSONSerializer{}, nil
+	case "protobuf":
+		return ProtobufSerializer{}, nil
+	}
+	return nil, fmt.Errorf("unknown kafka serialization %q", format)
+}
+
+// JSONSerializer writes events as JSON objects with id, name, loan_id,
+// occurred_at and data.
+type JSONSerializer struct{}
+
+func (JSONSerializer) Serialize(rec domain.EventRecord) ([]byte, error) {
+	return json.Marshal(rec)
+}
+
+func (JSONSerializer) ContentType() string { return "application/json" }
+
+// ProtobufSerializer writes events as the LoanEvent message described in
+// loan_event.proto.
+type ProtobufSerializer struct{}
+
+// Protobuf wire types.
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+func (ProtobufSerializer) Serialize(rec domain.EventRecord) ([]byte, error) {
+	var ts []byte
+	ts = appendVarintField(ts, 1, uint64(rec.OccurredAt.Unix()))
+	ts = appendVarintField(ts, 2, uint64(rec.OccurredAt.Nanosecond()))
+
+	var b []byte
+	b = appendVarintField(b, 1, uint64(rec.ID))
+	b = appendBytesField(b, 2, []byte(rec.Name))
+	b = appendVarintField(b, 3, uint64(rec.LoanID))
+	b = appendBytesField(b, 4, ts)
+	b = appendBytesField(b, 5, rec.Data)
+	return b, nil
+}
+
+func (ProtobufSerializer) ContentType() string {
+	return "application/x-protobuf; messageType=loanservice.events.v1.LoanEvent"
+}
+
+// appendVarintField appends a varint field, leaving it out when it holds
+// the default zero as proto3 does.
+func appendVarintField(b []byte, field int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+func appendBytesField(b []byte, field int, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
@@ -0,0 +1,78 @@
+// Package ledger records every money movement of the loan service as a
+// double-entry journal. Each entry debits and credits accounts by the same
+// total, so the books always balance and every balance can be traced back to
+// the entries that produced it.
+package ledger
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type AccountType string
+
+const (
+	// AccountCash is the platform's settlement bank account.
+	AccountCash AccountType = "cash"
+	// AccountInvestorWallet is money the platform holds for an investor.
+	AccountInvestorWallet AccountType = "investor_wallet"
+	// AccountLoanFunding is investor capital committed to a loan. It is
+	// released back to the investors' wallets as principal is repaid.
+	AccountLoanFunding AccountType = "loan_funding"
+	// AccountLoanReceivable is principal the borrower still owes.
+	AccountLoanReceivable AccountType = "loan_receivable"
+	// AccountBorrowerPayable is money the platform owes a borrower: the
+	// disbursement before it is paid out, and any over-payment.
+	AccountBorrowerPayable AccountType = "borrower_payable"
+	// AccountPlatformRevenue collects the interest spread and fees.
+	AccountPlatformRevenue AccountType = "platform_revenue"
+)
+
+// DebitNormal reports whether the account type is an asset, whose balance
+// grows with debits. All other accounts grow with credits.
+func (t AccountType) DebitNormal() bool {
+	return t == AccountCash || t == AccountLoanReceivable
+}
+
+// Account identifies one ledger account: a type and, for per-party accounts,
+// the investor or loan it belongs to.
+type Account struct {
+	Type AccountType
+	Ref  string
+}
+
+// Code is the account's unique key, e.g. "investor_wallet:ann@example.com".
+func (a Account) Code() string {
+	if a.Ref == "" {
+		return string(a.Type)
+	}
+	return fmt.Sprintf("%s:%s", a.Type, a.Ref)
+}
+
+func (a Account) String() string {
+	return a.Code()
+}
+
+func Cash() Account {
+	return Account{Type: AccountCash}
+}
+
+func PlatformRevenue() Account {
+	return Account{Type: AccountPlatformRevenue}
+}
+
+func InvestorWallet(email string) Account {
+	return Account{Type: AccountInvestorWallet, Ref: email}
+}
+
+func LoanFunding(loanID int) Account {
+	return Account{Type: AccountLoanFunding, Ref: strconv.Itoa(loanID)}
+}
+
+func LoanReceivable(loanID int) Account {
+	return Account{Type: AccountLoanReceivable, Ref: strconv.Itoa(loanID)}
+}
+
+func BorrowerPayable(loanID int) Account {
+	return Account{Type: AccountBorrowerPayable, Ref: strconv.Itoa(loanID)}
+}
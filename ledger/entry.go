@@ -0,0 +1,99 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+)
+
+var ErrUnbalanced = errors.New("journal entry does not balance")
+
+type Direction string
+
+const (
+	Debit  Direction = "debit"
+	Credit Direction = "credit"
+)
+
+type EntryKind string
+
+const (
+	EntryInvestment   EntryKind = "investment"
+	EntryDisbursement EntryKind = "disbursement"
+	EntryRepayment    EntryKind = "repayment"
+	EntryTopUp        EntryKind = "top_up"
+	EntryWithdrawal   EntryKind = "withdrawal"
+)
+
+type Line struct {
+	ID        int
+	Account   Account
+	Direction Direction
+	Amount    domain.Money
+}
+
+// Entry is an immutable journal entry. Once posted it is never updated or
+// deleted; corrections are made with a new entry.
+type Entry struct {
+	ID        int
+	Kind      EntryKind
+	LoanID    int
+	Reference string
+	Lines     []Line
+	PostedAt  time.Time
+}
+
+func NewEntry(kind EntryKind, loanID int, reference string) *Entry {
+	return &Entry{Kind: kind, LoanID: loanID, Reference: reference, PostedAt: time.Now()}
+}
+
+// Debit adds a debit line. Zero amounts are skipped so callers can post
+// optional components without checking them first.
+func (e *Entry) Debit(a Account, amount domain.Money) *Entry {
+	return e.add(a, Debit, amount)
+}
+
+// Credit adds a credit line. Zero amounts are skipped.
+func (e *Entry) Credit(a Account, amount domain.Money) *Entry {
+	return e.add(a, Credit, amount)
+}
+
+func (e *Entry) add(a Account, d Direction, amount domain.Money) *Entry {
+	if !amount.IsZero() {
+		e.Lines = append(e.Lines, Line{Account: a, Direction: d, Amount: amount})
+	}
+	return e
+}
+
+// Validate checks that the entry has lines, that every amount is positive and
+// in the same currency, and that debits equal credits.
+func (e *Entry) Validate() error {
+	if len(e.Lines) < 2 {
+		return fmt.Errorf("%w: needs at least two lines", ErrUnbalanced)
+	}
+
+	currency := e.Lines[0].Amount.Currency
+	debits, credits := domain.Zero(currency), domain.Zero(currency)
+	for _, l := range e.Lines {
+		if !l.Amount.IsPositive() {
+			return fmt.Errorf("%w: line amounts must be positive", ErrUnbalanced)
+		}
+		if err := l.Amount.CheckCurrency(debits); err != nil {
+			return err
+		}
+		switch l.Direction {
+		case Debit:
+			debits = debits.Add(l.Amount)
+		case Credit:
+			credits = credits.Add(l.Amount)
+		default:
+			return fmt.Errorf("%w: unknown direction %q", ErrUnbalanced, l.Direction)
+		}
+	}
+	if debits.Cmp(credits) != 0 {
+		return fmt.Errorf("%w: debits %s, credits %s", ErrUnbalanced, debits, credits)
+	}
+	return nil
+}
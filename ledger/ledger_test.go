@@ -0,0 +1,75 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func idr(s string) domain.Money {
+	return domain.MustParseMoney(s, domain.CurrencyIDR)
+}
+
+func TestEntryValidate(t *testing.T) {
+	e := NewEntry(EntryInvestment, 1, "").
+		Debit(Cash(), idr("100")).
+		Credit(PlatformRevenue(), idr("60")).
+		Credit(PlatformRevenue(), idr("40"))
+	assert.NoError(t, e.Validate())
+
+	e = NewEntry(EntryInvestment, 1, "").
+		Debit(Cash(), idr("100")).
+		Credit(PlatformRevenue(), idr("99.99"))
+	assert.ErrorIs(t, e.Validate(), ErrUnbalanced)
+
+	e = NewEntry(EntryInvestment, 1, "").Debit(Cash(), idr("100"))
+	assert.ErrorIs(t, e.Validate(), ErrUnbalanced)
+}
+
+func TestEntrySkipsZeroLines(t *testing.T) {
+	e := NewEntry(EntryRepayment, 1, "").
+		Debit(Cash(), idr("10")).
+		Credit(BorrowerPayable(1), idr("0")).
+		Credit(PlatformRevenue(), idr("10"))
+	assert.Len(t, e.Lines, 2)
+}
+
+func TestAccountCode(t *testing.T) {
+	assert.Equal(t, "cash", Cash().Code())
+	assert.Equal(t, "investor_wallet:a@example.com", InvestorWallet("a@example.com").Code())
+	assert.Equal(t, "loan_receivable:7", LoanReceivable(7).Code())
+	assert.True(t, AccountLoanReceivable.DebitNormal())
+	assert.False(t, AccountInvestorWallet.DebitNormal())
+}
+
+func TestRepaymentEntryBalances(t *testing.T) {
+	r := domain.Repayment{
+		ID:             3,
+		LoanID:         1,
+		Amount:         idr("103000"),
+		Principal:      idr("100000"),
+		Interest:       idr("2000"),
+		Fee:            idr("0"),
+		Excess:         idr("1000"),
+		PlatformMargin: idr("500"),
+		Payouts: []domain.InvestorPayout{
+			{InvestorEmail: "a@example.com", Principal: idr("75000"), Return: idr("1125")},
+			{InvestorEmail: "b@example.com", Principal: idr("25000"), Return: idr("375")},
+		},
+	}
+
+	e := RepaymentEntry(r)
+	require.NoError(t, e.Validate())
+	assert.Equal(t, "3", e.Reference)
+	assert.Len(t, e.Lines, 7)
+}
+
+func TestInvestmentAndDisbursementEntriesBalance(t *testing.T) {
+	inv := InvestmentEntry(domain.Investment{LoanID: 1, InvestorEmail: "a@example.com", Amount: idr("500")})
+	assert.NoError(t, inv.Validate())
+
+	dis := DisbursementEntry(domain.Loan{ID: 1, PrincipalAmount: idr("500")})
+	assert.NoError(t, dis.Validate())
+}
@@ -0,0 +1,57 @@
+package ledger
+
+import (
+	"strconv"
+
+	"github.com/martinusiron/loan-service/domain"
+)
+
+// TopUpEntry records money an investor paid into their wallet.
+func TopUpEntry(email string, amount domain.Money, reference string) *Entry {
+	return NewEntry(EntryTopUp, 0, reference).
+		Debit(Cash(), amount).
+		Credit(InvestorWallet(email), amount)
+}
+
+// WithdrawalEntry records money paid out of an investor's wallet.
+func WithdrawalEntry(email string, amount domain.Money, reference string) *Entry {
+	return NewEntry(EntryWithdrawal, 0, reference).
+		Debit(InvestorWallet(email), amount).
+		Credit(Cash(), amount)
+}
+
+// InvestmentEntry moves an investor's money from their wallet into the loan's
+// funding. It is posted when the wallet hold behind the investment is
+// captured at disbursement.
+func InvestmentEntry(inv domain.Investment) *Entry {
+	return NewEntry(EntryInvestment, inv.LoanID, inv.InvestorEmail).
+		Debit(InvestorWallet(inv.InvestorEmail), inv.Amount).
+		Credit(LoanFunding(inv.LoanID), inv.Amount)
+}
+
+// DisbursementEntry pays the principal out to the borrower, who now owes it
+// back.
+func DisbursementEntry(loan domain.Loan) *Entry {
+	return NewEntry(EntryDisbursement, loan.ID, "").
+		Debit(LoanReceivable(loan.ID), loan.PrincipalAmount).
+		Credit(Cash(), loan.PrincipalAmount)
+}
+
+// RepaymentEntry books a borrower payment that has already been allocated and
+// distributed. The cash received reduces the receivable by the principal
+// part, pays each investor their principal and return, credits the platform
+// margin to revenue and leaves any excess owed back to the borrower. The
+// repaid principal is taken out of the loan's funding as it goes back to the
+// investors.
+func RepaymentEntry(r domain.Repayment) *Entry {
+	e := NewEntry(EntryRepayment, r.LoanID, strconv.Itoa(r.ID)).
+		Debit(Cash(), r.Amount).
+		Debit(LoanFunding(r.LoanID), r.Principal).
+		Credit(LoanReceivable(r.LoanID), r.Principal).
+		Credit(PlatformRevenue(), r.PlatformMargin).
+		Credit(BorrowerPayable(r.LoanID), r.Excess)
+	for _, p := range r.Payouts {
+		e.Credit(InvestorWallet(p.InvestorEmail), p.Principal.Add(p.Return))
+	}
+	return e
+}
@@ -1,27 +1,294 @@
+CREATE SEQUENCE borrower_id_seq;
+
+CREATE TABLE borrowers (
+    id VARCHAR(100) PRIMARY KEY DEFAULT 'BR' || LPAD(nextval('borrower_id_seq')::text, 8, '0'),
+    identity_number VARCHAR(32) NOT NULL UNIQUE,
+    name VARCHAR(150) NOT NULL,
+    phone VARCHAR(20) NOT NULL,
+    email VARCHAR(100),
+    address TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_borrowers_status ON borrowers (status, id);
+
+CREATE TABLE investors (
+    email VARCHAR(100) PRIMARY KEY,
+    name VARCHAR(150) NOT NULL,
+    phone VARCHAR(20) NOT NULL,
+    identity_number VARCHAR(32) NOT NULL,
+    kyc_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    kyc_note TEXT,
+    kyc_reviewed_by VARCHAR(50),
+    kyc_reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_investors_kyc_status ON investors (kyc_status, email);
+
+CREATE TABLE employees (
+    id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(150) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('field_validator', 'field_officer', 'admin')),
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
 CREATE TABLE loans (
     id SERIAL PRIMARY KEY,
-    borrower_id VARCHAR(100) NOT NULL,
+    borrower_id VARCHAR(100) NOT NULL REFERENCES borrowers(id),
     principal_amount NUMERIC(12,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
     rate NUMERIC(5,2) NOT NULL,
     roi NUMERIC(5,2) NOT NULL,
+    tenor_months INT NOT NULL,
+    repayment_method VARCHAR(20) NOT NULL DEFAULT 'flat',
     status VARCHAR(20) NOT NULL DEFAULT 'proposed',
     agreement_letter_link TEXT,
+    funding_deadline TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE TABLE loan_approvals (
     id SERIAL PRIMARY KEY,
-    loan_id INT REFERENCES loans(id) ON DELETE CASCADE,
-    picture_proof TEXT NOT NULL,
-    employee_id VARCHAR(50) NOT NULL,
+    loan_id INT UNIQUE REFERENCES loans(id) ON DELETE CASCADE,
+    picture_proof_key TEXT NOT NULL,
+    picture_proof_sha256 CHAR(64) NOT NULL,
+    picture_proof_content_type VARCHAR(50) NOT NULL,
+    employee_id VARCHAR(50) NOT NULL REFERENCES employees(id),
     approved_at TIMESTAMP NOT NULL
 );
 
+CREATE TABLE loan_agreements (
+    id SERIAL PRIMARY KEY,
+    loan_id INT NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    party VARCHAR(20) NOT NULL CHECK (party IN ('borrower', 'investor')),
+    -- Empty for the borrower's letter.
+    investor_email VARCHAR(100) NOT NULL DEFAULT '',
+    format VARCHAR(10) NOT NULL CHECK (format IN ('html', 'pdf')),
+    blob_key TEXT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (loan_id, party, investor_email, format)
+);
+
 CREATE TABLE investments (
     id SERIAL PRIMARY KEY,
     loan_id INT REFERENCES loans(id) ON DELETE CASCADE,
-    investor_email VARCHAR(100) NOT NULL,
+    investor_email VARCHAR(100) NOT NULL REFERENCES investors(email),
     amount NUMERIC(12,2) NOT NULL,
-    invested_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-);
\ No newline at end of file
+    invested_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    refunded_at TIMESTAMP
+);
+CREATE TABLE loan_rejections (
+    id SERIAL PRIMARY KEY,
+    loan_id INT REFERENCES loans(id) ON DELETE CASCADE,
+    employee_id VARCHAR(50) NOT NULL REFERENCES employees(id),
+    reason_code VARCHAR(50) NOT NULL,
+    note TEXT,
+    rejected_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE loan_status_history (
+    id SERIAL PRIMARY KEY,
+    loan_id INT REFERENCES loans(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_loan_status_history_loan_id ON loan_status_history (loan_id);
+
+-- Keys are scoped to the caller that sent them.
+CREATE TABLE idempotency_keys (
+    caller TEXT NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_method VARCHAR(10) NOT NULL,
+    request_path TEXT NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status_code INT,
+    response_body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    PRIMARY KEY (caller, key)
+);
+
+CREATE INDEX idx_loans_created_at ON loans (created_at, id);
+CREATE INDEX idx_loans_status_created_at ON loans (status, created_at, id);
+CREATE INDEX idx_loans_borrower_id_created_at ON loans (borrower_id, created_at, id);
+CREATE INDEX idx_loans_principal_amount ON loans (principal_amount, id);
+CREATE INDEX idx_loans_rate ON loans (rate, id);
+CREATE INDEX idx_loans_status_funding_deadline ON loans (status, funding_deadline);
+
+CREATE INDEX idx_investments_loan_id ON investments (loan_id, id);
+CREATE INDEX idx_investments_investor_email ON investments (investor_email, id);
+
+CREATE TABLE repayment_schedules (
+    id SERIAL PRIMARY KEY,
+    loan_id INT NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    installment_number INT NOT NULL,
+    due_date DATE NOT NULL,
+    principal_due NUMERIC(12,2) NOT NULL,
+    interest_due NUMERIC(12,2) NOT NULL,
+    fee_due NUMERIC(12,2) NOT NULL DEFAULT 0,
+    principal_paid NUMERIC(12,2) NOT NULL DEFAULT 0,
+    interest_paid NUMERIC(12,2) NOT NULL DEFAULT 0,
+    fee_paid NUMERIC(12,2) NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    UNIQUE (loan_id, installment_number)
+);
+
+CREATE TABLE repayments (
+    id SERIAL PRIMARY KEY,
+    loan_id INT NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    amount NUMERIC(12,2) NOT NULL,
+    principal_amount NUMERIC(12,2) NOT NULL,
+    interest_amount NUMERIC(12,2) NOT NULL,
+    fee_amount NUMERIC(12,2) NOT NULL,
+    excess_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+    platform_margin NUMERIC(12,2) NOT NULL DEFAULT 0,
+    reference VARCHAR(100),
+    paid_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_repayments_loan_id ON repayments (loan_id);
+
+CREATE TABLE repayment_allocations (
+    id SERIAL PRIMARY KEY,
+    repayment_id INT NOT NULL REFERENCES repayments(id) ON DELETE CASCADE,
+    installment_id INT NOT NULL REFERENCES repayment_schedules(id) ON DELETE CASCADE,
+    component VARCHAR(20) NOT NULL,
+    amount NUMERIC(12,2) NOT NULL
+);
+
+CREATE TABLE repayment_payouts (
+    id SERIAL PRIMARY KEY,
+    repayment_id INT NOT NULL REFERENCES repayments(id) ON DELETE CASCADE,
+    investment_id INT NOT NULL REFERENCES investments(id) ON DELETE CASCADE,
+    loan_id INT NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    investor_email VARCHAR(100) NOT NULL,
+    principal_amount NUMERIC(12,2) NOT NULL,
+    return_amount NUMERIC(12,2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_repayment_payouts_investor ON repayment_payouts (investor_email, id);
+
+CREATE TABLE ledger_accounts (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(150) NOT NULL UNIQUE,
+    type VARCHAR(30) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE ledger_entries (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(30) NOT NULL,
+    loan_id INT REFERENCES loans(id),
+    reference VARCHAR(150),
+    posted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE ledger_lines (
+    id SERIAL PRIMARY KEY,
+    entry_id INT NOT NULL REFERENCES ledger_entries(id),
+    account_id INT NOT NULL REFERENCES ledger_accounts(id),
+    direction VARCHAR(6) NOT NULL CHECK (direction IN ('debit', 'credit')),
+    amount NUMERIC(12,2) NOT NULL CHECK (amount > 0)
+);
+
+CREATE INDEX idx_ledger_lines_account_id ON ledger_lines (account_id);
+CREATE INDEX idx_ledger_lines_entry_id ON ledger_lines (entry_id);
+
+-- Journal entries are immutable: corrections are posted as new entries.
+CREATE FUNCTION ledger_reject_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger is append-only: % on % is not allowed', TG_OP, TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_entries_immutable BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+
+CREATE TRIGGER ledger_lines_immutable BEFORE UPDATE OR DELETE ON ledger_lines
+    FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+
+CREATE TABLE investor_wallets (
+    investor_email VARCHAR(100) PRIMARY KEY,
+    balance NUMERIC(12,2) NOT NULL DEFAULT 0,
+    held NUMERIC(12,2) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (held >= 0 AND held <= balance)
+);
+
+CREATE TABLE wallet_holds (
+    id SERIAL PRIMARY KEY,
+    investor_email VARCHAR(100) NOT NULL REFERENCES investor_wallets(investor_email),
+    loan_id INT NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    amount NUMERIC(12,2) NOT NULL CHECK (amount > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_wallet_holds_loan_id ON wallet_holds (loan_id, status);
+
+-- Side effects recorded with the change causing them and carried out by the
+-- outbox dispatcher after commit.
+CREATE TABLE outbox_messages (
+    id BIGSERIAL PRIMARY KEY,
+    topic VARCHAR(50) NOT NULL,
+    key VARCHAR(100) NOT NULL DEFAULT '',
+    payload JSONB NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'done', 'dead')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_messages_due ON outbox_messages (next_attempt_at, id) WHERE status = 'pending';
+-- Finds earlier pending messages with the same key, which are delivered first.
+CREATE INDEX idx_outbox_messages_key ON outbox_messages (topic, key, id) WHERE status = 'pending';
+
+-- Partner endpoints subscribed to loan events.
+CREATE TABLE webhooks (
+    id SERIAL PRIMARY KEY,
+    url VARCHAR(500) NOT NULL,
+    event_types TEXT[] NOT NULL CHECK (cardinality(event_types) > 0),
+    secret VARCHAR(200) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'inactive')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per event sent to a webhook; the attempts themselves are driven
+-- by outbox messages on the "webhook" topic.
+CREATE TABLE webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id INT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_name VARCHAR(50) NOT NULL,
+    loan_id INT NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'retrying', 'succeeded', 'failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    response_status INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_attempt_at TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, id DESC);
@@ -0,0 +1,143 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AgreementRepository is an autogenerated mock type for the AgreementRepository type
+type AgreementRepository struct {
+	mock.Mock
+}
+
+type AgreementRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AgreementRepository) EXPECT() *AgreementRepository_Expecter {
+	return &AgreementRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateAgreement provides a mock function with given fields: ctx, a
+func (_m *AgreementRepository) CreateAgreement(ctx context.Context, a *domain.Agreement) error {
+	ret := _m.Called(ctx, a)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAgreement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Agreement) error); ok {
+		r0 = rf(ctx, a)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AgreementRepository_CreateAgreement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAgreement'
+type AgreementRepository_CreateAgreement_Call struct {
+	*mock.Call
+}
+
+// CreateAgreement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - a *domain.Agreement
+func (_e *AgreementRepository_Expecter) CreateAgreement(ctx interface{}, a interface{}) *AgreementRepository_CreateAgreement_Call {
+	return &AgreementRepository_CreateAgreement_Call{Call: _e.mock.On("CreateAgreement", ctx, a)}
+}
+
+func (_c *AgreementRepository_CreateAgreement_Call) Run(run func(ctx context.Context, a *domain.Agreement)) *AgreementRepository_CreateAgreement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Agreement))
+	})
+	return _c
+}
+
+func (_c *AgreementRepository_CreateAgreement_Call) Return(_a0 error) *AgreementRepository_CreateAgreement_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AgreementRepository_CreateAgreement_Call) RunAndReturn(run func(context.Context, *domain.Agreement) error) *AgreementRepository_CreateAgreement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAgreementsByLoan provides a mock function with given fields: ctx, loanID
+func (_m *AgreementRepository) ListAgreementsByLoan(ctx context.Context, loanID int) ([]domain.Agreement, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAgreementsByLoan")
+	}
+
+	var r0 []domain.Agreement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Agreement, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Agreement); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Agreement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AgreementRepository_ListAgreementsByLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAgreementsByLoan'
+type AgreementRepository_ListAgreementsByLoan_Call struct {
+	*mock.Call
+}
+
+// ListAgreementsByLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loanID int
+func (_e *AgreementRepository_Expecter) ListAgreementsByLoan(ctx interface{}, loanID interface{}) *AgreementRepository_ListAgreementsByLoan_Call {
+	return &AgreementRepository_ListAgreementsByLoan_Call{Call: _e.mock.On("ListAgreementsByLoan", ctx, loanID)}
+}
+
+func (_c *AgreementRepository_ListAgreementsByLoan_Call) Run(run func(ctx context.Context, loanID int)) *AgreementRepository_ListAgreementsByLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *AgreementRepository_ListAgreementsByLoan_Call) Return(_a0 []domain.Agreement, _a1 error) *AgreementRepository_ListAgreementsByLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AgreementRepository_ListAgreementsByLoan_Call) RunAndReturn(run func(context.Context, int) ([]domain.Agreement, error)) *AgreementRepository_ListAgreementsByLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAgreementRepository creates a new instance of AgreementRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAgreementRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AgreementRepository {
+	mock := &AgreementRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
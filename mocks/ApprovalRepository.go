@@ -69,6 +69,65 @@ func (_c *ApprovalRepository_CreateApproval_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// GetApprovalByLoanID provides a mock function with given fields: ctx, loanID
+func (_m *ApprovalRepository) GetApprovalByLoanID(ctx context.Context, loanID int) (*domain.LoanApproval, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApprovalByLoanID")
+	}
+
+	var r0 *domain.LoanApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.LoanApproval, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.LoanApproval); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LoanApproval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApprovalRepository_GetApprovalByLoanID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApprovalByLoanID'
+type ApprovalRepository_GetApprovalByLoanID_Call struct {
+	*mock.Call
+}
+
+// GetApprovalByLoanID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loanID int
+func (_e *ApprovalRepository_Expecter) GetApprovalByLoanID(ctx interface{}, loanID interface{}) *ApprovalRepository_GetApprovalByLoanID_Call {
+	return &ApprovalRepository_GetApprovalByLoanID_Call{Call: _e.mock.On("GetApprovalByLoanID", ctx, loanID)}
+}
+
+func (_c *ApprovalRepository_GetApprovalByLoanID_Call) Run(run func(ctx context.Context, loanID int)) *ApprovalRepository_GetApprovalByLoanID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ApprovalRepository_GetApprovalByLoanID_Call) Return(_a0 *domain.LoanApproval, _a1 error) *ApprovalRepository_GetApprovalByLoanID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ApprovalRepository_GetApprovalByLoanID_Call) RunAndReturn(run func(context.Context, int) (*domain.LoanApproval, error)) *ApprovalRepository_GetApprovalByLoanID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewApprovalRepository creates a new instance of ApprovalRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApprovalRepository(t interface {
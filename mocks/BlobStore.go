@@ -0,0 +1,203 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	domain "github.com/martinusiron/loan-service/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlobStore is an autogenerated mock type for the BlobStore type
+type BlobStore struct {
+	mock.Mock
+}
+
+type BlobStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BlobStore) EXPECT() *BlobStore_Expecter {
+	return &BlobStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, key
+func (_m *BlobStore) Delete(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlobStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type BlobStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *BlobStore_Expecter) Delete(ctx interface{}, key interface{}) *BlobStore_Delete_Call {
+	return &BlobStore_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
+}
+
+func (_c *BlobStore_Delete_Call) Run(run func(ctx context.Context, key string)) *BlobStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlobStore_Delete_Call) Return(_a0 error) *BlobStore_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlobStore_Delete_Call) RunAndReturn(run func(context.Context, string) error) *BlobStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, *domain.BlobInfo, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 io.ReadCloser
+	var r1 *domain.BlobInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, *domain.BlobInfo, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *domain.BlobInfo); ok {
+		r1 = rf(ctx, key)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.BlobInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// BlobStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type BlobStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *BlobStore_Expecter) Get(ctx interface{}, key interface{}) *BlobStore_Get_Call {
+	return &BlobStore_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *BlobStore_Get_Call) Run(run func(ctx context.Context, key string)) *BlobStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlobStore_Get_Call) Return(_a0 io.ReadCloser, _a1 *domain.BlobInfo, _a2 error) *BlobStore_Get_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *BlobStore_Get_Call) RunAndReturn(run func(context.Context, string) (io.ReadCloser, *domain.BlobInfo, error)) *BlobStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: ctx, key, data, contentType
+func (_m *BlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	ret := _m.Called(ctx, key, data, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, string) error); ok {
+		r0 = rf(ctx, key, data, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlobStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type BlobStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - data []byte
+//   - contentType string
+func (_e *BlobStore_Expecter) Put(ctx interface{}, key interface{}, data interface{}, contentType interface{}) *BlobStore_Put_Call {
+	return &BlobStore_Put_Call{Call: _e.mock.On("Put", ctx, key, data, contentType)}
+}
+
+func (_c *BlobStore_Put_Call) Run(run func(ctx context.Context, key string, data []byte, contentType string)) *BlobStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *BlobStore_Put_Call) Return(_a0 error) *BlobStore_Put_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlobStore_Put_Call) RunAndReturn(run func(context.Context, string, []byte, string) error) *BlobStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBlobStore creates a new instance of BlobStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBlobStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BlobStore {
+	mock := &BlobStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,249 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BorrowerRepository is an autogenerated mock type for the BorrowerRepository type
+type BorrowerRepository struct {
+	mock.Mock
+}
+
+type BorrowerRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BorrowerRepository) EXPECT() *BorrowerRepository_Expecter {
+	return &BorrowerRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateBorrower provides a mock function with given fields: ctx, b
+func (_m *BorrowerRepository) CreateBorrower(ctx context.Context, b *domain.Borrower) error {
+	ret := _m.Called(ctx, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBorrower")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Borrower) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BorrowerRepository_CreateBorrower_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBorrower'
+type BorrowerRepository_CreateBorrower_Call struct {
+	*mock.Call
+}
+
+// CreateBorrower is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Borrower
+func (_e *BorrowerRepository_Expecter) CreateBorrower(ctx interface{}, b interface{}) *BorrowerRepository_CreateBorrower_Call {
+	return &BorrowerRepository_CreateBorrower_Call{Call: _e.mock.On("CreateBorrower", ctx, b)}
+}
+
+func (_c *BorrowerRepository_CreateBorrower_Call) Run(run func(ctx context.Context, b *domain.Borrower)) *BorrowerRepository_CreateBorrower_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Borrower))
+	})
+	return _c
+}
+
+func (_c *BorrowerRepository_CreateBorrower_Call) Return(_a0 error) *BorrowerRepository_CreateBorrower_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BorrowerRepository_CreateBorrower_Call) RunAndReturn(run func(context.Context, *domain.Borrower) error) *BorrowerRepository_CreateBorrower_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBorrowerByID provides a mock function with given fields: ctx, id
+func (_m *BorrowerRepository) GetBorrowerByID(ctx context.Context, id string) (*domain.Borrower, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBorrowerByID")
+	}
+
+	var r0 *domain.Borrower
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Borrower, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Borrower); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Borrower)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BorrowerRepository_GetBorrowerByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBorrowerByID'
+type BorrowerRepository_GetBorrowerByID_Call struct {
+	*mock.Call
+}
+
+// GetBorrowerByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *BorrowerRepository_Expecter) GetBorrowerByID(ctx interface{}, id interface{}) *BorrowerRepository_GetBorrowerByID_Call {
+	return &BorrowerRepository_GetBorrowerByID_Call{Call: _e.mock.On("GetBorrowerByID", ctx, id)}
+}
+
+func (_c *BorrowerRepository_GetBorrowerByID_Call) Run(run func(ctx context.Context, id string)) *BorrowerRepository_GetBorrowerByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BorrowerRepository_GetBorrowerByID_Call) Return(_a0 *domain.Borrower, _a1 error) *BorrowerRepository_GetBorrowerByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BorrowerRepository_GetBorrowerByID_Call) RunAndReturn(run func(context.Context, string) (*domain.Borrower, error)) *BorrowerRepository_GetBorrowerByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBorrowers provides a mock function with given fields: ctx, filter
+func (_m *BorrowerRepository) ListBorrowers(ctx context.Context, filter domain.BorrowerFilter) ([]domain.Borrower, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBorrowers")
+	}
+
+	var r0 []domain.Borrower
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BorrowerFilter) ([]domain.Borrower, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BorrowerFilter) []domain.Borrower); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Borrower)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BorrowerFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BorrowerRepository_ListBorrowers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBorrowers'
+type BorrowerRepository_ListBorrowers_Call struct {
+	*mock.Call
+}
+
+// ListBorrowers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.BorrowerFilter
+func (_e *BorrowerRepository_Expecter) ListBorrowers(ctx interface{}, filter interface{}) *BorrowerRepository_ListBorrowers_Call {
+	return &BorrowerRepository_ListBorrowers_Call{Call: _e.mock.On("ListBorrowers", ctx, filter)}
+}
+
+func (_c *BorrowerRepository_ListBorrowers_Call) Run(run func(ctx context.Context, filter domain.BorrowerFilter)) *BorrowerRepository_ListBorrowers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BorrowerFilter))
+	})
+	return _c
+}
+
+func (_c *BorrowerRepository_ListBorrowers_Call) Return(_a0 []domain.Borrower, _a1 error) *BorrowerRepository_ListBorrowers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BorrowerRepository_ListBorrowers_Call) RunAndReturn(run func(context.Context, domain.BorrowerFilter) ([]domain.Borrower, error)) *BorrowerRepository_ListBorrowers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBorrower provides a mock function with given fields: ctx, b
+func (_m *BorrowerRepository) UpdateBorrower(ctx context.Context, b *domain.Borrower) error {
+	ret := _m.Called(ctx, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBorrower")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Borrower) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BorrowerRepository_UpdateBorrower_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBorrower'
+type BorrowerRepository_UpdateBorrower_Call struct {
+	*mock.Call
+}
+
+// UpdateBorrower is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Borrower
+func (_e *BorrowerRepository_Expecter) UpdateBorrower(ctx interface{}, b interface{}) *BorrowerRepository_UpdateBorrower_Call {
+	return &BorrowerRepository_UpdateBorrower_Call{Call: _e.mock.On("UpdateBorrower", ctx, b)}
+}
+
+func (_c *BorrowerRepository_UpdateBorrower_Call) Run(run func(ctx context.Context, b *domain.Borrower)) *BorrowerRepository_UpdateBorrower_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Borrower))
+	})
+	return _c
+}
+
+func (_c *BorrowerRepository_UpdateBorrower_Call) Return(_a0 error) *BorrowerRepository_UpdateBorrower_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BorrowerRepository_UpdateBorrower_Call) RunAndReturn(run func(context.Context, *domain.Borrower) error) *BorrowerRepository_UpdateBorrower_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBorrowerRepository creates a new instance of BorrowerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBorrowerRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BorrowerRepository {
+	mock := &BorrowerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,190 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EmployeeRepository is an autogenerated mock type for the EmployeeRepository type
+type EmployeeRepository struct {
+	mock.Mock
+}
+
+type EmployeeRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EmployeeRepository) EXPECT() *EmployeeRepository_Expecter {
+	return &EmployeeRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateEmployee provides a mock function with given fields: ctx, e
+func (_m *EmployeeRepository) CreateEmployee(ctx context.Context, e *domain.Employee) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmployee")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Employee) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EmployeeRepository_CreateEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEmployee'
+type EmployeeRepository_CreateEmployee_Call struct {
+	*mock.Call
+}
+
+// CreateEmployee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e *domain.Employee
+func (_e *EmployeeRepository_Expecter) CreateEmployee(ctx interface{}, e interface{}) *EmployeeRepository_CreateEmployee_Call {
+	return &EmployeeRepository_CreateEmployee_Call{Call: _e.mock.On("CreateEmployee", ctx, e)}
+}
+
+func (_c *EmployeeRepository_CreateEmployee_Call) Run(run func(ctx context.Context, e *domain.Employee)) *EmployeeRepository_CreateEmployee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Employee))
+	})
+	return _c
+}
+
+func (_c *EmployeeRepository_CreateEmployee_Call) Return(_a0 error) *EmployeeRepository_CreateEmployee_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EmployeeRepository_CreateEmployee_Call) RunAndReturn(run func(context.Context, *domain.Employee) error) *EmployeeRepository_CreateEmployee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEmployeeByID provides a mock function with given fields: ctx, id
+func (_m *EmployeeRepository) GetEmployeeByID(ctx context.Context, id string) (*domain.Employee, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmployeeByID")
+	}
+
+	var r0 *domain.Employee
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Employee, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Employee); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Employee)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EmployeeRepository_GetEmployeeByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEmployeeByID'
+type EmployeeRepository_GetEmployeeByID_Call struct {
+	*mock.Call
+}
+
+// GetEmployeeByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *EmployeeRepository_Expecter) GetEmployeeByID(ctx interface{}, id interface{}) *EmployeeRepository_GetEmployeeByID_Call {
+	return &EmployeeRepository_GetEmployeeByID_Call{Call: _e.mock.On("GetEmployeeByID", ctx, id)}
+}
+
+func (_c *EmployeeRepository_GetEmployeeByID_Call) Run(run func(ctx context.Context, id string)) *EmployeeRepository_GetEmployeeByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *EmployeeRepository_GetEmployeeByID_Call) Return(_a0 *domain.Employee, _a1 error) *EmployeeRepository_GetEmployeeByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EmployeeRepository_GetEmployeeByID_Call) RunAndReturn(run func(context.Context, string) (*domain.Employee, error)) *EmployeeRepository_GetEmployeeByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEmployee provides a mock function with given fields: ctx, e
+func (_m *EmployeeRepository) UpdateEmployee(ctx context.Context, e *domain.Employee) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmployee")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Employee) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EmployeeRepository_UpdateEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEmployee'
+type EmployeeRepository_UpdateEmployee_Call struct {
+	*mock.Call
+}
+
+// UpdateEmployee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e *domain.Employee
+func (_e *EmployeeRepository_Expecter) UpdateEmployee(ctx interface{}, e interface{}) *EmployeeRepository_UpdateEmployee_Call {
+	return &EmployeeRepository_UpdateEmployee_Call{Call: _e.mock.On("UpdateEmployee", ctx, e)}
+}
+
+func (_c *EmployeeRepository_UpdateEmployee_Call) Run(run func(ctx context.Context, e *domain.Employee)) *EmployeeRepository_UpdateEmployee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Employee))
+	})
+	return _c
+}
+
+func (_c *EmployeeRepository_UpdateEmployee_Call) Return(_a0 error) *EmployeeRepository_UpdateEmployee_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EmployeeRepository_UpdateEmployee_Call) RunAndReturn(run func(context.Context, *domain.Employee) error) *EmployeeRepository_UpdateEmployee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEmployeeRepository creates a new instance of EmployeeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEmployeeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EmployeeRepository {
+	mock := &EmployeeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventStream is an autogenerated mock type for the EventStream type
+type EventStream struct {
+	mock.Mock
+}
+
+type EventStream_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EventStream) EXPECT() *EventStream_Expecter {
+	return &EventStream_Expecter{mock: &_m.Mock}
+}
+
+// Stream provides a mock function with given fields: ctx, rec
+func (_m *EventStream) Stream(ctx context.Context, rec domain.EventRecord) error {
+	ret := _m.Called(ctx, rec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.EventRecord) error); ok {
+		r0 = rf(ctx, rec)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EventStream_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type EventStream_Stream_Call struct {
+	*mock.Call
+}
+
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rec domain.EventRecord
+func (_e *EventStream_Expecter) Stream(ctx interface{}, rec interface{}) *EventStream_Stream_Call {
+	return &EventStream_Stream_Call{Call: _e.mock.On("Stream", ctx, rec)}
+}
+
+func (_c *EventStream_Stream_Call) Run(run func(ctx context.Context, rec domain.EventRecord)) *EventStream_Stream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.EventRecord))
+	})
+	return _c
+}
+
+func (_c *EventStream_Stream_Call) Return(_a0 error) *EventStream_Stream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EventStream_Stream_Call) RunAndReturn(run func(context.Context, domain.EventRecord) error) *EventStream_Stream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEventStream creates a new instance of EventStream. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventStream(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventStream {
+	mock := &EventStream{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,252 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
+type IdempotencyRepository struct {
+	mock.Mock
+}
+
+type IdempotencyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IdempotencyRepository) EXPECT() *IdempotencyRepository_Expecter {
+	return &IdempotencyRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetByKey provides a mock function with given fields: ctx, caller, key
+func (_m *IdempotencyRepository) GetByKey(ctx context.Context, caller string, key string) (*domain.IdempotencyRecord, error) {
+	ret := _m.Called(ctx, caller, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByKey")
+	}
+
+	var r0 *domain.IdempotencyRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.IdempotencyRecord, error)); ok {
+		return rf(ctx, caller, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.IdempotencyRecord); ok {
+		r0 = rf(ctx, caller, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotencyRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, caller, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyRepository_GetByKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByKey'
+type IdempotencyRepository_GetByKey_Call struct {
+	*mock.Call
+}
+
+// GetByKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - caller string
+//   - key string
+func (_e *IdempotencyRepository_Expecter) GetByKey(ctx interface{}, caller interface{}, key interface{}) *IdempotencyRepository_GetByKey_Call {
+	return &IdempotencyRepository_GetByKey_Call{Call: _e.mock.On("GetByKey", ctx, caller, key)}
+}
+
+func (_c *IdempotencyRepository_GetByKey_Call) Run(run func(ctx context.Context, caller string, key string)) *IdempotencyRepository_GetByKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_GetByKey_Call) Return(_a0 *domain.IdempotencyRecord, _a1 error) *IdempotencyRepository_GetByKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyRepository_GetByKey_Call) RunAndReturn(run func(context.Context, string, string) (*domain.IdempotencyRecord, error)) *IdempotencyRepository_GetByKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, caller, key
+func (_m *IdempotencyRepository) Release(ctx context.Context, caller string, key string) error {
+	ret := _m.Called(ctx, caller, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, caller, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepository_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type IdempotencyRepository_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - caller string
+//   - key string
+func (_e *IdempotencyRepository_Expecter) Release(ctx interface{}, caller interface{}, key interface{}) *IdempotencyRepository_Release_Call {
+	return &IdempotencyRepository_Release_Call{Call: _e.mock.On("Release", ctx, caller, key)}
+}
+
+func (_c *IdempotencyRepository_Release_Call) Run(run func(ctx context.Context, caller string, key string)) *IdempotencyRepository_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Release_Call) Return(_a0 error) *IdempotencyRepository_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Release_Call) RunAndReturn(run func(context.Context, string, string) error) *IdempotencyRepository_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, rec
+func (_m *IdempotencyRepository) Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (bool, error) {
+	ret := _m.Called(ctx, rec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotencyRecord) (bool, error)); ok {
+		return rf(ctx, rec)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotencyRecord) bool); ok {
+		r0 = rf(ctx, rec)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.IdempotencyRecord) error); ok {
+		r1 = rf(ctx, rec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyRepository_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type IdempotencyRepository_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rec *domain.IdempotencyRecord
+func (_e *IdempotencyRepository_Expecter) Reserve(ctx interface{}, rec interface{}) *IdempotencyRepository_Reserve_Call {
+	return &IdempotencyRepository_Reserve_Call{Call: _e.mock.On("Reserve", ctx, rec)}
+}
+
+func (_c *IdempotencyRepository_Reserve_Call) Run(run func(ctx context.Context, rec *domain.IdempotencyRecord)) *IdempotencyRepository_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.IdempotencyRecord))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Reserve_Call) Return(_a0 bool, _a1 error) *IdempotencyRepository_Reserve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Reserve_Call) RunAndReturn(run func(context.Context, *domain.IdempotencyRecord) (bool, error)) *IdempotencyRepository_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveResponse provides a mock function with given fields: ctx, caller, key, statusCode, body
+func (_m *IdempotencyRepository) SaveResponse(ctx context.Context, caller string, key string, statusCode int, body []byte) error {
+	ret := _m.Called(ctx, caller, key, statusCode, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveResponse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, []byte) error); ok {
+		r0 = rf(ctx, caller, key, statusCode, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepository_SaveResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveResponse'
+type IdempotencyRepository_SaveResponse_Call struct {
+	*mock.Call
+}
+
+// SaveResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - caller string
+//   - key string
+//   - statusCode int
+//   - body []byte
+func (_e *IdempotencyRepository_Expecter) SaveResponse(ctx interface{}, caller interface{}, key interface{}, statusCode interface{}, body interface{}) *IdempotencyRepository_SaveResponse_Call {
+	return &IdempotencyRepository_SaveResponse_Call{Call: _e.mock.On("SaveResponse", ctx, caller, key, statusCode, body)}
+}
+
+func (_c *IdempotencyRepository_SaveResponse_Call) Run(run func(ctx context.Context, caller string, key string, statusCode int, body []byte)) *IdempotencyRepository_SaveResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int), args[4].([]byte))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_SaveResponse_Call) Return(_a0 error) *IdempotencyRepository_SaveResponse_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepository_SaveResponse_Call) RunAndReturn(run func(context.Context, string, string, int, []byte) error) *IdempotencyRepository_SaveResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyRepository {
+	mock := &IdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
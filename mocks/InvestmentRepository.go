@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/martinusiron/loan-service/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // InvestmentRepository is an autogenerated mock type for the InvestmentRepository type
@@ -129,22 +131,22 @@ func (_c *InvestmentRepository_GetInvestorsByLoan_Call) RunAndReturn(run func(co
 }
 
 // GetTotalInvested provides a mock function with given fields: ctx, loanID
-func (_m *InvestmentRepository) GetTotalInvested(ctx context.Context, loanID int) (float64, error) {
+func (_m *InvestmentRepository) GetTotalInvested(ctx context.Context, loanID int) (domain.Money, error) {
 	ret := _m.Called(ctx, loanID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTotalInvested")
 	}
 
-	var r0 float64
+	var r0 domain.Money
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (float64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Money, error)); ok {
 		return rf(ctx, loanID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) float64); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Money); ok {
 		r0 = rf(ctx, loanID)
 	} else {
-		r0 = ret.Get(0).(float64)
+		r0 = ret.Get(0).(domain.Money)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
@@ -175,12 +177,119 @@ func (_c *InvestmentRepository_GetTotalInvested_Call) Run(run func(ctx context.C
 	return _c
 }
 
-func (_c *InvestmentRepository_GetTotalInvested_Call) Return(_a0 float64, _a1 error) *InvestmentRepository_GetTotalInvested_Call {
+func (_c *InvestmentRepository_GetTotalInvested_Call) Return(_a0 domain.Money, _a1 error) *InvestmentRepository_GetTotalInvested_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvestmentRepository_GetTotalInvested_Call) RunAndReturn(run func(context.Context, int) (domain.Money, error)) *InvestmentRepository_GetTotalInvested_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListInvestments provides a mock function with given fields: ctx, filter
+func (_m *InvestmentRepository) ListInvestments(ctx context.Context, filter domain.InvestmentFilter) ([]domain.InvestmentDetail, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvestments")
+	}
+
+	var r0 []domain.InvestmentDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvestmentFilter) ([]domain.InvestmentDetail, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvestmentFilter) []domain.InvestmentDetail); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.InvestmentDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.InvestmentFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvestmentRepository_ListInvestments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInvestments'
+type InvestmentRepository_ListInvestments_Call struct {
+	*mock.Call
+}
+
+// ListInvestments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.InvestmentFilter
+func (_e *InvestmentRepository_Expecter) ListInvestments(ctx interface{}, filter interface{}) *InvestmentRepository_ListInvestments_Call {
+	return &InvestmentRepository_ListInvestments_Call{Call: _e.mock.On("ListInvestments", ctx, filter)}
+}
+
+func (_c *InvestmentRepository_ListInvestments_Call) Run(run func(ctx context.Context, filter domain.InvestmentFilter)) *InvestmentRepository_ListInvestments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.InvestmentFilter))
+	})
+	return _c
+}
+
+func (_c *InvestmentRepository_ListInvestments_Call) Return(_a0 []domain.InvestmentDetail, _a1 error) *InvestmentRepository_ListInvestments_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *InvestmentRepository_GetTotalInvested_Call) RunAndReturn(run func(context.Context, int) (float64, error)) *InvestmentRepository_GetTotalInvested_Call {
+func (_c *InvestmentRepository_ListInvestments_Call) RunAndReturn(run func(context.Context, domain.InvestmentFilter) ([]domain.InvestmentDetail, error)) *InvestmentRepository_ListInvestments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRefunded provides a mock function with given fields: ctx, loanID, at
+func (_m *InvestmentRepository) MarkRefunded(ctx context.Context, loanID int, at time.Time) error {
+	ret := _m.Called(ctx, loanID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRefunded")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, loanID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvestmentRepository_MarkRefunded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRefunded'
+type InvestmentRepository_MarkRefunded_Call struct {
+	*mock.Call
+}
+
+// MarkRefunded is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loanID int
+//   - at time.Time
+func (_e *InvestmentRepository_Expecter) MarkRefunded(ctx interface{}, loanID interface{}, at interface{}) *InvestmentRepository_MarkRefunded_Call {
+	return &InvestmentRepository_MarkRefunded_Call{Call: _e.mock.On("MarkRefunded", ctx, loanID, at)}
+}
+
+func (_c *InvestmentRepository_MarkRefunded_Call) Run(run func(ctx context.Context, loanID int, at time.Time)) *InvestmentRepository_MarkRefunded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *InvestmentRepository_MarkRefunded_Call) Return(_a0 error) *InvestmentRepository_MarkRefunded_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvestmentRepository_MarkRefunded_Call) RunAndReturn(run func(context.Context, int, time.Time) error) *InvestmentRepository_MarkRefunded_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,308 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// InvestorRepository is an autogenerated mock type for the InvestorRepository type
+type InvestorRepository struct {
+	mock.Mock
+}
+
+type InvestorRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InvestorRepository) EXPECT() *InvestorRepository_Expecter {
+	return &InvestorRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateInvestor provides a mock function with given fields: ctx, i
+func (_m *InvestorRepository) CreateInvestor(ctx context.Context, i *domain.Investor) error {
+	ret := _m.Called(ctx, i)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvestor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Investor) error); ok {
+		r0 = rf(ctx, i)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvestorRepository_CreateInvestor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvestor'
+type InvestorRepository_CreateInvestor_Call struct {
+	*mock.Call
+}
+
+// CreateInvestor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - i *domain.Investor
+func (_e *InvestorRepository_Expecter) CreateInvestor(ctx interface{}, i interface{}) *InvestorRepository_CreateInvestor_Call {
+	return &InvestorRepository_CreateInvestor_Call{Call: _e.mock.On("CreateInvestor", ctx, i)}
+}
+
+func (_c *InvestorRepository_CreateInvestor_Call) Run(run func(ctx context.Context, i *domain.Investor)) *InvestorRepository_CreateInvestor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Investor))
+	})
+	return _c
+}
+
+func (_c *InvestorRepository_CreateInvestor_Call) Return(_a0 error) *InvestorRepository_CreateInvestor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvestorRepository_CreateInvestor_Call) RunAndReturn(run func(context.Context, *domain.Investor) error) *InvestorRepository_CreateInvestor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvestorByEmail provides a mock function with given fields: ctx, email
+func (_m *InvestorRepository) GetInvestorByEmail(ctx context.Context, email string) (*domain.Investor, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvestorByEmail")
+	}
+
+	var r0 *domain.Investor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Investor, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Investor); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Investor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvestorRepository_GetInvestorByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvestorByEmail'
+type InvestorRepository_GetInvestorByEmail_Call struct {
+	*mock.Call
+}
+
+// GetInvestorByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *InvestorRepository_Expecter) GetInvestorByEmail(ctx interface{}, email interface{}) *InvestorRepository_GetInvestorByEmail_Call {
+	return &InvestorRepository_GetInvestorByEmail_Call{Call: _e.mock.On("GetInvestorByEmail", ctx, email)}
+}
+
+func (_c *InvestorRepository_GetInvestorByEmail_Call) Run(run func(ctx context.Context, email string)) *InvestorRepository_GetInvestorByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InvestorRepository_GetInvestorByEmail_Call) Return(_a0 *domain.Investor, _a1 error) *InvestorRepository_GetInvestorByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvestorRepository_GetInvestorByEmail_Call) RunAndReturn(run func(context.Context, string) (*domain.Investor, error)) *InvestorRepository_GetInvestorByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvestorByEmailForUpdate provides a mock function with given fields: ctx, email
+func (_m *InvestorRepository) GetInvestorByEmailForUpdate(ctx context.Context, email string) (*domain.Investor, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvestorByEmailForUpdate")
+	}
+
+	var r0 *domain.Investor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Investor, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Investor); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Investor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvestorRepository_GetInvestorByEmailForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvestorByEmailForUpdate'
+type InvestorRepository_GetInvestorByEmailForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetInvestorByEmailForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *InvestorRepository_Expecter) GetInvestorByEmailForUpdate(ctx interface{}, email interface{}) *InvestorRepository_GetInvestorByEmailForUpdate_Call {
+	return &InvestorRepository_GetInvestorByEmailForUpdate_Call{Call: _e.mock.On("GetInvestorByEmailForUpdate", ctx, email)}
+}
+
+func (_c *InvestorRepository_GetInvestorByEmailForUpdate_Call) Run(run func(ctx context.Context, email string)) *InvestorRepository_GetInvestorByEmailForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InvestorRepository_GetInvestorByEmailForUpdate_Call) Return(_a0 *domain.Investor, _a1 error) *InvestorRepository_GetInvestorByEmailForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvestorRepository_GetInvestorByEmailForUpdate_Call) RunAndReturn(run func(context.Context, string) (*domain.Investor, error)) *InvestorRepository_GetInvestorByEmailForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListInvestors provides a mock function with given fields: ctx, filter
+func (_m *InvestorRepository) ListInvestors(ctx context.Context, filter domain.InvestorFilter) ([]domain.Investor, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvestors")
+	}
+
+	var r0 []domain.Investor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvestorFilter) ([]domain.Investor, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvestorFilter) []domain.Investor); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Investor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.InvestorFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvestorRepository_ListInvestors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInvestors'
+type InvestorRepository_ListInvestors_Call struct {
+	*mock.Call
+}
+
+// ListInvestors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.InvestorFilter
+func (_e *InvestorRepository_Expecter) ListInvestors(ctx interface{}, filter interface{}) *InvestorRepository_ListInvestors_Call {
+	return &InvestorRepository_ListInvestors_Call{Call: _e.mock.On("ListInvestors", ctx, filter)}
+}
+
+func (_c *InvestorRepository_ListInvestors_Call) Run(run func(ctx context.Context, filter domain.InvestorFilter)) *InvestorRepository_ListInvestors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.InvestorFilter))
+	})
+	return _c
+}
+
+func (_c *InvestorRepository_ListInvestors_Call) Return(_a0 []domain.Investor, _a1 error) *InvestorRepository_ListInvestors_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvestorRepository_ListInvestors_Call) RunAndReturn(run func(context.Context, domain.InvestorFilter) ([]domain.Investor, error)) *InvestorRepository_ListInvestors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateKYC provides a mock function with given fields: ctx, i
+func (_m *InvestorRepository) UpdateKYC(ctx context.Context, i *domain.Investor) error {
+	ret := _m.Called(ctx, i)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateKYC")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Investor) error); ok {
+		r0 = rf(ctx, i)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvestorRepository_UpdateKYC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateKYC'
+type InvestorRepository_UpdateKYC_Call struct {
+	*mock.Call
+}
+
+// UpdateKYC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - i *domain.Investor
+func (_e *InvestorRepository_Expecter) UpdateKYC(ctx interface{}, i interface{}) *InvestorRepository_UpdateKYC_Call {
+	return &InvestorRepository_UpdateKYC_Call{Call: _e.mock.On("UpdateKYC", ctx, i)}
+}
+
+func (_c *InvestorRepository_UpdateKYC_Call) Run(run func(ctx context.Context, i *domain.Investor)) *InvestorRepository_UpdateKYC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Investor))
+	})
+	return _c
+}
+
+func (_c *InvestorRepository_UpdateKYC_Call) Return(_a0 error) *InvestorRepository_UpdateKYC_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvestorRepository_UpdateKYC_Call) RunAndReturn(run func(context.Context, *domain.Investor) error) *InvestorRepository_UpdateKYC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewInvestorRepository creates a new instance of InvestorRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvestorRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvestorRepository {
+	mock := &InvestorRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
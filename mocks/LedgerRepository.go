@@ -0,0 +1,143 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	ledger "github.com/martinusiron/loan-service/ledger"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LedgerRepository is an autogenerated mock type for the LedgerRepository type
+type LedgerRepository struct {
+	mock.Mock
+}
+
+type LedgerRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LedgerRepository) EXPECT() *LedgerRepository_Expecter {
+	return &LedgerRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetBalance provides a mock function with given fields: ctx, account
+func (_m *LedgerRepository) GetBalance(ctx context.Context, account ledger.Account) (domain.Money, error) {
+	ret := _m.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
+	var r0 domain.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ledger.Account) (domain.Money, error)); ok {
+		return rf(ctx, account)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ledger.Account) domain.Money); ok {
+		r0 = rf(ctx, account)
+	} else {
+		r0 = ret.Get(0).(domain.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ledger.Account) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LedgerRepository_GetBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalance'
+type LedgerRepository_GetBalance_Call struct {
+	*mock.Call
+}
+
+// GetBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account ledger.Account
+func (_e *LedgerRepository_Expecter) GetBalance(ctx interface{}, account interface{}) *LedgerRepository_GetBalance_Call {
+	return &LedgerRepository_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, account)}
+}
+
+func (_c *LedgerRepository_GetBalance_Call) Run(run func(ctx context.Context, account ledger.Account)) *LedgerRepository_GetBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ledger.Account))
+	})
+	return _c
+}
+
+func (_c *LedgerRepository_GetBalance_Call) Return(_a0 domain.Money, _a1 error) *LedgerRepository_GetBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LedgerRepository_GetBalance_Call) RunAndReturn(run func(context.Context, ledger.Account) (domain.Money, error)) *LedgerRepository_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostEntry provides a mock function with given fields: ctx, e
+func (_m *LedgerRepository) PostEntry(ctx context.Context, e *ledger.Entry) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ledger.Entry) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LedgerRepository_PostEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostEntry'
+type LedgerRepository_PostEntry_Call struct {
+	*mock.Call
+}
+
+// PostEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e *ledger.Entry
+func (_e *LedgerRepository_Expecter) PostEntry(ctx interface{}, e interface{}) *LedgerRepository_PostEntry_Call {
+	return &LedgerRepository_PostEntry_Call{Call: _e.mock.On("PostEntry", ctx, e)}
+}
+
+func (_c *LedgerRepository_PostEntry_Call) Run(run func(ctx context.Context, e *ledger.Entry)) *LedgerRepository_PostEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*ledger.Entry))
+	})
+	return _c
+}
+
+func (_c *LedgerRepository_PostEntry_Call) Return(_a0 error) *LedgerRepository_PostEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LedgerRepository_PostEntry_Call) RunAndReturn(run func(context.Context, *ledger.Entry) error) *LedgerRepository_PostEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLedgerRepository creates a new instance of LedgerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLedgerRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LedgerRepository {
+	mock := &LedgerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoanHistoryRepository is an autogenerated mock type for the LoanHistoryRepository type
+type LoanHistoryRepository struct {
+	mock.Mock
+}
+
+type LoanHistoryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LoanHistoryRepository) EXPECT() *LoanHistoryRepository_Expecter {
+	return &LoanHistoryRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetHistoryByLoan provides a mock function with given fields: ctx, loanID
+func (_m *LoanHistoryRepository) GetHistoryByLoan(ctx context.Context, loanID int) ([]domain.LoanStatusHistory, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistoryByLoan")
+	}
+
+	var r0 []domain.LoanStatusHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.LoanStatusHistory, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.LoanStatusHistory); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LoanStatusHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoanHistoryRepository_GetHistoryByLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHistoryByLoan'
+type LoanHistoryRepository_GetHistoryByLoan_Call struct {
+	*mock.Call
+}
+
+// GetHistoryByLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loanID int
+func (_e *LoanHistoryRepository_Expecter) GetHistoryByLoan(ctx interface{}, loanID interface{}) *LoanHistoryRepository_GetHistoryByLoan_Call {
+	return &LoanHistoryRepository_GetHistoryByLoan_Call{Call: _e.mock.On("GetHistoryByLoan", ctx, loanID)}
+}
+
+func (_c *LoanHistoryRepository_GetHistoryByLoan_Call) Run(run func(ctx context.Context, loanID int)) *LoanHistoryRepository_GetHistoryByLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *LoanHistoryRepository_GetHistoryByLoan_Call) Return(_a0 []domain.LoanStatusHistory, _a1 error) *LoanHistoryRepository_GetHistoryByLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoanHistoryRepository_GetHistoryByLoan_Call) RunAndReturn(run func(context.Context, int) ([]domain.LoanStatusHistory, error)) *LoanHistoryRepository_GetHistoryByLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordStatusChange provides a mock function with given fields: ctx, h
+func (_m *LoanHistoryRepository) RecordStatusChange(ctx context.Context, h *domain.LoanStatusHistory) error {
+	ret := _m.Called(ctx, h)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordStatusChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoanStatusHistory) error); ok {
+		r0 = rf(ctx, h)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoanHistoryRepository_RecordStatusChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordStatusChange'
+type LoanHistoryRepository_RecordStatusChange_Call struct {
+	*mock.Call
+}
+
+// RecordStatusChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - h *domain.LoanStatusHistory
+func (_e *LoanHistoryRepository_Expecter) RecordStatusChange(ctx interface{}, h interface{}) *LoanHistoryRepository_RecordStatusChange_Call {
+	return &LoanHistoryRepository_RecordStatusChange_Call{Call: _e.mock.On("RecordStatusChange", ctx, h)}
+}
+
+func (_c *LoanHistoryRepository_RecordStatusChange_Call) Run(run func(ctx context.Context, h *domain.LoanStatusHistory)) *LoanHistoryRepository_RecordStatusChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.LoanStatusHistory))
+	})
+	return _c
+}
+
+func (_c *LoanHistoryRepository_RecordStatusChange_Call) Return(_a0 error) *LoanHistoryRepository_RecordStatusChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoanHistoryRepository_RecordStatusChange_Call) RunAndReturn(run func(context.Context, *domain.LoanStatusHistory) error) *LoanHistoryRepository_RecordStatusChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLoanHistoryRepository creates a new instance of LoanHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoanHistoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoanHistoryRepository {
+	mock := &LoanHistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
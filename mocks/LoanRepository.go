@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/martinusiron/loan-service/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // LoanRepository is an autogenerated mock type for the LoanRepository type
@@ -128,6 +130,184 @@ func (_c *LoanRepository_GetLoanByID_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// GetLoanByIDForUpdate provides a mock function with given fields: ctx, id
+func (_m *LoanRepository) GetLoanByIDForUpdate(ctx context.Context, id int) (*domain.Loan, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanByIDForUpdate")
+	}
+
+	var r0 *domain.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Loan, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Loan); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoanRepository_GetLoanByIDForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanByIDForUpdate'
+type LoanRepository_GetLoanByIDForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetLoanByIDForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *LoanRepository_Expecter) GetLoanByIDForUpdate(ctx interface{}, id interface{}) *LoanRepository_GetLoanByIDForUpdate_Call {
+	return &LoanRepository_GetLoanByIDForUpdate_Call{Call: _e.mock.On("GetLoanByIDForUpdate", ctx, id)}
+}
+
+func (_c *LoanRepository_GetLoanByIDForUpdate_Call) Run(run func(ctx context.Context, id int)) *LoanRepository_GetLoanByIDForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *LoanRepository_GetLoanByIDForUpdate_Call) Return(_a0 *domain.Loan, _a1 error) *LoanRepository_GetLoanByIDForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoanRepository_GetLoanByIDForUpdate_Call) RunAndReturn(run func(context.Context, int) (*domain.Loan, error)) *LoanRepository_GetLoanByIDForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLoanIDsPastFundingDeadline provides a mock function with given fields: ctx, now, limit
+func (_m *LoanRepository) ListLoanIDsPastFundingDeadline(ctx context.Context, now time.Time, limit int) ([]int, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLoanIDsPastFundingDeadline")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]int, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []int); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoanRepository_ListLoanIDsPastFundingDeadline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLoanIDsPastFundingDeadline'
+type LoanRepository_ListLoanIDsPastFundingDeadline_Call struct {
+	*mock.Call
+}
+
+// ListLoanIDsPastFundingDeadline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *LoanRepository_Expecter) ListLoanIDsPastFundingDeadline(ctx interface{}, now interface{}, limit interface{}) *LoanRepository_ListLoanIDsPastFundingDeadline_Call {
+	return &LoanRepository_ListLoanIDsPastFundingDeadline_Call{Call: _e.mock.On("ListLoanIDsPastFundingDeadline", ctx, now, limit)}
+}
+
+func (_c *LoanRepository_ListLoanIDsPastFundingDeadline_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *LoanRepository_ListLoanIDsPastFundingDeadline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *LoanRepository_ListLoanIDsPastFundingDeadline_Call) Return(_a0 []int, _a1 error) *LoanRepository_ListLoanIDsPastFundingDeadline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoanRepository_ListLoanIDsPastFundingDeadline_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]int, error)) *LoanRepository_ListLoanIDsPastFundingDeadline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLoans provides a mock function with given fields: ctx, filter
+func (_m *LoanRepository) ListLoans(ctx context.Context, filter domain.LoanFilter) ([]domain.Loan, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLoans")
+	}
+
+	var r0 []domain.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LoanFilter) ([]domain.Loan, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LoanFilter) []domain.Loan); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.LoanFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoanRepository_ListLoans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLoans'
+type LoanRepository_ListLoans_Call struct {
+	*mock.Call
+}
+
+// ListLoans is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.LoanFilter
+func (_e *LoanRepository_Expecter) ListLoans(ctx interface{}, filter interface{}) *LoanRepository_ListLoans_Call {
+	return &LoanRepository_ListLoans_Call{Call: _e.mock.On("ListLoans", ctx, filter)}
+}
+
+func (_c *LoanRepository_ListLoans_Call) Run(run func(ctx context.Context, filter domain.LoanFilter)) *LoanRepository_ListLoans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.LoanFilter))
+	})
+	return _c
+}
+
+func (_c *LoanRepository_ListLoans_Call) Return(_a0 []domain.Loan, _a1 error) *LoanRepository_ListLoans_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoanRepository_ListLoans_Call) RunAndReturn(run func(context.Context, domain.LoanFilter) ([]domain.Loan, error)) *LoanRepository_ListLoans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetAgreementLink provides a mock function with given fields: ctx, id, link
 func (_m *LoanRepository) SetAgreementLink(ctx context.Context, id int, link string) error {
 	ret := _m.Called(ctx, id, link)
@@ -176,6 +356,54 @@ func (_c *LoanRepository_SetAgreementLink_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// SetFundingDeadline provides a mock function with given fields: ctx, id, deadline
+func (_m *LoanRepository) SetFundingDeadline(ctx context.Context, id int, deadline time.Time) error {
+	ret := _m.Called(ctx, id, deadline)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFundingDeadline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, id, deadline)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoanRepository_SetFundingDeadline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFundingDeadline'
+type LoanRepository_SetFundingDeadline_Call struct {
+	*mock.Call
+}
+
+// SetFundingDeadline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - deadline time.Time
+func (_e *LoanRepository_Expecter) SetFundingDeadline(ctx interface{}, id interface{}, deadline interface{}) *LoanRepository_SetFundingDeadline_Call {
+	return &LoanRepository_SetFundingDeadline_Call{Call: _e.mock.On("SetFundingDeadline", ctx, id, deadline)}
+}
+
+func (_c *LoanRepository_SetFundingDeadline_Call) Run(run func(ctx context.Context, id int, deadline time.Time)) *LoanRepository_SetFundingDeadline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoanRepository_SetFundingDeadline_Call) Return(_a0 error) *LoanRepository_SetFundingDeadline_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoanRepository_SetFundingDeadline_Call) RunAndReturn(run func(context.Context, int, time.Time) error) *LoanRepository_SetFundingDeadline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateLoanStatus provides a mock function with given fields: ctx, id, status
 func (_m *LoanRepository) UpdateLoanStatus(ctx context.Context, id int, status domain.LoanStatus) error {
 	ret := _m.Called(ctx, id, status)
@@ -0,0 +1,294 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// OutboxRepository is an autogenerated mock type for the OutboxRepository type
+type OutboxRepository struct {
+	mock.Mock
+}
+
+type OutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxRepository) EXPECT() *OutboxRepository_Expecter {
+	return &OutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimNext provides a mock function with given fields: ctx, now
+func (_m *OutboxRepository) ClaimNext(ctx context.Context, now time.Time) (*domain.OutboxMessage, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimNext")
+	}
+
+	var r0 *domain.OutboxMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*domain.OutboxMessage, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *domain.OutboxMessage); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OutboxMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxRepository_ClaimNext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimNext'
+type OutboxRepository_ClaimNext_Call struct {
+	*mock.Call
+}
+
+// ClaimNext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *OutboxRepository_Expecter) ClaimNext(ctx interface{}, now interface{}) *OutboxRepository_ClaimNext_Call {
+	return &OutboxRepository_ClaimNext_Call{Call: _e.mock.On("ClaimNext", ctx, now)}
+}
+
+func (_c *OutboxRepository_ClaimNext_Call) Run(run func(ctx context.Context, now time.Time)) *OutboxRepository_ClaimNext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_ClaimNext_Call) Return(_a0 *domain.OutboxMessage, _a1 error) *OutboxRepository_ClaimNext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxRepository_ClaimNext_Call) RunAndReturn(run func(context.Context, time.Time) (*domain.OutboxMessage, error)) *OutboxRepository_ClaimNext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enqueue provides a mock function with given fields: ctx, m
+func (_m *OutboxRepository) Enqueue(ctx context.Context, m *domain.OutboxMessage) error {
+	ret := _m.Called(ctx, m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.OutboxMessage) error); ok {
+		r0 = rf(ctx, m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type OutboxRepository_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - m *domain.OutboxMessage
+func (_e *OutboxRepository_Expecter) Enqueue(ctx interface{}, m interface{}) *OutboxRepository_Enqueue_Call {
+	return &OutboxRepository_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, m)}
+}
+
+func (_c *OutboxRepository_Enqueue_Call) Run(run func(ctx context.Context, m *domain.OutboxMessage)) *OutboxRepository_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_Enqueue_Call) Return(_a0 error) *OutboxRepository_Enqueue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_Enqueue_Call) RunAndReturn(run func(context.Context, *domain.OutboxMessage) error) *OutboxRepository_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDead provides a mock function with given fields: ctx, id, attempts, lastErr, at
+func (_m *OutboxRepository) MarkDead(ctx context.Context, id int64, attempts int, lastErr string, at time.Time) error {
+	ret := _m.Called(ctx, id, attempts, lastErr, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, string, time.Time) error); ok {
+		r0 = rf(ctx, id, attempts, lastErr, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_MarkDead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDead'
+type OutboxRepository_MarkDead_Call struct {
+	*mock.Call
+}
+
+// MarkDead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - attempts int
+//   - lastErr string
+//   - at time.Time
+func (_e *OutboxRepository_Expecter) MarkDead(ctx interface{}, id interface{}, attempts interface{}, lastErr interface{}, at interface{}) *OutboxRepository_MarkDead_Call {
+	return &OutboxRepository_MarkDead_Call{Call: _e.mock.On("MarkDead", ctx, id, attempts, lastErr, at)}
+}
+
+func (_c *OutboxRepository_MarkDead_Call) Run(run func(ctx context.Context, id int64, attempts int, lastErr string, at time.Time)) *OutboxRepository_MarkDead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_MarkDead_Call) Return(_a0 error) *OutboxRepository_MarkDead_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_MarkDead_Call) RunAndReturn(run func(context.Context, int64, int, string, time.Time) error) *OutboxRepository_MarkDead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDone provides a mock function with given fields: ctx, id, attempts, at
+func (_m *OutboxRepository) MarkDone(ctx context.Context, id int64, attempts int, at time.Time) error {
+	ret := _m.Called(ctx, id, attempts, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, time.Time) error); ok {
+		r0 = rf(ctx, id, attempts, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_MarkDone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDone'
+type OutboxRepository_MarkDone_Call struct {
+	*mock.Call
+}
+
+// MarkDone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - attempts int
+//   - at time.Time
+func (_e *OutboxRepository_Expecter) MarkDone(ctx interface{}, id interface{}, attempts interface{}, at interface{}) *OutboxRepository_MarkDone_Call {
+	return &OutboxRepository_MarkDone_Call{Call: _e.mock.On("MarkDone", ctx, id, attempts, at)}
+}
+
+func (_c *OutboxRepository_MarkDone_Call) Run(run func(ctx context.Context, id int64, attempts int, at time.Time)) *OutboxRepository_MarkDone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_MarkDone_Call) Return(_a0 error) *OutboxRepository_MarkDone_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_MarkDone_Call) RunAndReturn(run func(context.Context, int64, int, time.Time) error) *OutboxRepository_MarkDone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRetry provides a mock function with given fields: ctx, id, attempts, next, lastErr
+func (_m *OutboxRepository) MarkRetry(ctx context.Context, id int64, attempts int, next time.Time, lastErr string) error {
+	ret := _m.Called(ctx, id, attempts, next, lastErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRetry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, time.Time, string) error); ok {
+		r0 = rf(ctx, id, attempts, next, lastErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_MarkRetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRetry'
+type OutboxRepository_MarkRetry_Call struct {
+	*mock.Call
+}
+
+// MarkRetry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - attempts int
+//   - next time.Time
+//   - lastErr string
+func (_e *OutboxRepository_Expecter) MarkRetry(ctx interface{}, id interface{}, attempts interface{}, next interface{}, lastErr interface{}) *OutboxRepository_MarkRetry_Call {
+	return &OutboxRepository_MarkRetry_Call{Call: _e.mock.On("MarkRetry", ctx, id, attempts, next, lastErr)}
+}
+
+func (_c *OutboxRepository_MarkRetry_Call) Run(run func(ctx context.Context, id int64, attempts int, next time.Time, lastErr string)) *OutboxRepository_MarkRetry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(time.Time), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_MarkRetry_Call) Return(_a0 error) *OutboxRepository_MarkRetry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_MarkRetry_Call) RunAndReturn(run func(context.Context, int64, int, time.Time, string) error) *OutboxRepository_MarkRetry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepository {
+	mock := &OutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
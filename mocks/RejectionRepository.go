@@ -0,0 +1,84 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RejectionRepository is an autogenerated mock type for the RejectionRepository type
+type RejectionRepository struct {
+	mock.Mock
+}
+
+type RejectionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RejectionRepository) EXPECT() *RejectionRepository_Expecter {
+	return &RejectionRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateRejection provides a mock function with given fields: ctx, r
+func (_m *RejectionRepository) CreateRejection(ctx context.Context, r *domain.LoanRejection) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRejection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoanRejection) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RejectionRepository_CreateRejection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRejection'
+type RejectionRepository_CreateRejection_Call struct {
+	*mock.Call
+}
+
+// CreateRejection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *domain.LoanRejection
+func (_e *RejectionRepository_Expecter) CreateRejection(ctx interface{}, r interface{}) *RejectionRepository_CreateRejection_Call {
+	return &RejectionRepository_CreateRejection_Call{Call: _e.mock.On("CreateRejection", ctx, r)}
+}
+
+func (_c *RejectionRepository_CreateRejection_Call) Run(run func(ctx context.Context, r *domain.LoanRejection)) *RejectionRepository_CreateRejection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.LoanRejection))
+	})
+	return _c
+}
+
+func (_c *RejectionRepository_CreateRejection_Call) Return(_a0 error) *RejectionRepository_CreateRejection_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RejectionRepository_CreateRejection_Call) RunAndReturn(run func(context.Context, *domain.LoanRejection) error) *RejectionRepository_CreateRejection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRejectionRepository creates a new instance of RejectionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRejectionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RejectionRepository {
+	mock := &RejectionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RepaymentRepository is an autogenerated mock type for the RepaymentRepository type
+type RepaymentRepository struct {
+	mock.Mock
+}
+
+type RepaymentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RepaymentRepository) EXPECT() *RepaymentRepository_Expecter {
+	return &RepaymentRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateRepayment provides a mock function with given fields: ctx, r
+func (_m *RepaymentRepository) CreateRepayment(ctx context.Context, r *domain.Repayment) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRepayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Repayment) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RepaymentRepository_CreateRepayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRepayment'
+type RepaymentRepository_CreateRepayment_Call struct {
+	*mock.Call
+}
+
+// CreateRepayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *domain.Repayment
+func (_e *RepaymentRepository_Expecter) CreateRepayment(ctx interface{}, r interface{}) *RepaymentRepository_CreateRepayment_Call {
+	return &RepaymentRepository_CreateRepayment_Call{Call: _e.mock.On("CreateRepayment", ctx, r)}
+}
+
+func (_c *RepaymentRepository_CreateRepayment_Call) Run(run func(ctx context.Context, r *domain.Repayment)) *RepaymentRepository_CreateRepayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Repayment))
+	})
+	return _c
+}
+
+func (_c *RepaymentRepository_CreateRepayment_Call) Return(_a0 error) *RepaymentRepository_CreateRepayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RepaymentRepository_CreateRepayment_Call) RunAndReturn(run func(context.Context, *domain.Repayment) error) *RepaymentRepository_CreateRepayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRepaymentRepository creates a new instance of RepaymentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepaymentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RepaymentRepository {
+	mock := &RepaymentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
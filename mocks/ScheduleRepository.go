@@ -0,0 +1,190 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ScheduleRepository is an autogenerated mock type for the ScheduleRepository type
+type ScheduleRepository struct {
+	mock.Mock
+}
+
+type ScheduleRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ScheduleRepository) EXPECT() *ScheduleRepository_Expecter {
+	return &ScheduleRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateInstallments provides a mock function with given fields: ctx, installments
+func (_m *ScheduleRepository) CreateInstallments(ctx context.Context, installments []domain.Installment) error {
+	ret := _m.Called(ctx, installments)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInstallments")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Installment) error); ok {
+		r0 = rf(ctx, installments)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScheduleRepository_CreateInstallments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInstallments'
+type ScheduleRepository_CreateInstallments_Call struct {
+	*mock.Call
+}
+
+// CreateInstallments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - installments []domain.Installment
+func (_e *ScheduleRepository_Expecter) CreateInstallments(ctx interface{}, installments interface{}) *ScheduleRepository_CreateInstallments_Call {
+	return &ScheduleRepository_CreateInstallments_Call{Call: _e.mock.On("CreateInstallments", ctx, installments)}
+}
+
+func (_c *ScheduleRepository_CreateInstallments_Call) Run(run func(ctx context.Context, installments []domain.Installment)) *ScheduleRepository_CreateInstallments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Installment))
+	})
+	return _c
+}
+
+func (_c *ScheduleRepository_CreateInstallments_Call) Return(_a0 error) *ScheduleRepository_CreateInstallments_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScheduleRepository_CreateInstallments_Call) RunAndReturn(run func(context.Context, []domain.Installment) error) *ScheduleRepository_CreateInstallments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetScheduleByLoan provides a mock function with given fields: ctx, loanID
+func (_m *ScheduleRepository) GetScheduleByLoan(ctx context.Context, loanID int) ([]domain.Installment, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScheduleByLoan")
+	}
+
+	var r0 []domain.Installment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Installment, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Installment); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Installment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleRepository_GetScheduleByLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetScheduleByLoan'
+type ScheduleRepository_GetScheduleByLoan_Call struct {
+	*mock.Call
+}
+
+// GetScheduleByLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loanID int
+func (_e *ScheduleRepository_Expecter) GetScheduleByLoan(ctx interface{}, loanID interface{}) *ScheduleRepository_GetScheduleByLoan_Call {
+	return &ScheduleRepository_GetScheduleByLoan_Call{Call: _e.mock.On("GetScheduleByLoan", ctx, loanID)}
+}
+
+func (_c *ScheduleRepository_GetScheduleByLoan_Call) Run(run func(ctx context.Context, loanID int)) *ScheduleRepository_GetScheduleByLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ScheduleRepository_GetScheduleByLoan_Call) Return(_a0 []domain.Installment, _a1 error) *ScheduleRepository_GetScheduleByLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScheduleRepository_GetScheduleByLoan_Call) RunAndReturn(run func(context.Context, int) ([]domain.Installment, error)) *ScheduleRepository_GetScheduleByLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateInstallmentPayment provides a mock function with given fields: ctx, inst
+func (_m *ScheduleRepository) UpdateInstallmentPayment(ctx context.Context, inst domain.Installment) error {
+	ret := _m.Called(ctx, inst)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateInstallmentPayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Installment) error); ok {
+		r0 = rf(ctx, inst)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScheduleRepository_UpdateInstallmentPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateInstallmentPayment'
+type ScheduleRepository_UpdateInstallmentPayment_Call struct {
+	*mock.Call
+}
+
+// UpdateInstallmentPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - inst domain.Installment
+func (_e *ScheduleRepository_Expecter) UpdateInstallmentPayment(ctx interface{}, inst interface{}) *ScheduleRepository_UpdateInstallmentPayment_Call {
+	return &ScheduleRepository_UpdateInstallmentPayment_Call{Call: _e.mock.On("UpdateInstallmentPayment", ctx, inst)}
+}
+
+func (_c *ScheduleRepository_UpdateInstallmentPayment_Call) Run(run func(ctx context.Context, inst domain.Installment)) *ScheduleRepository_UpdateInstallmentPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Installment))
+	})
+	return _c
+}
+
+func (_c *ScheduleRepository_UpdateInstallmentPayment_Call) Return(_a0 error) *ScheduleRepository_UpdateInstallmentPayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScheduleRepository_UpdateInstallmentPayment_Call) RunAndReturn(run func(context.Context, domain.Installment) error) *ScheduleRepository_UpdateInstallmentPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewScheduleRepository creates a new instance of ScheduleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduleRepository {
+	mock := &ScheduleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
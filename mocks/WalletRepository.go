@@ -0,0 +1,357 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WalletRepository is an autogenerated mock type for the WalletRepository type
+type WalletRepository struct {
+	mock.Mock
+}
+
+type WalletRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WalletRepository) EXPECT() *WalletRepository_Expecter {
+	return &WalletRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateHold provides a mock function with given fields: ctx, h
+func (_m *WalletRepository) CreateHold(ctx context.Context, h *domain.WalletHold) error {
+	ret := _m.Called(ctx, h)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.WalletHold) error); ok {
+		r0 = rf(ctx, h)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WalletRepository_CreateHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHold'
+type WalletRepository_CreateHold_Call struct {
+	*mock.Call
+}
+
+// CreateHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - h *domain.WalletHold
+func (_e *WalletRepository_Expecter) CreateHold(ctx interface{}, h interface{}) *WalletRepository_CreateHold_Call {
+	return &WalletRepository_CreateHold_Call{Call: _e.mock.On("CreateHold", ctx, h)}
+}
+
+func (_c *WalletRepository_CreateHold_Call) Run(run func(ctx context.Context, h *domain.WalletHold)) *WalletRepository_CreateHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.WalletHold))
+	})
+	return _c
+}
+
+func (_c *WalletRepository_CreateHold_Call) Return(_a0 error) *WalletRepository_CreateHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WalletRepository_CreateHold_Call) RunAndReturn(run func(context.Context, *domain.WalletHold) error) *WalletRepository_CreateHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveHoldsByLoan provides a mock function with given fields: ctx, loanID
+func (_m *WalletRepository) GetActiveHoldsByLoan(ctx context.Context, loanID int) ([]domain.WalletHold, error) {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveHoldsByLoan")
+	}
+
+	var r0 []domain.WalletHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.WalletHold, error)); ok {
+		return rf(ctx, loanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.WalletHold); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WalletHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, loanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WalletRepository_GetActiveHoldsByLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveHoldsByLoan'
+type WalletRepository_GetActiveHoldsByLoan_Call struct {
+	*mock.Call
+}
+
+// GetActiveHoldsByLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loanID int
+func (_e *WalletRepository_Expecter) GetActiveHoldsByLoan(ctx interface{}, loanID interface{}) *WalletRepository_GetActiveHoldsByLoan_Call {
+	return &WalletRepository_GetActiveHoldsByLoan_Call{Call: _e.mock.On("GetActiveHoldsByLoan", ctx, loanID)}
+}
+
+func (_c *WalletRepository_GetActiveHoldsByLoan_Call) Run(run func(ctx context.Context, loanID int)) *WalletRepository_GetActiveHoldsByLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *WalletRepository_GetActiveHoldsByLoan_Call) Return(_a0 []domain.WalletHold, _a1 error) *WalletRepository_GetActiveHoldsByLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WalletRepository_GetActiveHoldsByLoan_Call) RunAndReturn(run func(context.Context, int) ([]domain.WalletHold, error)) *WalletRepository_GetActiveHoldsByLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWallet provides a mock function with given fields: ctx, email
+func (_m *WalletRepository) GetWallet(ctx context.Context, email string) (*domain.Wallet, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWallet")
+	}
+
+	var r0 *domain.Wallet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Wallet, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Wallet); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Wallet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WalletRepository_GetWallet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWallet'
+type WalletRepository_GetWallet_Call struct {
+	*mock.Call
+}
+
+// GetWallet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *WalletRepository_Expecter) GetWallet(ctx interface{}, email interface{}) *WalletRepository_GetWallet_Call {
+	return &WalletRepository_GetWallet_Call{Call: _e.mock.On("GetWallet", ctx, email)}
+}
+
+func (_c *WalletRepository_GetWallet_Call) Run(run func(ctx context.Context, email string)) *WalletRepository_GetWallet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WalletRepository_GetWallet_Call) Return(_a0 *domain.Wallet, _a1 error) *WalletRepository_GetWallet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WalletRepository_GetWallet_Call) RunAndReturn(run func(context.Context, string) (*domain.Wallet, error)) *WalletRepository_GetWallet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWalletForUpdate provides a mock function with given fields: ctx, email, currency
+func (_m *WalletRepository) GetWalletForUpdate(ctx context.Context, email string, currency domain.Currency) (*domain.Wallet, error) {
+	ret := _m.Called(ctx, email, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWalletForUpdate")
+	}
+
+	var r0 *domain.Wallet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Currency) (*domain.Wallet, error)); ok {
+		return rf(ctx, email, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Currency) *domain.Wallet); ok {
+		r0 = rf(ctx, email, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Wallet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.Currency) error); ok {
+		r1 = rf(ctx, email, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WalletRepository_GetWalletForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWalletForUpdate'
+type WalletRepository_GetWalletForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetWalletForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - currency domain.Currency
+func (_e *WalletRepository_Expecter) GetWalletForUpdate(ctx interface{}, email interface{}, currency interface{}) *WalletRepository_GetWalletForUpdate_Call {
+	return &WalletRepository_GetWalletForUpdate_Call{Call: _e.mock.On("GetWalletForUpdate", ctx, email, currency)}
+}
+
+func (_c *WalletRepository_GetWalletForUpdate_Call) Run(run func(ctx context.Context, email string, currency domain.Currency)) *WalletRepository_GetWalletForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.Currency))
+	})
+	return _c
+}
+
+func (_c *WalletRepository_GetWalletForUpdate_Call) Return(_a0 *domain.Wallet, _a1 error) *WalletRepository_GetWalletForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WalletRepository_GetWalletForUpdate_Call) RunAndReturn(run func(context.Context, string, domain.Currency) (*domain.Wallet, error)) *WalletRepository_GetWalletForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateHoldStatus provides a mock function with given fields: ctx, id, status
+func (_m *WalletRepository) UpdateHoldStatus(ctx context.Context, id int, status domain.HoldStatus) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateHoldStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.HoldStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WalletRepository_UpdateHoldStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateHoldStatus'
+type WalletRepository_UpdateHoldStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateHoldStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - status domain.HoldStatus
+func (_e *WalletRepository_Expecter) UpdateHoldStatus(ctx interface{}, id interface{}, status interface{}) *WalletRepository_UpdateHoldStatus_Call {
+	return &WalletRepository_UpdateHoldStatus_Call{Call: _e.mock.On("UpdateHoldStatus", ctx, id, status)}
+}
+
+func (_c *WalletRepository_UpdateHoldStatus_Call) Run(run func(ctx context.Context, id int, status domain.HoldStatus)) *WalletRepository_UpdateHoldStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(domain.HoldStatus))
+	})
+	return _c
+}
+
+func (_c *WalletRepository_UpdateHoldStatus_Call) Return(_a0 error) *WalletRepository_UpdateHoldStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WalletRepository_UpdateHoldStatus_Call) RunAndReturn(run func(context.Context, int, domain.HoldStatus) error) *WalletRepository_UpdateHoldStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWallet provides a mock function with given fields: ctx, w
+func (_m *WalletRepository) UpdateWallet(ctx context.Context, w *domain.Wallet) error {
+	ret := _m.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWallet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Wallet) error); ok {
+		r0 = rf(ctx, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WalletRepository_UpdateWallet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWallet'
+type WalletRepository_UpdateWallet_Call struct {
+	*mock.Call
+}
+
+// UpdateWallet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w *domain.Wallet
+func (_e *WalletRepository_Expecter) UpdateWallet(ctx interface{}, w interface{}) *WalletRepository_UpdateWallet_Call {
+	return &WalletRepository_UpdateWallet_Call{Call: _e.mock.On("UpdateWallet", ctx, w)}
+}
+
+func (_c *WalletRepository_UpdateWallet_Call) Run(run func(ctx context.Context, w *domain.Wallet)) *WalletRepository_UpdateWallet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Wallet))
+	})
+	return _c
+}
+
+func (_c *WalletRepository_UpdateWallet_Call) Return(_a0 error) *WalletRepository_UpdateWallet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WalletRepository_UpdateWallet_Call) RunAndReturn(run func(context.Context, *domain.Wallet) error) *WalletRepository_UpdateWallet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWalletRepository creates a new instance of WalletRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWalletRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WalletRepository {
+	mock := &WalletRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,249 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookDeliveryRepository is an autogenerated mock type for the WebhookDeliveryRepository type
+type WebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+type WebhookDeliveryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookDeliveryRepository) EXPECT() *WebhookDeliveryRepository_Expecter {
+	return &WebhookDeliveryRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateDelivery provides a mock function with given fields: ctx, d
+func (_m *WebhookDeliveryRepository) CreateDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.WebhookDelivery) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryRepository_CreateDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDelivery'
+type WebhookDeliveryRepository_CreateDelivery_Call struct {
+	*mock.Call
+}
+
+// CreateDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d *domain.WebhookDelivery
+func (_e *WebhookDeliveryRepository_Expecter) CreateDelivery(ctx interface{}, d interface{}) *WebhookDeliveryRepository_CreateDelivery_Call {
+	return &WebhookDeliveryRepository_CreateDelivery_Call{Call: _e.mock.On("CreateDelivery", ctx, d)}
+}
+
+func (_c *WebhookDeliveryRepository_CreateDelivery_Call) Run(run func(ctx context.Context, d *domain.WebhookDelivery)) *WebhookDeliveryRepository_CreateDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_CreateDelivery_Call) Return(_a0 error) *WebhookDeliveryRepository_CreateDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_CreateDelivery_Call) RunAndReturn(run func(context.Context, *domain.WebhookDelivery) error) *WebhookDeliveryRepository_CreateDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveryByID provides a mock function with given fields: ctx, id
+func (_m *WebhookDeliveryRepository) GetDeliveryByID(ctx context.Context, id int) (*domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveryByID")
+	}
+
+	var r0 *domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.WebhookDelivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.WebhookDelivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryRepository_GetDeliveryByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveryByID'
+type WebhookDeliveryRepository_GetDeliveryByID_Call struct {
+	*mock.Call
+}
+
+// GetDeliveryByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *WebhookDeliveryRepository_Expecter) GetDeliveryByID(ctx interface{}, id interface{}) *WebhookDeliveryRepository_GetDeliveryByID_Call {
+	return &WebhookDeliveryRepository_GetDeliveryByID_Call{Call: _e.mock.On("GetDeliveryByID", ctx, id)}
+}
+
+func (_c *WebhookDeliveryRepository_GetDeliveryByID_Call) Run(run func(ctx context.Context, id int)) *WebhookDeliveryRepository_GetDeliveryByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_GetDeliveryByID_Call) Return(_a0 *domain.WebhookDelivery, _a1 error) *WebhookDeliveryRepository_GetDeliveryByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_GetDeliveryByID_Call) RunAndReturn(run func(context.Context, int) (*domain.WebhookDelivery, error)) *WebhookDeliveryRepository_GetDeliveryByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeliveries provides a mock function with given fields: ctx, filter
+func (_m *WebhookDeliveryRepository) ListDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 []domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookDeliveryFilter) []domain.WebhookDelivery); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.WebhookDeliveryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryRepository_ListDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeliveries'
+type WebhookDeliveryRepository_ListDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.WebhookDeliveryFilter
+func (_e *WebhookDeliveryRepository_Expecter) ListDeliveries(ctx interface{}, filter interface{}) *WebhookDeliveryRepository_ListDeliveries_Call {
+	return &WebhookDeliveryRepository_ListDeliveries_Call{Call: _e.mock.On("ListDeliveries", ctx, filter)}
+}
+
+func (_c *WebhookDeliveryRepository_ListDeliveries_Call) Run(run func(ctx context.Context, filter domain.WebhookDeliveryFilter)) *WebhookDeliveryRepository_ListDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WebhookDeliveryFilter))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_ListDeliveries_Call) Return(_a0 []domain.WebhookDelivery, _a1 error) *WebhookDeliveryRepository_ListDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_ListDeliveries_Call) RunAndReturn(run func(context.Context, domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)) *WebhookDeliveryRepository_ListDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDelivery provides a mock function with given fields: ctx, d
+func (_m *WebhookDeliveryRepository) UpdateDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.WebhookDelivery) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryRepository_UpdateDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDelivery'
+type WebhookDeliveryRepository_UpdateDelivery_Call struct {
+	*mock.Call
+}
+
+// UpdateDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d *domain.WebhookDelivery
+func (_e *WebhookDeliveryRepository_Expecter) UpdateDelivery(ctx interface{}, d interface{}) *WebhookDeliveryRepository_UpdateDelivery_Call {
+	return &WebhookDeliveryRepository_UpdateDelivery_Call{Call: _e.mock.On("UpdateDelivery", ctx, d)}
+}
+
+func (_c *WebhookDeliveryRepository_UpdateDelivery_Call) Run(run func(ctx context.Context, d *domain.WebhookDelivery)) *WebhookDeliveryRepository_UpdateDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_UpdateDelivery_Call) Return(_a0 error) *WebhookDeliveryRepository_UpdateDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_UpdateDelivery_Call) RunAndReturn(run func(context.Context, *domain.WebhookDelivery) error) *WebhookDeliveryRepository_UpdateDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookDeliveryRepository creates a new instance of WebhookDeliveryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookDeliveryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookDeliveryRepository {
+	mock := &WebhookDeliveryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
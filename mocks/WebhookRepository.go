@@ -0,0 +1,365 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/martinusiron/loan-service/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookRepository is an autogenerated mock type for the WebhookRepository type
+type WebhookRepository struct {
+	mock.Mock
+}
+
+type WebhookRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookRepository) EXPECT() *WebhookRepository_Expecter {
+	return &WebhookRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, w
+func (_m *WebhookRepository) CreateWebhook(ctx context.Context, w *domain.Webhook) error {
+	ret := _m.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Webhook) error); ok {
+		r0 = rf(ctx, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookRepository_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type WebhookRepository_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w *domain.Webhook
+func (_e *WebhookRepository_Expecter) CreateWebhook(ctx interface{}, w interface{}) *WebhookRepository_CreateWebhook_Call {
+	return &WebhookRepository_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, w)}
+}
+
+func (_c *WebhookRepository_CreateWebhook_Call) Run(run func(ctx context.Context, w *domain.Webhook)) *WebhookRepository_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Webhook))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_CreateWebhook_Call) Return(_a0 error) *WebhookRepository_CreateWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookRepository_CreateWebhook_Call) RunAndReturn(run func(context.Context, *domain.Webhook) error) *WebhookRepository_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *WebhookRepository) DeleteWebhook(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type WebhookRepository_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *WebhookRepository_Expecter) DeleteWebhook(ctx interface{}, id interface{}) *WebhookRepository_DeleteWebhook_Call {
+	return &WebhookRepository_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, id)}
+}
+
+func (_c *WebhookRepository_DeleteWebhook_Call) Run(run func(ctx context.Context, id int)) *WebhookRepository_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_DeleteWebhook_Call) Return(_a0 bool, _a1 error) *WebhookRepository_DeleteWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_DeleteWebhook_Call) RunAndReturn(run func(context.Context, int) (bool, error)) *WebhookRepository_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookByID provides a mock function with given fields: ctx, id
+func (_m *WebhookRepository) GetWebhookByID(ctx context.Context, id int) (*domain.Webhook, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookByID")
+	}
+
+	var r0 *domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Webhook, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Webhook); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_GetWebhookByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookByID'
+type WebhookRepository_GetWebhookByID_Call struct {
+	*mock.Call
+}
+
+// GetWebhookByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *WebhookRepository_Expecter) GetWebhookByID(ctx interface{}, id interface{}) *WebhookRepository_GetWebhookByID_Call {
+	return &WebhookRepository_GetWebhookByID_Call{Call: _e.mock.On("GetWebhookByID", ctx, id)}
+}
+
+func (_c *WebhookRepository_GetWebhookByID_Call) Run(run func(ctx context.Context, id int)) *WebhookRepository_GetWebhookByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_GetWebhookByID_Call) Return(_a0 *domain.Webhook, _a1 error) *WebhookRepository_GetWebhookByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_GetWebhookByID_Call) RunAndReturn(run func(context.Context, int) (*domain.Webhook, error)) *WebhookRepository_GetWebhookByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActiveWebhooksForEvent provides a mock function with given fields: ctx, name
+func (_m *WebhookRepository) ListActiveWebhooksForEvent(ctx context.Context, name domain.EventName) ([]domain.Webhook, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveWebhooksForEvent")
+	}
+
+	var r0 []domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.EventName) ([]domain.Webhook, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.EventName) []domain.Webhook); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.EventName) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_ListActiveWebhooksForEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveWebhooksForEvent'
+type WebhookRepository_ListActiveWebhooksForEvent_Call struct {
+	*mock.Call
+}
+
+// ListActiveWebhooksForEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name domain.EventName
+func (_e *WebhookRepository_Expecter) ListActiveWebhooksForEvent(ctx interface{}, name interface{}) *WebhookRepository_ListActiveWebhooksForEvent_Call {
+	return &WebhookRepository_ListActiveWebhooksForEvent_Call{Call: _e.mock.On("ListActiveWebhooksForEvent", ctx, name)}
+}
+
+func (_c *WebhookRepository_ListActiveWebhooksForEvent_Call) Run(run func(ctx context.Context, name domain.EventName)) *WebhookRepository_ListActiveWebhooksForEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.EventName))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_ListActiveWebhooksForEvent_Call) Return(_a0 []domain.Webhook, _a1 error) *WebhookRepository_ListActiveWebhooksForEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_ListActiveWebhooksForEvent_Call) RunAndReturn(run func(context.Context, domain.EventName) ([]domain.Webhook, error)) *WebhookRepository_ListActiveWebhooksForEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhooks provides a mock function with given fields: ctx, filter
+func (_m *WebhookRepository) ListWebhooks(ctx context.Context, filter domain.WebhookFilter) ([]domain.Webhook, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 []domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookFilter) ([]domain.Webhook, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookFilter) []domain.Webhook); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.WebhookFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
+type WebhookRepository_ListWebhooks_Call struct {
+	*mock.Call
+}
+
+// ListWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.WebhookFilter
+func (_e *WebhookRepository_Expecter) ListWebhooks(ctx interface{}, filter interface{}) *WebhookRepository_ListWebhooks_Call {
+	return &WebhookRepository_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", ctx, filter)}
+}
+
+func (_c *WebhookRepository_ListWebhooks_Call) Run(run func(ctx context.Context, filter domain.WebhookFilter)) *WebhookRepository_ListWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WebhookFilter))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_ListWebhooks_Call) Return(_a0 []domain.Webhook, _a1 error) *WebhookRepository_ListWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_ListWebhooks_Call) RunAndReturn(run func(context.Context, domain.WebhookFilter) ([]domain.Webhook, error)) *WebhookRepository_ListWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWebhook provides a mock function with given fields: ctx, w
+func (_m *WebhookRepository) UpdateWebhook(ctx context.Context, w *domain.Webhook) error {
+	ret := _m.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Webhook) error); ok {
+		r0 = rf(ctx, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookRepository_UpdateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhook'
+type WebhookRepository_UpdateWebhook_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w *domain.Webhook
+func (_e *WebhookRepository_Expecter) UpdateWebhook(ctx interface{}, w interface{}) *WebhookRepository_UpdateWebhook_Call {
+	return &WebhookRepository_UpdateWebhook_Call{Call: _e.mock.On("UpdateWebhook", ctx, w)}
+}
+
+func (_c *WebhookRepository_UpdateWebhook_Call) Run(run func(ctx context.Context, w *domain.Webhook)) *WebhookRepository_UpdateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Webhook))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_UpdateWebhook_Call) Return(_a0 error) *WebhookRepository_UpdateWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookRepository_UpdateWebhook_Call) RunAndReturn(run func(context.Context, *domain.Webhook) error) *WebhookRepository_UpdateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookRepository {
+	mock := &WebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Package notify delivers notifications as e-mail. Messages are rendered
+// from a catalogue of templates, one per notification kind, and handed to a
+// Sender such as SMTPSender.
+package notify
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"strings"
+	"text/template"
+
+	"github.com/martinusiron/loan-service/domain"
+)
+
+// Message is a rendered notification, ready to be sent.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+//go:embed templates
+var embedded embed.FS
+
+// DefaultTemplates are the templates built into the service.
+var DefaultTemplates = func() fs.FS {
+	sub, err := fs.Sub(embedded, "templates")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}()
+
+// kinds are the notification kinds a catalogue must have a template for.
+var kinds = []domain.NotificationKind{
+	domain.NotifyLoanFunded,
+	domain.NotifyLoanExpired,
+	domain.NotifyLoanCancelled,
+}
+
+// Catalogue holds the message templates, one "<kind>.txt" file per
+// notification kind. The first line a template renders is the subject and
+// the rest the body.
+type Catalogue struct {
+	templates *template.Template
+}
+
+func NewCatalogue(fsys fs.FS) (*Catalogue, error) {
+	t, err := template.New("").Option("missingkey=error").ParseFS(fsys, "*.txt")
+	if err != nil {
+		return nil, fmt.Errorf("parse notification templates: %w", err)
+	}
+	for _, kind := range kinds {
+		if t.Lookup(string(kind)+".txt") == nil {
+			return nil, fmt.Errorf("notification template %s.txt is missing", kind)
+		}
+	}
+	return &Catalogue{templates: t}, nil
+}
+
+var defaultCatalogue = func() *Catalogue {
+	c, err := NewCatalogue(DefaultTemplates)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}()
+
+// DefaultCatalogue returns a Catalogue using DefaultTemplates.
+func DefaultCatalogue() *Catalogue {
+	return defaultCatalogue
+}
+
+// Render fills in the template of the notification's kind.
+func (c *Catalogue) Render(n domain.Notification) (Message, error) {
+	t := c.templates.Lookup(string(n.Kind) + ".txt")
+	if t == nil {
+		return Message{}, fmt.Errorf("no notification template for %q", n.Kind)
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, n.Data); err != nil {
+		return Message{}, fmt.Errorf("render %s notification: %w", n.Kind, err)
+	}
+	subject, body, _ := strings.Cut(out.String(), "\n")
+	return Message{
+		To:      n.To,
+		Subject: strings.TrimSpace(subject),
+		Body:    strings.TrimLeft(body, "\n"),
+	}, nil
+}
@@ -0,0 +1,34 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/martinusiron/loan-service/configs"
+)
+
+// NewFromConfig builds the notifier described by the notifications section
+// of the config.
+func NewFromConfig(cfg configs.NotificationsConfig) (*Notifier, error) {
+	catalogue := DefaultCatalogue()
+	if cfg.TemplateDir != "" {
+		var err error
+		catalogue, err = NewCatalogue(os.DirFS(cfg.TemplateDir))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch cfg.Driver {
+	case "", "log":
+		return NewNotifier(catalogue, LogSender{}), nil
+	case "smtp":
+		sender, err := NewSMTPSender(cfg.SMTP.Addr, cfg.From,
+			os.Getenv(cfg.SMTP.UsernameEnv), os.Getenv(cfg.SMTP.PasswordEnv), cfg.SMTP.TLS)
+		if err != nil {
+			return nil, err
+		}
+		return NewNotifier(catalogue, sender), nil
+	}
+	return nil, fmt.Errorf("unknown notifications driver %q", cfg.Driver)
+}
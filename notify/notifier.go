@@ -0,0 +1,29 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/martinusiron/loan-service/domain"
+)
+
+// Notifier renders notifications from a Catalogue and sends them with a
+// Sender, returning once the message is delivered. Retrying failed
+// deliveries is left to the caller, usually the outbox dispatcher.
+type Notifier struct {
+	Catalogue *Catalogue
+	Sender    Sender
+}
+
+func NewNotifier(catalogue *Catalogue, sender Sender) *Notifier {
+	return &Notifier{Catalogue: catalogue, Sender: sender}
+}
+
+// Notify fails with a PermanentError when the notification cannot be
+// rendered, since sending it again would fail the same way.
+func (n *Notifier) Notify(ctx context.Context, notification domain.Notification) error {
+	m, err := n.Catalogue.Render(notification)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	return n.Sender.Send(ctx, m)
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSender records the messages it is given and fails as long as fail
+// returns an error.
+type fakeSender struct {
+	mu       sync.Mutex
+	attempts int
+	sent     []Message
+	fail     func(attempt int) error
+}
+
+func (f *fakeSender) Send(_ context.Context, m Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attempts++
+	if f.fail != nil {
+		if err := f.fail(f.attempts); err != nil {
+			return err
+		}
+	}
+	f.sent = append(f.sent, m)
+	return nil
+}
+
+func funded(to string) domain.Notification {
+	return domain.Notification{
+		Kind: domain.NotifyLoanFunded,
+		To:   to,
+		Data: map[string]any{"LoanID": 7, "Amount": "IDR 1,500,000.00"},
+	}
+}
+
+func TestCatalogue_Render(t *testing.T) {
+	m, err := DefaultCatalogue().Render(funded("ani@example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "ani@example.com", m.To)
+	assert.Equal(t, "Loan #7 has been fully funded", m.Subject)
+	assert.Contains(t, m.Body, "IDR 1,500,000.00 in loan #7")
+
+	_, err = DefaultCatalogue().Render(domain.Notification{Kind: domain.NotifyLoanExpired, To: "ani@example.com", Data: map[string]any{"LoanID": 7}})
+	assert.Error(t, err, "missing template data must not render as <no value>")
+
+	_, err = NewCatalogue(fstest.MapFS{"loan_funded.txt": {Data: []byte("Funded\n")}})
+	assert.ErrorContains(t, err, "loan_expired.txt")
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewNotifier(DefaultCatalogue(), sender)
+
+	require.NoError(t, n.Notify(context.Background(), funded("ani@example.com")))
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "Loan #7 has been fully funded", sender.sent[0].Subject)
+
+	// A notification that cannot be rendered is never worth retrying.
+	var permanent *PermanentError
+	err := n.Notify(context.Background(), domain.Notification{Kind: domain.NotifyLoanFunded, To: "ani@example.com"})
+	assert.ErrorAs(t, err, &permanent)
+
+	sender.fail = func(int) error { return errors.New("connection refused") }
+	err = n.Notify(context.Background(), funded("ani@example.com"))
+	assert.EqualError(t, err, "connection refused")
+	assert.False(t, errors.As(err, &permanent))
+}
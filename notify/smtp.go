@@ -0,0 +1,221 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Sender delivers a rendered message.
+type Sender interface {
+	Send(ctx context.Context, m Message) error
+}
+
+// PermanentError marks a delivery failure that retrying cannot fix, such as
+// a recipient the mail server rejects.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent tells the outbox dispatcher not to retry the delivery.
+func (e *PermanentError) Permanent() bool { return true }
+
+// LogSender only logs messages. It stands in for a mail server in
+// development.
+type LogSender struct{}
+
+func (LogSender) Send(_ context.Context, m Message) error {
+	log.Printf("[EMAIL] To: %s | Subject: %s | Body: %s", m.To, m.Subject, strings.ReplaceAll(m.Body, "\n", " "))
+	return nil
+}
+
+// TLS modes of SMTPSender.
+const (
+	// TLSOpportunistic upgrades the connection with STARTTLS when the server
+	// offers it.
+	TLSOpportunistic = ""
+	// TLSStartTLS requires STARTTLS.
+	TLSStartTLS = "starttls"
+	// TLSImplicit connects over TLS from the start, usually on port 465.
+	TLSImplicit = "tls"
+	// TLSNone never encrypts the connection.
+	TLSNone = "none"
+)
+
+// SMTPSender sends messages through an SMTP server, one connection per
+// message.
+type SMTPSender struct {
+	Addr string
+	From mail.Address
+	// Username and Password, when set, authenticate with AUTH PLAIN. The
+	// password is only sent over TLS or to a server on localhost.
+	Username string
+	Password string
+	TLS      string
+	// TLSConfig overrides the TLS settings; ServerName defaults to the host
+	// of Addr.
+	TLSConfig *tls.Config
+	// Timeout bounds a whole delivery when the context has no earlier
+	// deadline.
+	Timeout time.Duration
+}
+
+func NewSMTPSender(addr, from, username, password, tlsMode string) (*SMTPSender, error) {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", from, err)
+	}
+	switch tlsMode {
+	case TLSOpportunistic, TLSStartTLS, TLSImplicit, TLSNone:
+	default:
+		return nil, fmt.Errorf("unknown SMTP TLS mode %q", tlsMode)
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q: %w", addr, err)
+	}
+	return &SMTPSender{
+		Addr:     addr,
+		From:     *sender,
+		Username: username,
+		Password: password,
+		TLS:      tlsMode,
+		Timeout:  30 * time.Second,
+	}, nil
+}
+
+func (s *SMTPSender) Send(ctx context.Context, m Message) error {
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return &PermanentError{Err: fmt.Errorf("invalid recipient %q: %w", m.To, err)}
+	}
+	msg, err := s.compose(to, m, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := s.deliver(ctx, to.Address, msg); err != nil {
+		var proto *textproto.Error
+		if errors.As(err, &proto) && proto.Code >= 500 {
+			return &PermanentError{Err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *SMTPSender) deliver(ctx context.Context, to string, msg []byte) error {
+	host, _, _ := net.SplitHostPort(s.Addr)
+	tlsConfig := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	if s.TLSConfig != nil {
+		tlsConfig = s.TLSConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = host
+		}
+	}
+
+	if _, ok := ctx.Deadline(); !ok && s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if s.TLS == TLSImplicit {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if s.TLS != TLSImplicit && s.TLS != TLSNone {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		} else if s.TLS == TLSStartTLS {
+			return errors.New("smtp: server does not support STARTTLS")
+		}
+	}
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(s.From.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// compose builds the message as a plain text, quoted-printable e-mail with
+// CRLF line endings.
+func (s *SMTPSender) compose(to *mail.Address, m Message, now time.Time) ([]byte, error) {
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	domainPart := s.From.Address[strings.LastIndex(s.From.Address, "@")+1:]
+
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", s.From.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", "<"+hex.EncodeToString(id)+"@"+domainPart+">")
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	body := strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n")
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
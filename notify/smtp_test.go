@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTP is a local stand-in for a mail server. It accepts every message
+// unless told how to reply to RCPT, and keeps what it received in memory.
+type fakeSMTP struct {
+	ln net.Listener
+
+	mu sync.Mutex
+	// rcptReplies are used, one per session, to answer RCPT instead of
+	// accepting the recipient.
+	rcptReplies []string
+	received    []fakeMail
+}
+
+type fakeMail struct {
+	auth, from, to string
+	data           []byte
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeSMTP{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeSMTP) addr() string { return f.ln.Addr().String() }
+
+func (f *fakeSMTP) mails() []fakeMail {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeMail(nil), f.received...)
+}
+
+func (f *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 fake ESMTP ready")
+
+	var m fakeMail
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250-fake greets you")
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			_, resp, _ := strings.Cut(arg, " ")
+			raw, _ := base64.StdEncoding.DecodeString(resp)
+			m.auth = string(raw)
+			tp.PrintfLine("235 2.7.0 authenticated")
+		case "MAIL":
+			m.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			tp.PrintfLine("250 2.1.0 ok")
+		case "RCPT":
+			f.mu.Lock()
+			reply := ""
+			if len(f.rcptReplies) > 0 {
+				reply, f.rcptReplies = f.rcptReplies[0], f.rcptReplies[1:]
+			}
+			f.mu.Unlock()
+			if reply != "" {
+				tp.PrintfLine("%s", reply)
+				continue
+			}
+			m.to = strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			tp.PrintfLine("250 2.1.5 ok")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			m.data, err = io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.received = append(f.received, m)
+			f.mu.Unlock()
+			tp.PrintfLine("250 2.0.0 queued")
+		case "QUIT":
+			tp.PrintfLine("221 2.0.0 bye")
+			return
+		default:
+			tp.PrintfLine("502 5.5.2 not implemented")
+		}
+	}
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	server := newFakeSMTP(t)
+	sender, err := NewSMTPSender(server.addr(), "Loan Service <no-reply@loans.example>", "mailer", "s3cret", TLSNone)
+	require.NoError(t, err)
+
+	err = sender.Send(context.Background(), Message{
+		To:      "Ani <ani@example.com>",
+		Subject: "Pinjaman #7 sudah terdanai – terima kasih",
+		Body:    "Hello Ani,\n\nYour investment of IDR 1,500,000.00 is fully funded.\n",
+	})
+	require.NoError(t, err)
+
+	mails := server.mails()
+	require.Len(t, mails, 1)
+	got := mails[0]
+	assert.Equal(t, "\x00mailer\x00s3cret", got.auth)
+	assert.Equal(t, "no-reply@loans.example", got.from)
+	assert.Equal(t, "ani@example.com", got.to)
+
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(string(got.data))))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Pinjaman #7 sudah terdanai – terima kasih", subject)
+	assert.Equal(t, `"Ani" <ani@example.com>`, msg.Header.Get("To"))
+	assert.Contains(t, msg.Header.Get("Message-Id"), "@loans.example>")
+
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello Ani,\n\nYour investment of IDR 1,500,000.00 is fully funded.\n", string(body))
+}
+
+func TestSMTPSender_Failures(t *testing.T) {
+	server := newFakeSMTP(t)
+	server.rcptReplies = []string{"451 4.3.0 try again later", "550 5.1.1 no such user"}
+	sender, err := NewSMTPSender(server.addr(), "no-reply@loans.example", "", "", TLSOpportunistic)
+	require.NoError(t, err)
+	m := Message{To: "ani@example.com", Subject: "Hi", Body: "Hello"}
+
+	var permanent *PermanentError
+
+	err = sender.Send(context.Background(), m)
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &permanent), "a 4xx reply may succeed later")
+
+	err = sender.Send(context.Background(), m)
+	assert.ErrorAs(t, err, &permanent)
+
+	err = sender.Send(context.Background(), Message{To: "not an address", Subject: "Hi"})
+	assert.ErrorAs(t, err, &permanent)
+
+	// The server does not offer STARTTLS.
+	sender.TLS = TLSStartTLS
+	assert.Error(t, sender.Send(context.Background(), m))
+	assert.Empty(t, server.mails())
+}
+
+func TestNewSMTPSender_Invalid(t *testing.T) {
+	_, err := NewSMTPSender("localhost:25", "not an address", "", "", "")
+	assert.Error(t, err)
+	_, err = NewSMTPSender("localhost", "no-reply@loans.example", "", "", "")
+	assert.Error(t, err)
+	_, err = NewSMTPSender("localhost:25", "no-reply@loans.example", "", "", "ssl")
+	assert.Error(t, err)
+}
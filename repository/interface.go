@@ -2,23 +2,186 @@ package repository
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/ledger"
 )
 
 type LoanRepository interface {
 	CreateLoan(ctx context.Context, loan *domain.Loan) error
 	GetLoanByID(ctx context.Context, id int) (*domain.Loan, error)
+	GetLoanByIDForUpdate(ctx context.Context, id int) (*domain.Loan, error)
+	ListLoans(ctx context.Context, filter domain.LoanFilter) ([]domain.Loan, error)
 	UpdateLoanStatus(ctx context.Context, id int, status domain.LoanStatus) error
 	SetAgreementLink(ctx context.Context, id int, link string) error
+	SetFundingDeadline(ctx context.Context, id int, deadline time.Time) error
+	ListLoanIDsPastFundingDeadline(ctx context.Context, now time.Time, limit int) ([]int, error)
+}
+
+type BorrowerRepository interface {
+	// CreateBorrower fails with domain.ErrBorrowerExists when the identity
+	// number is already registered.
+	CreateBorrower(ctx context.Context, b *domain.Borrower) error
+	GetBorrowerByID(ctx context.Context, id string) (*domain.Borrower, error)
+	ListBorrowers(ctx context.Context, filter domain.BorrowerFilter) ([]domain.Borrower, error)
+	UpdateBorrower(ctx context.Context, b *domain.Borrower) error
+}
+
+type InvestorRepository interface {
+	// CreateInvestor fails with domain.ErrInvestorExists when the email is
+	// already registered.
+	CreateInvestor(ctx context.Context, i *domain.Investor) error
+	GetInvestorByEmail(ctx context.Context, email string) (*domain.Investor, error)
+	GetInvestorByEmailForUpdate(ctx context.Context, email string) (*domain.Investor, error)
+	ListInvestors(ctx context.Context, filter domain.InvestorFilter) ([]domain.Investor, error)
+	UpdateKYC(ctx context.Context, i *domain.Investor) error
+}
+
+type EmployeeRepository interface {
+	// CreateEmployee fails with domain.ErrEmployeeExists when the ID is
+	// already taken.
+	CreateEmployee(ctx context.Context, e *domain.Employee) error
+	GetEmployeeByID(ctx context.Context, id string) (*domain.Employee, error)
+	UpdateEmployee(ctx context.Context, e *domain.Employee) error
 }
 
 type ApprovalRepository interface {
 	CreateApproval(ctx context.Context, a *domain.LoanApproval) error
+	GetApprovalByLoanID(ctx context.Context, loanID int) (*domain.LoanApproval, error)
+}
+
+// AgreementRepository records the agreement letters generated for disbursed
+// loans; the letters themselves are kept in the BlobStore.
+type AgreementRepository interface {
+	CreateAgreement(ctx context.Context, a *domain.Agreement) error
+	ListAgreementsByLoan(ctx context.Context, loanID int) ([]domain.Agreement, error)
+}
+
+// BlobStore keeps files such as picture proofs and agreement letters
+// outside the database.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get fails with domain.ErrBlobNotFound when no file is stored under key.
+	// The caller closes the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, *domain.BlobInfo, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Notifier delivers notifications such as e-mails to investors. Notify may
+// return before the message is delivered.
+type Notifier interface {
+	Notify(ctx context.Context, n domain.Notification) error
+}
+
+// EventPublisher hands domain events to their subscribers. Publish is
+// called inside the transaction making the change, so subscribers that
+// write with ctx take part in it.
+type EventPublisher interface {
+	Publish(ctx context.Context, e domain.Event) error
+}
+
+// OutboxRepository stores the side effects to carry out after a transaction
+// commits. Enqueue joins the transaction in ctx.
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, m *domain.OutboxMessage) error
+	// ClaimNext locks the oldest pending message due at now, skipping
+	// messages other dispatchers hold, and returns nil when there is none.
+	// Messages with the same topic and a non-empty key are claimed in
+	// order: none is claimed while an earlier one is still pending. It must
+	// run in a transaction, which keeps the lock until the message is
+	// marked.
+	ClaimNext(ctx context.Context, now time.Time) (*domain.OutboxMessage, error)
+	MarkDone(ctx context.Context, id int64, attempts int, at time.Time) error
+	MarkRetry(ctx context.Context, id int64, attempts int, next time.Time, lastErr string) error
+	MarkDead(ctx context.Context, id int64, attempts int, lastErr string, at time.Time) error
+}
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, w *domain.Webhook) error
+	GetWebhookByID(ctx context.Context, id int) (*domain.Webhook, error)
+	ListWebhooks(ctx context.Context, filter domain.WebhookFilter) ([]domain.Webhook, error)
+	// ListActiveWebhooksForEvent returns the active webhooks subscribed to
+	// the named event.
+	ListActiveWebhooksForEvent(ctx context.Context, name domain.EventName) ([]domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, w *domain.Webhook) error
+	// DeleteWebhook removes the webhook and its deliveries, and reports
+	// false when there was no such webhook.
+	DeleteWebhook(ctx context.Context, id int) (bool, error)
+}
+
+type WebhookDeliveryRepository interface {
+	CreateDelivery(ctx context.Context, d *domain.WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context, id int) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)
+	// UpdateDelivery records the delivery's status and latest attempt.
+	UpdateDelivery(ctx context.Context, d *domain.WebhookDelivery) error
+}
+
+// WebhookSender sends a delivery to a webhook endpoint. It returns the
+// response status, 0 when there was no response, and an error unless the
+// endpoint answered 2xx.
+type WebhookSender interface {
+	Send(ctx context.Context, w domain.Webhook, d domain.WebhookDelivery) (int, error)
+}
+
+// EventStream publishes events to other systems, such as a Kafka topic.
+// Errors with a Permanent() bool method returning true are not retried.
+type EventStream interface {
+	Stream(ctx context.Context, rec domain.EventRecord) error
+}
+
+type RejectionRepository interface {
+	CreateRejection(ctx context.Context, r *domain.LoanRejection) error
+}
+
+type LoanHistoryRepository interface {
+	RecordStatusChange(ctx context.Context, h *domain.LoanStatusHistory) error
+	GetHistoryByLoan(ctx context.Context, loanID int) ([]domain.LoanStatusHistory, error)
 }
 
 type InvestmentRepository interface {
 	AddInvestment(ctx context.Context, i *domain.Investment) error
-	GetTotalInvested(ctx context.Context, loanID int) (float64, error)
+	GetTotalInvested(ctx context.Context, loanID int) (domain.Money, error)
 	GetInvestorsByLoan(ctx context.Context, loanID int) ([]domain.Investment, error)
+	ListInvestments(ctx context.Context, filter domain.InvestmentFilter) ([]domain.InvestmentDetail, error)
+	MarkRefunded(ctx context.Context, loanID int, at time.Time) error
+}
+
+type ScheduleRepository interface {
+	CreateInstallments(ctx context.Context, installments []domain.Installment) error
+	GetScheduleByLoan(ctx context.Context, loanID int) ([]domain.Installment, error)
+	UpdateInstallmentPayment(ctx context.Context, inst domain.Installment) error
+}
+
+type RepaymentRepository interface {
+	CreateRepayment(ctx context.Context, r *domain.Repayment) error
+}
+
+type LedgerRepository interface {
+	// PostEntry validates and appends a journal entry. It must run inside the
+	// transaction of the operation that moved the money.
+	PostEntry(ctx context.Context, e *ledger.Entry) error
+	GetBalance(ctx context.Context, account ledger.Account) (domain.Money, error)
+}
+
+type WalletRepository interface {
+	GetWallet(ctx context.Context, email string) (*domain.Wallet, error)
+	// GetWalletForUpdate locks the investor's wallet row, creating an empty
+	// wallet in the given currency first if the investor has none.
+	GetWalletForUpdate(ctx context.Context, email string, currency domain.Currency) (*domain.Wallet, error)
+	UpdateWallet(ctx context.Context, w *domain.Wallet) error
+	CreateHold(ctx context.Context, h *domain.WalletHold) error
+	GetActiveHoldsByLoan(ctx context.Context, loanID int) ([]domain.WalletHold, error)
+	UpdateHoldStatus(ctx context.Context, id int, status domain.HoldStatus) error
+}
+
+type IdempotencyRepository interface {
+	// Reserve stores a new in-flight record and reports false when the
+	// caller already used the key.
+	Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (bool, error)
+	GetByKey(ctx context.Context, caller, key string) (*domain.IdempotencyRecord, error)
+	SaveResponse(ctx context.Context, caller, key string, statusCode int, body []byte) error
+	Release(ctx context.Context, caller, key string) error
 }
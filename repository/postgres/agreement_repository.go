@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type AgreementRepo struct {
+	DB *sql.DB
+}
+
+func NewAgreementRepo(db *sql.DB) *AgreementRepo {
+	return &AgreementRepo{DB: db}
+}
+
+func (r *AgreementRepo) CreateAgreement(ctx context.Context, a *domain.Agreement) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO loan_agreements (loan_id, party, investor_email, format, blob_key, sha256, content_type, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+
+	return exec.QueryRowContext(ctx, query,
+		a.LoanID, a.Party, a.InvestorEmail, a.Format, a.Key, a.Checksum, a.ContentType, a.CreatedAt).Scan(&a.ID)
+}
+
+func (r *AgreementRepo) ListAgreementsByLoan(ctx context.Context, loanID int) ([]domain.Agreement, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT id, loan_id, party, investor_email, format, blob_key, sha256, content_type, created_at
+		FROM loan_agreements WHERE loan_id = $1 ORDER BY id`
+
+	rows, err := exec.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	agreements := []domain.Agreement{}
+	for rows.Next() {
+		var a domain.Agreement
+		if err := rows.Scan(&a.ID, &a.LoanID, &a.Party, &a.InvestorEmail, &a.Format, &a.Key, &a.Checksum, &a.ContentType, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		agreements = append(agreements, a)
+	}
+	return agreements, rows.Err()
+}
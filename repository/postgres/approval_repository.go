@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 
 	"github.com/martinusiron/loan-service/domain"
 	"github.com/martinusiron/loan-service/utils"
@@ -18,8 +19,24 @@ func NewApprovalRepo(db *sql.DB) *ApprovalRepo {
 
 func (r *ApprovalRepo) CreateApproval(ctx context.Context, a *domain.LoanApproval) error {
 	exec := utils.GetExecutor(ctx, r.DB)
-	query := `INSERT INTO loan_approvals (loan_id, picture_proof, employee_id, approved_at) VALUES ($1, $2, $3, $4)`
+	query := `INSERT INTO loan_approvals (loan_id, picture_proof_key, picture_proof_sha256, picture_proof_content_type, employee_id, approved_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
 
-	_, err := exec.ExecContext(ctx, query, a.LoanID, a.PictureProof, a.EmployeeID, a.ApprovedAt)
-	return err
+	return exec.QueryRowContext(ctx, query, a.LoanID, a.PictureProofKey, a.PictureProofChecksum, a.PictureProofContentType, a.EmployeeID, a.ApprovedAt).Scan(&a.ID)
+}
+
+func (r *ApprovalRepo) GetApprovalByLoanID(ctx context.Context, loanID int) (*domain.LoanApproval, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT id, loan_id, picture_proof_key, picture_proof_sha256, picture_proof_content_type, employee_id, approved_at
+		FROM loan_approvals WHERE loan_id = $1`
+
+	var a domain.LoanApproval
+	err := exec.QueryRowContext(ctx, query, loanID).Scan(&a.ID, &a.LoanID, &a.PictureProofKey, &a.PictureProofChecksum, &a.PictureProofContentType, &a.EmployeeID, &a.ApprovedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
 }
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type BorrowerRepo struct {
+	DB *sql.DB
+}
+
+func NewBorrowerRepo(db *sql.DB) *BorrowerRepo {
+	return &BorrowerRepo{DB: db}
+}
+
+const borrowerColumns = `id, identity_number, name, phone, COALESCE(email, '') AS email, address, status, created_at, updated_at`
+
+func scanBorrower(row rowScanner) (*domain.Borrower, error) {
+	var b domain.Borrower
+	err := row.Scan(
+		&b.ID,
+		&b.IdentityNumber,
+		&b.Name,
+		&b.Phone,
+		&b.Email,
+		&b.Address,
+		&b.Status,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *BorrowerRepo) CreateBorrower(ctx context.Context, b *domain.Borrower) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO borrowers (identity_number, name, phone, email, address, status)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)
+		ON CONFLICT (identity_number) DO NOTHING
+		RETURNING id, created_at, updated_at`
+
+	err := exec.QueryRowContext(ctx, query, b.IdentityNumber, b.Name, b.Phone, b.Email, b.Address, b.Status).
+		Scan(&b.ID, &b.CreatedAt, &b.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrBorrowerExists
+	}
+	return err
+}
+
+func (r *BorrowerRepo) GetBorrowerByID(ctx context.Context, id string) (*domain.Borrower, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	b, err := scanBorrower(exec.QueryRowContext(ctx, `SELECT `+borrowerColumns+` FROM borrowers WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return b, err
+}
+
+func (r *BorrowerRepo) ListBorrowers(ctx context.Context, f domain.BorrowerFilter) ([]domain.Borrower, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if f.Status != "" {
+		where = append(where, "status = "+arg(f.Status))
+	}
+	if f.AfterID != "" {
+		where = append(where, "id > "+arg(f.AfterID))
+	}
+
+	query := `SELECT ` + borrowerColumns + ` FROM borrowers`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	query += ` ORDER BY id LIMIT ` + arg(f.Limit)
+
+	rows, err := exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	borrowers := []domain.Borrower{}
+	for rows.Next() {
+		b, err := scanBorrower(rows)
+		if err != nil {
+			return nil, err
+		}
+		borrowers = append(borrowers, *b)
+	}
+	return borrowers, rows.Err()
+}
+
+func (r *BorrowerRepo) UpdateBorrower(ctx context.Context, b *domain.Borrower) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `UPDATE borrowers SET name = $1, phone = $2, email = NULLIF($3, ''), address = $4, status = $5, updated_at = NOW()
+		WHERE id = $6 RETURNING updated_at`
+
+	err := exec.QueryRowContext(ctx, query, b.Name, b.Phone, b.Email, b.Address, b.Status, b.ID).Scan(&b.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrBorrowerNotFound
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type EmployeeRepo struct {
+	DB *sql.DB
+}
+
+func NewEmployeeRepo(db *sql.DB) *EmployeeRepo {
+	return &EmployeeRepo{DB: db}
+}
+
+func (r *EmployeeRepo) CreateEmployee(ctx context.Context, e *domain.Employee) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO employees (id, name, role, status) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING created_at, updated_at`
+
+	err := exec.QueryRowContext(ctx, query, e.ID, e.Name, e.Role, e.Status).Scan(&e.CreatedAt, &e.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrEmployeeExists
+	}
+	return err
+}
+
+func (r *EmployeeRepo) GetEmployeeByID(ctx context.Context, id string) (*domain.Employee, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT id, name, role, status, created_at, updated_at FROM employees WHERE id = $1`
+
+	var e domain.Employee
+	err := exec.QueryRowContext(ctx, query, id).Scan(&e.ID, &e.Name, &e.Role, &e.Status, &e.CreatedAt, &e.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *EmployeeRepo) UpdateEmployee(ctx context.Context, e *domain.Employee) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `UPDATE employees SET name = $1, role = $2, status = $3, updated_at = NOW() WHERE id = $4 RETURNING updated_at`
+
+	err := exec.QueryRowContext(ctx, query, e.Name, e.Role, e.Status, e.ID).Scan(&e.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrEmployeeNotFound
+	}
+	return err
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type LoanHistoryRepo struct {
+	DB *sql.DB
+}
+
+func NewLoanHistoryRepo(db *sql.DB) *LoanHistoryRepo {
+	return &LoanHistoryRepo{DB: db}
+}
+
+func (r *LoanHistoryRepo) RecordStatusChange(ctx context.Context, h *domain.LoanStatusHistory) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO loan_status_history (loan_id, from_status, to_status, actor, metadata, changed_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	if h.Metadata == nil {
+		h.Metadata = map[string]any{}
+	}
+	metadata, err := json.Marshal(h.Metadata)
+	if err != nil {
+		return err
+	}
+
+	return exec.QueryRowContext(ctx, query,
+		h.LoanID, h.FromStatus, h.ToStatus, h.Actor, metadata, h.ChangedAt).Scan(&h.ID)
+}
+
+func (r *LoanHistoryRepo) GetHistoryByLoan(ctx context.Context, loanID int) ([]domain.LoanStatusHistory, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT id, loan_id, from_status, to_status, actor, metadata, changed_at FROM loan_status_history WHERE loan_id = $1 ORDER BY changed_at, id`
+
+	rows, err := exec.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []domain.LoanStatusHistory{}
+	for rows.Next() {
+		var h domain.LoanStatusHistory
+		var metadata []byte
+		if err := rows.Scan(&h.ID, &h.LoanID, &h.FromStatus, &h.ToStatus, &h.Actor, &metadata, &h.ChangedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(metadata, &h.Metadata); err != nil {
+			return nil, err
+		}
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type IdempotencyRepo struct {
+	DB *sql.DB
+}
+
+func NewIdempotencyRepo(db *sql.DB) *IdempotencyRepo {
+	return &IdempotencyRepo{DB: db}
+}
+
+func (r *IdempotencyRepo) Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (bool, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO idempotency_keys (caller, key, request_method, request_path, request_hash) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (caller, key) DO NOTHING`
+
+	res, err := exec.ExecContext(ctx, query, rec.Caller, rec.Key, rec.Method, rec.Path, rec.RequestHash)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (r *IdempotencyRepo) GetByKey(ctx context.Context, caller, key string) (*domain.IdempotencyRecord, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT caller, key, request_method, request_path, request_hash, COALESCE(status_code, 0), response_body, completed_at IS NOT NULL, created_at FROM idempotency_keys WHERE caller = $1 AND key = $2`
+
+	var rec domain.IdempotencyRecord
+	err := exec.QueryRowContext(ctx, query, caller, key).Scan(
+		&rec.Caller,
+		&rec.Key,
+		&rec.Method,
+		&rec.Path,
+		&rec.RequestHash,
+		&rec.StatusCode,
+		&rec.ResponseBody,
+		&rec.Completed,
+		&rec.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (r *IdempotencyRepo) SaveResponse(ctx context.Context, caller, key string, statusCode int, body []byte) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	_, err := exec.ExecContext(ctx, `UPDATE idempotency_keys SET status_code = $1, response_body = $2, completed_at = NOW() WHERE caller = $3 AND key = $4`, statusCode, body, caller, key)
+	return err
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, caller, key string) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	_, err := exec.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE caller = $1 AND key = $2 AND completed_at IS NULL`, caller, key)
+	return err
+}
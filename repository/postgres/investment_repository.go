@@ -3,6 +3,9 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/martinusiron/loan-service/domain"
 	"github.com/martinusiron/loan-service/utils"
@@ -24,18 +27,18 @@ func (r *InvestmentRepo) AddInvestment(ctx context.Context, i *domain.Investment
 	return err
 }
 
-func (r *InvestmentRepo) GetTotalInvested(ctx context.Context, loanID int) (float64, error) {
+func (r *InvestmentRepo) GetTotalInvested(ctx context.Context, loanID int) (domain.Money, error) {
 	exec := utils.GetExecutor(ctx, r.DB)
-	query := `SELECT COALESCE(SUM(amount), 0) FROM investments WHERE loan_id = $1`
+	query := `SELECT COALESCE(SUM(i.amount), 0), l.currency FROM loans l LEFT JOIN investments i ON i.loan_id = l.id WHERE l.id = $1 GROUP BY l.currency`
 
-	var total float64
-	err := exec.QueryRowContext(ctx, query, loanID).Scan(&total)
+	var total domain.Money
+	err := exec.QueryRowContext(ctx, query, loanID).Scan(&total, &total.Currency)
 	return total, err
 }
 
 func (r *InvestmentRepo) GetInvestorsByLoan(ctx context.Context, loanID int) ([]domain.Investment, error) {
 	exec := utils.GetExecutor(ctx, r.DB)
-	query := `SELECT id, loan_id, investor_email, amount, invested_at FROM investments WHERE loan_id = $1`
+	query := `SELECT i.id, i.loan_id, i.investor_email, i.amount, l.currency, i.invested_at, i.refunded_at FROM investments i JOIN loans l ON l.id = i.loan_id WHERE i.loan_id = $1 ORDER BY i.id`
 
 	rows, err := exec.QueryContext(ctx, query, loanID)
 	if err != nil {
@@ -45,11 +48,88 @@ func (r *InvestmentRepo) GetInvestorsByLoan(ctx context.Context, loanID int) ([]
 
 	var investors []domain.Investment
 	for rows.Next() {
-		var i domain.Investment
-		if err := rows.Scan(&i.ID, &i.LoanID, &i.InvestorEmail, &i.Amount, &i.InvestedAt); err != nil {
+		var (
+			i        domain.Investment
+			refunded sql.NullTime
+		)
+		if err := rows.Scan(&i.ID, &i.LoanID, &i.InvestorEmail, &i.Amount, &i.Amount.Currency, &i.InvestedAt, &refunded); err != nil {
 			return nil, err
 		}
+		if refunded.Valid {
+			i.RefundedAt = &refunded.Time
+		}
 		investors = append(investors, i)
 	}
-	return investors, nil
+	return investors, rows.Err()
+}
+
+func (r *InvestmentRepo) ListInvestments(ctx context.Context, f domain.InvestmentFilter) ([]domain.InvestmentDetail, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if f.LoanID != 0 {
+		where = append(where, "i.loan_id = "+arg(f.LoanID))
+	}
+	if f.InvestorEmail != "" {
+		where = append(where, "i.investor_email = "+arg(f.InvestorEmail))
+	}
+	if f.AfterID != 0 {
+		where = append(where, "i.id > "+arg(f.AfterID))
+	}
+
+	query := `SELECT i.id, i.loan_id, i.investor_email, i.amount, l.currency, i.invested_at, i.refunded_at, l.status, l.principal_amount, l.currency, l.roi FROM investments i JOIN loans l ON l.id = i.loan_id`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	query += ` ORDER BY i.id LIMIT ` + arg(f.Limit)
+
+	rows, err := exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	details := []domain.InvestmentDetail{}
+	for rows.Next() {
+		var (
+			d        domain.InvestmentDetail
+			refunded sql.NullTime
+		)
+		if err := rows.Scan(
+			&d.ID,
+			&d.LoanID,
+			&d.InvestorEmail,
+			&d.Amount,
+			&d.Amount.Currency,
+			&d.InvestedAt,
+			&refunded,
+			&d.LoanStatus,
+			&d.PrincipalAmount,
+			&d.PrincipalAmount.Currency,
+			&d.ROI,
+		); err != nil {
+			return nil, err
+		}
+		if refunded.Valid {
+			d.RefundedAt = &refunded.Time
+		}
+		details = append(details, d)
+	}
+	return details, rows.Err()
+}
+
+// MarkRefunded stamps every not yet refunded investment in the loan as paid
+// back to its investor.
+func (r *InvestmentRepo) MarkRefunded(ctx context.Context, loanID int, at time.Time) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	_, err := exec.ExecContext(ctx, `UPDATE investments SET refunded_at = $1 WHERE loan_id = $2 AND refunded_at IS NULL`, at, loanID)
+	return err
 }
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type InvestorRepo struct {
+	DB *sql.DB
+}
+
+func NewInvestorRepo(db *sql.DB) *InvestorRepo {
+	return &InvestorRepo{DB: db}
+}
+
+const investorColumns = `email, name, phone, identity_number, kyc_status, COALESCE(kyc_note, '') AS kyc_note,
+	COALESCE(kyc_reviewed_by, '') AS kyc_reviewed_by, kyc_reviewed_at, created_at, updated_at`
+
+func scanInvestor(row rowScanner) (*domain.Investor, error) {
+	var (
+		i          domain.Investor
+		reviewedAt sql.NullTime
+	)
+	err := row.Scan(
+		&i.Email,
+		&i.Name,
+		&i.Phone,
+		&i.IdentityNumber,
+		&i.KYCStatus,
+		&i.KYCNote,
+		&i.KYCReviewedBy,
+		&reviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if reviewedAt.Valid {
+		i.KYCReviewedAt = &reviewedAt.Time
+	}
+	return &i, nil
+}
+
+func (r *InvestorRepo) CreateInvestor(ctx context.Context, i *domain.Investor) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO investors (email, name, phone, identity_number, kyc_status)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (email) DO NOTHING
+		RETURNING created_at, updated_at`
+
+	err := exec.QueryRowContext(ctx, query, i.Email, i.Name, i.Phone, i.IdentityNumber, i.KYCStatus).
+		Scan(&i.CreatedAt, &i.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrInvestorExists
+	}
+	return err
+}
+
+func (r *InvestorRepo) GetInvestorByEmail(ctx context.Context, email string) (*domain.Investor, error) {
+	return r.getInvestor(ctx, `SELECT `+investorColumns+` FROM investors WHERE email = $1`, email)
+}
+
+// GetInvestorByEmailForUpdate reads an investor and locks the row until the
+// surrounding transaction ends, so concurrent KYC reviews are serialised.
+func (r *InvestorRepo) GetInvestorByEmailForUpdate(ctx context.Context, email string) (*domain.Investor, error) {
+	return r.getInvestor(ctx, `SELECT `+investorColumns+` FROM investors WHERE email = $1 FOR UPDATE`, email)
+}
+
+func (r *InvestorRepo) getInvestor(ctx context.Context, query, email string) (*domain.Investor, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	i, err := scanInvestor(exec.QueryRowContext(ctx, query, email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return i, err
+}
+
+func (r *InvestorRepo) ListInvestors(ctx context.Context, f domain.InvestorFilter) ([]domain.Investor, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if f.KYCStatus != "" {
+		where = append(where, "kyc_status = "+arg(f.KYCStatus))
+	}
+	if f.AfterKey != "" {
+		where = append(where, "email > "+arg(f.AfterKey))
+	}
+
+	query := `SELECT ` + investorColumns + ` FROM investors`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	query += ` ORDER BY email LIMIT ` + arg(f.Limit)
+
+	rows, err := exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	investors := []domain.Investor{}
+	for rows.Next() {
+		i, err := scanInvestor(rows)
+		if err != nil {
+			return nil, err
+		}
+		investors = append(investors, *i)
+	}
+	return investors, rows.Err()
+}
+
+func (r *InvestorRepo) UpdateKYC(ctx context.Context, i *domain.Investor) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `UPDATE investors SET kyc_status = $1, kyc_note = NULLIF($2, ''), kyc_reviewed_by = NULLIF($3, ''), kyc_reviewed_at = $4, updated_at = NOW()
+		WHERE email = $5 RETURNING updated_at`
+
+	return exec.QueryRowContext(ctx, query, i.KYCStatus, i.KYCNote, i.KYCReviewedBy, i.KYCReviewedAt, i.Email).Scan(&i.UpdatedAt)
+}
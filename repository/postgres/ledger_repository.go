@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/ledger"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type LedgerRepo struct {
+	DB *sql.DB
+}
+
+func NewLedgerRepo(db *sql.DB) *LedgerRepo {
+	return &LedgerRepo{DB: db}
+}
+
+func (r *LedgerRepo) PostEntry(ctx context.Context, e *ledger.Entry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	exec := utils.GetExecutor(ctx, r.DB)
+	entryQuery := `INSERT INTO ledger_entries (kind, loan_id, reference, posted_at) VALUES ($1, NULLIF($2, 0), $3, $4) RETURNING id`
+	if err := exec.QueryRowContext(ctx, entryQuery, e.Kind, e.LoanID, e.Reference, e.PostedAt).Scan(&e.ID); err != nil {
+		return err
+	}
+
+	// The no-op update makes RETURNING yield the id of an existing account.
+	accountQuery := `INSERT INTO ledger_accounts (code, type, currency) VALUES ($1, $2, $3)
+		ON CONFLICT (code) DO UPDATE SET code = EXCLUDED.code RETURNING id`
+	lineQuery := `INSERT INTO ledger_lines (entry_id, account_id, direction, amount) VALUES ($1, $2, $3, $4) RETURNING id`
+	for i := range e.Lines {
+		l := &e.Lines[i]
+
+		var accountID int
+		if err := exec.QueryRowContext(ctx, accountQuery, l.Account.Code(), l.Account.Type, l.Amount.Currency).Scan(&accountID); err != nil {
+			return err
+		}
+		if err := exec.QueryRowContext(ctx, lineQuery, e.ID, accountID, l.Direction, l.Amount).Scan(&l.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBalance returns the account balance on its normal side: debits minus
+// credits for assets, credits minus debits for everything else. Accounts
+// that have never been posted to have a zero balance.
+func (r *LedgerRepo) GetBalance(ctx context.Context, account ledger.Account) (domain.Money, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT
+			COALESCE(SUM(CASE WHEN ll.direction = 'debit' THEN ll.amount ELSE -ll.amount END), 0),
+			a.currency
+		FROM ledger_accounts a
+		LEFT JOIN ledger_lines ll ON ll.account_id = a.id
+		WHERE a.code = $1
+		GROUP BY a.currency`
+
+	balance := domain.Zero(domain.DefaultCurrency)
+	err := exec.QueryRowContext(ctx, query, account.Code()).Scan(&balance, &balance.Currency)
+	if err == sql.ErrNoRows {
+		return domain.Zero(domain.DefaultCurrency), nil
+	}
+	if err != nil {
+		return domain.Money{}, err
+	}
+	if !account.Type.DebitNormal() {
+		balance.Amount = -balance.Amount
+	}
+	return balance, nil
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/martinusiron/loan-service/domain"
 	"github.com/martinusiron/loan-service/utils"
@@ -20,28 +22,60 @@ func NewLoanRepo(db *sql.DB) *LoanRepo {
 
 func (r *LoanRepo) CreateLoan(ctx context.Context, loan *domain.Loan) error {
 	exec := utils.GetExecutor(ctx, r.DB)
-	query := `INSERT INTO loans (borrower_id, principal_amount, rate, roi) VALUES ($1, $2, $3, $4) RETURNING id`
+	query := `INSERT INTO loans (borrower_id, principal_amount, currency, rate, roi, tenor_months, repayment_method) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
 	return exec.QueryRowContext(ctx, query,
-		loan.BorrowerID, loan.PrincipalAmount, loan.Rate, loan.ROI).Scan(&loan.ID)
+		loan.BorrowerID, loan.PrincipalAmount, loan.PrincipalAmount.Currency, loan.Rate, loan.ROI, loan.TenorMonths, loan.RepaymentMethod).Scan(&loan.ID)
 }
 
-func (r *LoanRepo) GetLoanByID(ctx context.Context, id int) (*domain.Loan, error) {
-	exec := utils.GetExecutor(ctx, r.DB)
-	query := `SELECT id, borrower_id, principal_amount, rate, roi, status, COALESCE(agreement_letter_link, '') AS agreement_letter_link, created_at, updated_at FROM loans WHERE id = $1`
-	row := exec.QueryRowContext(ctx, query, id)
+const loanColumns = `id, borrower_id, principal_amount, currency, rate, roi, tenor_months, repayment_method, status, COALESCE(agreement_letter_link, '') AS agreement_letter_link, funding_deadline, created_at, updated_at`
 
-	var l domain.Loan
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanLoan(row rowScanner) (*domain.Loan, error) {
+	var (
+		l        domain.Loan
+		deadline sql.NullTime
+	)
 	err := row.Scan(
 		&l.ID,
 		&l.BorrowerID,
 		&l.PrincipalAmount,
+		&l.PrincipalAmount.Currency,
 		&l.Rate,
 		&l.ROI,
+		&l.TenorMonths,
+		&l.RepaymentMethod,
 		&l.Status,
 		&l.AgreementLetterLink,
+		&deadline,
 		&l.CreatedAt,
 		&l.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if deadline.Valid {
+		l.FundingDeadline = &deadline.Time
+	}
+	return &l, nil
+}
+
+func (r *LoanRepo) GetLoanByID(ctx context.Context, id int) (*domain.Loan, error) {
+	return r.getLoan(ctx, `SELECT `+loanColumns+` FROM loans WHERE id = $1`, id)
+}
+
+// GetLoanByIDForUpdate reads a loan and locks its row until the surrounding
+// transaction ends, so concurrent writers to the same loan are serialised.
+// It must be called with a context from utils.WithTransaction.
+func (r *LoanRepo) GetLoanByIDForUpdate(ctx context.Context, id int) (*domain.Loan, error) {
+	return r.getLoan(ctx, `SELECT `+loanColumns+` FROM loans WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *LoanRepo) getLoan(ctx context.Context, query string, id int) (*domain.Loan, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	l, err := scanLoan(exec.QueryRowContext(ctx, query, id))
 	if err != nil {
 		fmt.Println("repo ", err)
 		if errors.Is(err, sql.ErrNoRows) {
@@ -49,7 +83,114 @@ func (r *LoanRepo) GetLoanByID(ctx context.Context, id int) (*domain.Loan, error
 		}
 		return nil, err
 	}
-	return &l, nil
+	return l, nil
+}
+
+// loanSortColumns whitelists the columns a listing may be ordered by.
+var loanSortColumns = map[domain.LoanSortField]string{
+	domain.SortByCreatedAt:       "created_at",
+	domain.SortByPrincipalAmount: "principal_amount",
+	domain.SortByRate:            "rate",
+	domain.SortByID:              "id",
+}
+
+// ListLoans returns up to f.Limit loans matching the filter, ordered by the
+// requested column with id as tie-breaker, starting after f.After.
+func (r *LoanRepo) ListLoans(ctx context.Context, f domain.LoanFilter) ([]domain.Loan, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(f.Statuses) > 0 {
+		placeholders := make([]string, len(f.Statuses))
+		for i, st := range f.Statuses {
+			placeholders[i] = arg(st)
+		}
+		where = append(where, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if f.BorrowerID != "" {
+		where = append(where, "borrower_id = "+arg(f.BorrowerID))
+	}
+	if f.InvestorEmail != "" {
+		where = append(where, "(status = "+arg(domain.StatusApproved)+
+			" OR id IN (SELECT loan_id FROM investments WHERE lower(investor_email) = lower("+arg(f.InvestorEmail)+")))")
+	}
+	if f.MinPrincipal != nil {
+		where = append(where, "principal_amount >= "+arg(*f.MinPrincipal))
+	}
+	if f.MaxPrincipal != nil {
+		where = append(where, "principal_amount <= "+arg(*f.MaxPrincipal))
+	}
+	if f.MinRate != nil {
+		where = append(where, "rate >= "+arg(*f.MinRate))
+	}
+	if f.MaxRate != nil {
+		where = append(where, "rate <= "+arg(*f.MaxRate))
+	}
+	if f.CreatedFrom != nil {
+		where = append(where, "created_at >= "+arg(*f.CreatedFrom))
+	}
+	if f.CreatedTo != nil {
+		where = append(where, "created_at < "+arg(*f.CreatedTo))
+	}
+
+	column, ok := loanSortColumns[f.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort field %q", domain.ErrInvalidFilter, f.SortBy)
+	}
+	direction, cmp := "ASC", ">"
+	if f.SortDesc {
+		direction, cmp = "DESC", "<"
+	}
+
+	if f.After != nil {
+		if f.SortBy == domain.SortByID {
+			where = append(where, "id "+cmp+" "+arg(f.After.ID))
+		} else {
+			var value any = f.After.Value
+			if f.SortBy == domain.SortByCreatedAt {
+				t, err := time.Parse(time.RFC3339Nano, f.After.Value)
+				if err != nil {
+					return nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidFilter)
+				}
+				value = t
+			}
+			where = append(where, fmt.Sprintf("(%s, id) %s (%s, %s)", column, cmp, arg(value), arg(f.After.ID)))
+		}
+	}
+
+	query := `SELECT ` + loanColumns + ` FROM loans`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s", column, direction)
+	if f.SortBy != domain.SortByID {
+		query += ", id " + direction
+	}
+	query += " LIMIT " + arg(f.Limit)
+
+	rows, err := exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	loans := []domain.Loan{}
+	for rows.Next() {
+		l, err := scanLoan(rows)
+		if err != nil {
+			return nil, err
+		}
+		loans = append(loans, *l)
+	}
+	return loans, rows.Err()
 }
 
 func (r *LoanRepo) UpdateLoanStatus(ctx context.Context, id int, status domain.LoanStatus) error {
@@ -63,3 +204,31 @@ func (r *LoanRepo) SetAgreementLink(ctx context.Context, id int, link string) er
 	_, err := exec.ExecContext(ctx, `UPDATE loans SET agreement_letter_link = $1, updated_at = NOW() WHERE id = $2`, link, id)
 	return err
 }
+
+func (r *LoanRepo) SetFundingDeadline(ctx context.Context, id int, deadline time.Time) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	_, err := exec.ExecContext(ctx, `UPDATE loans SET funding_deadline = $1, updated_at = NOW() WHERE id = $2`, deadline, id)
+	return err
+}
+
+// ListLoanIDsPastFundingDeadline returns up to limit approved loans whose
+// funding deadline is at or before now, oldest deadline first.
+func (r *LoanRepo) ListLoanIDsPastFundingDeadline(ctx context.Context, now time.Time, limit int) ([]int, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	rows, err := exec.QueryContext(ctx, `SELECT id FROM loans WHERE status = $1 AND funding_deadline <= $2 ORDER BY funding_deadline, id LIMIT $3`,
+		domain.StatusApproved, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type OutboxRepo struct {
+	DB *sql.DB
+}
+
+func NewOutboxRepo(db *sql.DB) *OutboxRepo {
+	return &OutboxRepo{DB: db}
+}
+
+func (r *OutboxRepo) Enqueue(ctx context.Context, m *domain.OutboxMessage) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO outbox_messages (topic, key, payload, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	return exec.QueryRowContext(ctx, query,
+		m.Topic, m.Key, []byte(m.Payload), m.Status, m.Attempts, m.NextAttemptAt, m.CreatedAt).Scan(&m.ID)
+}
+
+func (r *OutboxRepo) ClaimNext(ctx context.Context, now time.Time) (*domain.OutboxMessage, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT id, topic, key, payload, status, attempts, next_attempt_at, last_error, created_at, processed_at
+		FROM outbox_messages m
+		WHERE status = 'pending' AND next_attempt_at <= $1
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox_messages earlier
+				WHERE earlier.topic = m.topic AND earlier.key = m.key AND m.key <> ''
+					AND earlier.status = 'pending' AND earlier.id < m.id
+			)
+		ORDER BY next_attempt_at, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+
+	var (
+		m       domain.OutboxMessage
+		payload []byte
+	)
+	err := exec.QueryRowContext(ctx, query, now).Scan(&m.ID, &m.Topic, &m.Key, &payload, &m.Status,
+		&m.Attempts, &m.NextAttemptAt, &m.LastError, &m.CreatedAt, &m.ProcessedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.Payload = payload
+	return &m, nil
+}
+
+func (r *OutboxRepo) MarkDone(ctx context.Context, id int64, attempts int, at time.Time) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	_, err := exec.ExecContext(ctx, `UPDATE outbox_messages SET status = 'done', attempts = $2, processed_at = $3 WHERE id = $1`,
+		id, attempts, at)
+	return err
+}
+
+func (r *OutboxRepo) MarkRetry(ctx context.Context, id int64, attempts int, next time.Time, lastErr string) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	_, err := exec.ExecContext(ctx, `UPDATE outbox_messages SET attempts = $2, next_attempt_at = $3, last_error = $4 WHERE id = $1`,
+		id, attempts, next, lastErr)
+	return err
+}
+
+func (r *OutboxRepo) MarkDead(ctx context.Context, id int64, attempts int, lastErr string, at time.Time) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	_, err := exec.ExecContext(ctx, `UPDATE outbox_messages SET status = 'dead', attempts = $2, last_error = $3, processed_at = $4 WHERE id = $1`,
+		id, attempts, lastErr, at)
+	return err
+}
@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type RejectionRepo struct {
+	DB *sql.DB
+}
+
+func NewRejectionRepo(db *sql.DB) *RejectionRepo {
+	return &RejectionRepo{DB: db}
+}
+
+func (r *RejectionRepo) CreateRejection(ctx context.Context, rj *domain.LoanRejection) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO loan_rejections (loan_id, employee_id, reason_code, note, rejected_at) VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := exec.ExecContext(ctx, query, rj.LoanID, rj.EmployeeID, rj.ReasonCode, rj.Note, rj.RejectedAt)
+	return err
+}
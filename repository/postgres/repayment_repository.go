@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type RepaymentRepo struct {
+	DB *sql.DB
+}
+
+func NewRepaymentRepo(db *sql.DB) *RepaymentRepo {
+	return &RepaymentRepo{DB: db}
+}
+
+func (r *RepaymentRepo) CreateRepayment(ctx context.Context, rp *domain.Repayment) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO repayments (loan_id, amount, principal_amount, interest_amount, fee_amount, excess_amount, platform_margin, reference, paid_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, created_at`
+
+	if err := exec.QueryRowContext(ctx, query,
+		rp.LoanID, rp.Amount, rp.Principal, rp.Interest, rp.Fee, rp.Excess, rp.PlatformMargin, rp.Reference, rp.PaidAt).Scan(&rp.ID, &rp.CreatedAt); err != nil {
+		return err
+	}
+
+	allocQuery := `INSERT INTO repayment_allocations (repayment_id, installment_id, component, amount) VALUES ($1, $2, $3, $4) RETURNING id`
+	for i := range rp.Allocations {
+		a := &rp.Allocations[i]
+		a.RepaymentID = rp.ID
+		if err := exec.QueryRowContext(ctx, allocQuery, a.RepaymentID, a.InstallmentID, a.Component, a.Amount).Scan(&a.ID); err != nil {
+			return err
+		}
+	}
+
+	payoutQuery := `INSERT INTO repayment_payouts (repayment_id, investment_id, loan_id, investor_email, principal_amount, return_amount) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`
+	for i := range rp.Payouts {
+		p := &rp.Payouts[i]
+		p.RepaymentID = rp.ID
+		if err := exec.QueryRowContext(ctx, payoutQuery, p.RepaymentID, p.InvestmentID, p.LoanID, p.InvestorEmail, p.Principal, p.Return).Scan(&p.ID, &p.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
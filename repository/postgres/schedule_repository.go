@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type ScheduleRepo struct {
+	DB *sql.DB
+}
+
+func NewScheduleRepo(db *sql.DB) *ScheduleRepo {
+	return &ScheduleRepo{DB: db}
+}
+
+func (r *ScheduleRepo) CreateInstallments(ctx context.Context, installments []domain.Installment) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO repayment_schedules (loan_id, installment_number, due_date, principal_due, interest_due, fee_due, status) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	for i := range installments {
+		inst := &installments[i]
+		if err := exec.QueryRowContext(ctx, query,
+			inst.LoanID, inst.Number, inst.DueDate, inst.PrincipalDue, inst.InterestDue, inst.FeeDue, inst.Status).Scan(&inst.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const installmentColumns = `s.id, s.loan_id, s.installment_number, s.due_date, s.principal_due, s.interest_due, s.fee_due, s.principal_paid, s.interest_paid, s.fee_paid, s.status, l.currency`
+
+func scanInstallment(row rowScanner) (domain.Installment, error) {
+	var inst domain.Installment
+	var currency domain.Currency
+	err := row.Scan(
+		&inst.ID,
+		&inst.LoanID,
+		&inst.Number,
+		&inst.DueDate,
+		&inst.PrincipalDue,
+		&inst.InterestDue,
+		&inst.FeeDue,
+		&inst.PrincipalPaid,
+		&inst.InterestPaid,
+		&inst.FeePaid,
+		&inst.Status,
+		&currency,
+	)
+	for _, m := range []*domain.Money{&inst.PrincipalDue, &inst.InterestDue, &inst.FeeDue, &inst.PrincipalPaid, &inst.InterestPaid, &inst.FeePaid} {
+		m.Currency = currency
+	}
+	return inst, err
+}
+
+func (r *ScheduleRepo) GetScheduleByLoan(ctx context.Context, loanID int) ([]domain.Installment, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT ` + installmentColumns + ` FROM repayment_schedules s JOIN loans l ON l.id = s.loan_id WHERE s.loan_id = $1 ORDER BY s.installment_number`
+
+	rows, err := exec.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	installments := []domain.Installment{}
+	for rows.Next() {
+		inst, err := scanInstallment(rows)
+		if err != nil {
+			return nil, err
+		}
+		installments = append(installments, inst)
+	}
+	return installments, rows.Err()
+}
+
+func (r *ScheduleRepo) UpdateInstallmentPayment(ctx context.Context, inst domain.Installment) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `UPDATE repayment_schedules SET principal_paid = $1, interest_paid = $2, fee_paid = $3, status = $4 WHERE id = $5`
+
+	_, err := exec.ExecContext(ctx, query, inst.PrincipalPaid, inst.InterestPaid, inst.FeePaid, inst.Status, inst.ID)
+	return err
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type WalletRepo struct {
+	DB *sql.DB
+}
+
+func NewWalletRepo(db *sql.DB) *WalletRepo {
+	return &WalletRepo{DB: db}
+}
+
+const walletColumns = `investor_email, balance, held, currency, updated_at`
+
+func scanWallet(row rowScanner) (*domain.Wallet, error) {
+	var w domain.Wallet
+	var currency domain.Currency
+	if err := row.Scan(&w.InvestorEmail, &w.Balance, &w.Held, &currency, &w.UpdatedAt); err != nil {
+		return nil, err
+	}
+	w.Balance.Currency = currency
+	w.Held.Currency = currency
+	return &w, nil
+}
+
+func (r *WalletRepo) GetWallet(ctx context.Context, email string) (*domain.Wallet, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT ` + walletColumns + ` FROM investor_wallets WHERE investor_email = $1`
+
+	w, err := scanWallet(exec.QueryRowContext(ctx, query, email))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return w, err
+}
+
+func (r *WalletRepo) GetWalletForUpdate(ctx context.Context, email string, currency domain.Currency) (*domain.Wallet, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	insert := `INSERT INTO investor_wallets (investor_email, currency) VALUES ($1, $2) ON CONFLICT (investor_email) DO NOTHING`
+	if _, err := exec.ExecContext(ctx, insert, email, currency); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + walletColumns + ` FROM investor_wallets WHERE investor_email = $1 FOR UPDATE`
+	return scanWallet(exec.QueryRowContext(ctx, query, email))
+}
+
+func (r *WalletRepo) UpdateWallet(ctx context.Context, w *domain.Wallet) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `UPDATE investor_wallets SET balance = $1, held = $2, updated_at = NOW() WHERE investor_email = $3 RETURNING updated_at`
+
+	return exec.QueryRowContext(ctx, query, w.Balance, w.Held, w.InvestorEmail).Scan(&w.UpdatedAt)
+}
+
+func (r *WalletRepo) CreateHold(ctx context.Context, h *domain.WalletHold) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO wallet_holds (investor_email, loan_id, amount, status) VALUES ($1, $2, $3, $4) RETURNING id, created_at, updated_at`
+
+	return exec.QueryRowContext(ctx, query, h.InvestorEmail, h.LoanID, h.Amount, h.Status).Scan(&h.ID, &h.CreatedAt, &h.UpdatedAt)
+}
+
+// GetActiveHoldsByLoan returns the loan's active holds ordered by investor, so
+// callers that lock the wallets behind them always lock in the same order.
+func (r *WalletRepo) GetActiveHoldsByLoan(ctx context.Context, loanID int) ([]domain.WalletHold, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT h.id, h.investor_email, h.loan_id, h.amount, w.currency, h.status, h.created_at, h.updated_at
+		FROM wallet_holds h JOIN investor_wallets w ON w.investor_email = h.investor_email
+		WHERE h.loan_id = $1 AND h.status = $2
+		ORDER BY h.investor_email, h.id`
+
+	rows, err := exec.QueryContext(ctx, query, loanID, domain.HoldActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []domain.WalletHold
+	for rows.Next() {
+		var h domain.WalletHold
+		if err := rows.Scan(&h.ID, &h.InvestorEmail, &h.LoanID, &h.Amount, &h.Amount.Currency, &h.Status, &h.CreatedAt, &h.UpdatedAt); err != nil {
+			return nil, err
+		}
+		holds = append(holds, h)
+	}
+	return holds, rows.Err()
+}
+
+func (r *WalletRepo) UpdateHoldStatus(ctx context.Context, id int, status domain.HoldStatus) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `UPDATE wallet_holds SET status = $1, updated_at = NOW() WHERE id = $2`
+
+	_, err := exec.ExecContext(ctx, query, status, id)
+	return err
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type WebhookDeliveryRepo struct {
+	DB *sql.DB
+}
+
+func NewWebhookDeliveryRepo(db *sql.DB) *WebhookDeliveryRepo {
+	return &WebhookDeliveryRepo{DB: db}
+}
+
+const webhookDeliveryColumns = `id, webhook_id, event_name, loan_id, payload, status, attempts, response_status, last_error,
+	created_at, last_attempt_at, delivered_at`
+
+func scanWebhookDelivery(row rowScanner) (*domain.WebhookDelivery, error) {
+	var (
+		d       domain.WebhookDelivery
+		payload []byte
+	)
+	err := row.Scan(
+		&d.ID,
+		&d.WebhookID,
+		&d.EventName,
+		&d.LoanID,
+		&payload,
+		&d.Status,
+		&d.Attempts,
+		&d.ResponseStatus,
+		&d.LastError,
+		&d.CreatedAt,
+		&d.LastAttemptAt,
+		&d.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	return &d, nil
+}
+
+func (r *WebhookDeliveryRepo) CreateDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO webhook_deliveries (webhook_id, event_name, loan_id, payload, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	return exec.QueryRowContext(ctx, query, d.WebhookID, d.EventName, d.LoanID, []byte(d.Payload), d.Status, d.CreatedAt).
+		Scan(&d.ID)
+}
+
+func (r *WebhookDeliveryRepo) GetDeliveryByID(ctx context.Context, id int) (*domain.WebhookDelivery, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	d, err := scanWebhookDelivery(exec.QueryRowContext(ctx, `SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return d, err
+}
+
+func (r *WebhookDeliveryRepo) ListDeliveries(ctx context.Context, f domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where := []string{"webhook_id = " + arg(f.WebhookID)}
+	if f.Status != "" {
+		where = append(where, "status = "+arg(f.Status))
+	}
+	if f.BeforeID > 0 {
+		where = append(where, "id < "+arg(f.BeforeID))
+	}
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE ` + strings.Join(where, " AND ") +
+		` ORDER BY id DESC LIMIT ` + arg(f.Limit)
+
+	rows, err := exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookDeliveryRepo) UpdateDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `UPDATE webhook_deliveries SET status = $2, attempts = $3, response_status = $4, last_error = $5,
+		last_attempt_at = $6, delivered_at = $7 WHERE id = $1`
+
+	_, err := exec.ExecContext(ctx, query, d.ID, d.Status, d.Attempts, d.ResponseStatus, d.LastError, d.LastAttemptAt, d.DeliveredAt)
+	return err
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type WebhookRepo struct {
+	DB *sql.DB
+}
+
+func NewWebhookRepo(db *sql.DB) *WebhookRepo {
+	return &WebhookRepo{DB: db}
+}
+
+const webhookColumns = `id, url, event_types, secret, status, created_at, updated_at`
+
+func scanWebhook(row rowScanner) (*domain.Webhook, error) {
+	var (
+		w     domain.Webhook
+		types pq.StringArray
+	)
+	err := row.Scan(&w.ID, &w.URL, &types, &w.Secret, &w.Status, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	w.EventTypes = make([]domain.EventName, len(types))
+	for i, t := range types {
+		w.EventTypes[i] = domain.EventName(t)
+	}
+	return &w, nil
+}
+
+func eventTypesArray(names []domain.EventName) pq.StringArray {
+	types := make(pq.StringArray, len(names))
+	for i, n := range names {
+		types[i] = string(n)
+	}
+	return types
+}
+
+func (r *WebhookRepo) CreateWebhook(ctx context.Context, w *domain.Webhook) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `INSERT INTO webhooks (url, event_types, secret, status) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at`
+
+	return exec.QueryRowContext(ctx, query, w.URL, eventTypesArray(w.EventTypes), w.Secret, w.Status).
+		Scan(&w.ID, &w.CreatedAt, &w.UpdatedAt)
+}
+
+func (r *WebhookRepo) GetWebhookByID(ctx context.Context, id int) (*domain.Webhook, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	w, err := scanWebhook(exec.QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return w, err
+}
+
+func (r *WebhookRepo) ListWebhooks(ctx context.Context, f domain.WebhookFilter) ([]domain.Webhook, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id > $1 ORDER BY id LIMIT $2`
+	return r.list(exec.QueryContext(ctx, query, f.AfterID, f.Limit))
+}
+
+func (r *WebhookRepo) ListActiveWebhooksForEvent(ctx context.Context, name domain.EventName) ([]domain.Webhook, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE status = 'active' AND $1 = ANY(event_types) ORDER BY id`
+	return r.list(exec.QueryContext(ctx, query, name))
+}
+
+func (r *WebhookRepo) list(rows *sql.Rows, err error) ([]domain.Webhook, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []domain.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *w)
+	}
+	return webhooks, rows.Err()
+}
+
+func (r *WebhookRepo) UpdateWebhook(ctx context.Context, w *domain.Webhook) error {
+	exec := utils.GetExecutor(ctx, r.DB)
+	query := `UPDATE webhooks SET url = $1, event_types = $2, secret = $3, status = $4, updated_at = NOW()
+		WHERE id = $5 RETURNING updated_at`
+
+	err := exec.QueryRowContext(ctx, query, w.URL, eventTypesArray(w.EventTypes), w.Secret, w.Status, w.ID).Scan(&w.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrWebhookNotFound
+	}
+	return err
+}
+
+func (r *WebhookRepo) DeleteWebhook(ctx context.Context, id int) (bool, error) {
+	exec := utils.GetExecutor(ctx, r.DB)
+	res, err := exec.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/martinusiron/loan-service/configs"
+	"github.com/martinusiron/loan-service/repository"
+)
+
+// NewFromConfig builds the blob store selected by the storage section of the
+// config.
+func NewFromConfig(cfg configs.StorageConfig) (repository.BlobStore, error) {
+	switch cfg.Driver {
+	case "", "local":
+		dir := cfg.LocalDir
+		if dir == "" {
+			dir = "data/blobs"
+		}
+		return NewLocalStore(dir)
+	case "s3":
+		return NewS3Store(cfg.S3.Endpoint, cfg.S3.Region, cfg.S3.Bucket,
+			os.Getenv(cfg.S3.AccessKeyEnv), os.Getenv(cfg.S3.SecretKeyEnv), cfg.S3.PathStyle)
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+}
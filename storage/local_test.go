@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore_RoundTrip(t *testing.T) {
+	s, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "picture-proofs/loan-1/a.png", []byte("png"), "image/png"))
+
+	r, info, err := s.Get(ctx, "picture-proofs/loan-1/a.png")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "png", string(data))
+	assert.Equal(t, "image/png", info.ContentType)
+	assert.EqualValues(t, 3, info.Size)
+
+	require.NoError(t, s.Delete(ctx, "picture-proofs/loan-1/a.png"))
+	_, _, err = s.Get(ctx, "picture-proofs/loan-1/a.png")
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+	assert.NoError(t, s.Delete(ctx, "picture-proofs/loan-1/a.png"))
+}
+
+func TestLocalStore_RejectsKeysOutsideRoot(t *testing.T) {
+	s, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "a/../../b", "a//b"} {
+		assert.ErrorIs(t, s.Put(context.Background(), key, []byte("x"), ""), errInvalidKey, key)
+	}
+}
+
+func TestURLSigner(t *testing.T) {
+	now := time.Unix(1_750_000_000, 0)
+	s := NewURLSigner([]byte("secret"), "/files", 15*time.Minute)
+
+	link, expiresAt := s.URL("picture-proofs/loan-1/a b.png", now)
+	assert.Equal(t, now.Add(15*time.Minute), expiresAt)
+	assert.Contains(t, link, "/files/picture-proofs/loan-1/a%20b.png?expires=")
+
+	exp := "1750000900"
+	sig := s.signature("picture-proofs/loan-1/a b.png", 1750000900)
+	assert.NoError(t, s.Verify("picture-proofs/loan-1/a b.png", exp, sig, now))
+
+	for name, check := range map[string]error{
+		"other key": s.Verify("picture-proofs/loan-2/a.png", exp, sig, now),
+		"tampered":  s.Verify("picture-proofs/loan-1/a b.png", "1750009999", sig, now),
+		"expired":   s.Verify("picture-proofs/loan-1/a b.png", exp, sig, expiresAt),
+		"garbage":   s.Verify("picture-proofs/loan-1/a b.png", "soon", sig, now),
+	} {
+		assert.ErrorIs(t, check, domain.ErrInvalidDownloadLink, name)
+	}
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+)
+
+// URLSigner issues and checks time-limited download links for stored files,
+// so a file can be fetched without a bearer token, e.g. from an <img> tag.
+type URLSigner struct {
+	Secret []byte
+	// BasePath is the route files are downloaded from; the key is appended.
+	BasePath string
+	TTL      time.Duration
+}
+
+func NewURLSigner(secret []byte, basePath string, ttl time.Duration) *URLSigner {
+	return &URLSigner{Secret: secret, BasePath: basePath, TTL: ttl}
+}
+
+func (s *URLSigner) signature(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write([]byte(key))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// URL returns a download link for key that stays valid for the signer's TTL.
+func (s *URLSigner) URL(key string, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(s.TTL).Truncate(time.Second)
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	q.Set("signature", s.signature(key, expiresAt.Unix()))
+	return s.BasePath + "/" + uriEncode(key, false) + "?" + q.Encode(), expiresAt
+}
+
+// Verify fails with domain.ErrInvalidDownloadLink unless signature was issued
+// by URL for key and has not expired.
+func (s *URLSigner) Verify(key, expires, signature string, now time.Time) error {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() >= exp {
+		return domain.ErrInvalidDownloadLink
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(key, exp))) {
+		return domain.ErrInvalidDownloadLink
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/martinusiron/loan-service/auth"
+)
+
+func (s *IntegrationTestSuite) TestDisbursementGeneratesAgreementLetters() {
+	investor := fmt.Sprintf("agreement%d@example.com", time.Now().UnixNano())
+	borrowerID := s.borrower()
+	loanID := s.disbursedLoan(map[string]interface{}{
+		"borrower_id":      borrowerID,
+		"principal_amount": 600000,
+		"rate":             12.0,
+		"roi":              6.0,
+		"tenor_months":     3,
+	}, investor)
+
+	get := func(tok, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if tok != "" {
+			req.Header.Set("Authorization", "Bearer "+tok)
+		}
+		w := httptest.NewRecorder()
+		s.Server.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("", fmt.Sprintf("/v1/loans/%d", loanID))
+	s.Require().Equal(200, w.Code)
+	var loan struct{ AgreementLetterLink string }
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &loan))
+	s.True(strings.HasSuffix(loan.AgreementLetterLink, fmt.Sprintf("/v1/loans/%d/agreements", loanID)), loan.AgreementLetterLink)
+
+	type document struct {
+		Agreement struct {
+			Party         string
+			InvestorEmail string
+			Format        string
+		} `json:"agreement"`
+		URL string `json:"url"`
+	}
+	list := func(tok string) []document {
+		w := get(tok, fmt.Sprintf("/v1/loans/%d/agreements", loanID))
+		s.Require().Equal(200, w.Code, w.Body.String())
+		var docs []document
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &docs))
+		return docs
+	}
+
+	// Staff see the borrower's and the investor's letter, each in HTML and PDF.
+	docs := list("")
+	s.Require().Len(docs, 4)
+	for _, d := range docs {
+		w := httptest.NewRecorder()
+		s.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, d.URL, nil))
+		s.Require().Equal(200, w.Code, d.URL)
+		if d.Agreement.Format == "pdf" {
+			s.Equal("application/pdf", w.Header().Get("Content-Type"))
+			s.True(bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")))
+		} else {
+			s.Contains(w.Body.String(), "IDR 600,000.00")
+		}
+	}
+
+	// Borrowers and investors only see their own letters.
+	for _, d := range list(token(s.T(), borrowerID, "", auth.RoleBorrower)) {
+		s.Equal("borrower", d.Agreement.Party)
+	}
+	docs = list(token(s.T(), "", investor, auth.RoleInvestor))
+	s.Len(docs, 2)
+	for _, d := range docs {
+		s.Equal(investor, d.Agreement.InvestorEmail)
+	}
+	s.Equal(403, get(token(s.T(), "", "someone-else@example.com", auth.RoleInvestor), fmt.Sprintf("/v1/loans/%d/agreements", loanID)).Code)
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/martinusiron/loan-service/auth"
+)
+
+func (s *IntegrationTestSuite) TestRoutesRequireAuthorizedCaller() {
+	borrowerID := s.borrower()
+	otherBorrower := s.borrower()
+	investor := fmt.Sprintf("auth%d@example.com", time.Now().UnixNano())
+	otherInvestor := fmt.Sprintf("auth-other%d@example.com", time.Now().UnixNano())
+	s.topUp(investor, 100000)
+	s.topUp(otherInvestor, 100000)
+
+	as := func(tok, method, path string, payload interface{}) *httptest.ResponseRecorder {
+		var body []byte
+		if payload != nil {
+			body, _ = json.Marshal(payload)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tok)
+		w := httptest.NewRecorder()
+		s.Server.ServeHTTP(w, req)
+		return w
+	}
+	borrowerTok := token(s.T(), borrowerID, "", auth.RoleBorrower)
+	investorTok := token(s.T(), "", investor, auth.RoleInvestor)
+	validatorTok := token(s.T(), fieldValidator, "", auth.RoleFieldValidator)
+	officerTok := token(s.T(), fieldOfficer, "", auth.RoleFieldOfficer)
+
+	// Bad or expired tokens are rejected before any handler runs.
+	s.Equal(401, as("not-a-token", http.MethodGet, "/v1/loans", nil).Code)
+	expired, err := auth.Sign("HS256", "", authSecret, auth.Claims{
+		"sub": borrowerID, "roles": []string{"borrower"}, "exp": time.Now().Add(-time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+	s.Equal(401, as(expired, http.MethodGet, "/v1/loans", nil).Code)
+
+	create := map[string]interface{}{
+		"borrower_id":      borrowerID,
+		"principal_amount": 100000,
+		"rate":             10.0,
+		"roi":              5.0,
+		"tenor_months":     6,
+	}
+	s.Equal(403, as(investorTok, http.MethodPost, "/v1/loans", create).Code)
+	create["borrower_id"] = otherBorrower
+	s.Equal(403, as(borrowerTok, http.MethodPost, "/v1/loans", create).Code)
+	create["borrower_id"] = borrowerID
+	w := as(borrowerTok, http.MethodPost, "/v1/loans", create)
+	s.Require().Equal(201, w.Code, w.Body.String())
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	loanID := int(resp["ID"].(float64))
+
+	// Only field validators approve, and only as themselves.
+	approveAs := func(tok, employee string) *httptest.ResponseRecorder {
+		req := approvalRequest(loanID, map[string]string{"employee_id": employee, "date": "2025-06-26"})
+		req.Header.Set("Authorization", "Bearer "+tok)
+		w := httptest.NewRecorder()
+		s.Server.ServeHTTP(w, req)
+		return w
+	}
+	s.Equal(403, approveAs(borrowerTok, fieldValidator).Code)
+	s.Equal(403, approveAs(officerTok, fieldValidator).Code)
+	s.Equal(403, approveAs(validatorTok, "EMP-SOMEONE-ELSE").Code)
+	w = approveAs(validatorTok, fieldValidator)
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	// Investors only invest, and only as themselves.
+	invest := fmt.Sprintf("/v1/loans/%d/invest", loanID)
+	s.Equal(403, as(investorTok, http.MethodPost, invest, map[string]interface{}{"investor_email": otherInvestor, "amount": 1000}).Code)
+	s.Equal(403, as(borrowerTok, http.MethodPost, invest, map[string]interface{}{"investor_email": investor, "amount": 1000}).Code)
+	w = as(investorTok, http.MethodPost, invest, map[string]interface{}{"investor_email": investor, "amount": 1000})
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	// Investors see only their own wallet and never the KYC review.
+	s.Equal(200, as(investorTok, http.MethodGet, fmt.Sprintf("/v1/investors/%s/wallet", investor), nil).Code)
+	s.Equal(403, as(investorTok, http.MethodGet, fmt.Sprintf("/v1/investors/%s/wallet", otherInvestor), nil).Code)
+	s.Equal(403, as(investorTok, http.MethodPost, fmt.Sprintf("/v1/investors/%s/kyc", investor),
+		map[string]interface{}{"status": "verified"}).Code)
+
+	// Borrowers read only their own loans, and investors cannot see who
+	// else funded a loan.
+	otherTok := token(s.T(), otherBorrower, "", auth.RoleBorrower)
+	for _, path := range []string{"", "/history", "/schedule", "/investments"} {
+		path = fmt.Sprintf("/v1/loans/%d%s", loanID, path)
+		s.Equal(200, as(borrowerTok, http.MethodGet, path, nil).Code, path)
+		s.Equal(403, as(otherTok, http.MethodGet, path, nil).Code, path)
+	}
+	s.Equal(403, as(investorTok, http.MethodGet, fmt.Sprintf("/v1/loans/%d/investments", loanID), nil).Code)
+	s.Equal(200, as(investorTok, http.MethodGet, fmt.Sprintf("/v1/loans/%d", loanID), nil).Code)
+	w = as(otherTok, http.MethodGet, "/v1/loans?borrower_id="+borrowerID, nil)
+	s.Require().Equal(200, w.Code, w.Body.String())
+	var page struct{ Data []json.RawMessage }
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &page))
+	s.Empty(page.Data)
+
+	// Borrowers cancel only their own loans.
+	cancel := fmt.Sprintf("/v1/loans/%d/cancel", loanID)
+	s.Equal(403, as(otherTok, http.MethodPost, cancel, map[string]interface{}{"actor": otherBorrower, "reason": "not mine"}).Code)
+	w = as(borrowerTok, http.MethodPost, cancel, map[string]interface{}{"actor": borrowerID, "reason": "changed my mind"})
+	s.Require().Equal(200, w.Code, w.Body.String())
+}
+
+func (s *IntegrationTestSuite) TestRequestsWithoutTokenAreRejected() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/loans", nil)
+	w := httptest.NewRecorder()
+	s.Router.ServeHTTP(w, req)
+	s.Equal(401, w.Code)
+	s.Contains(w.Header().Get("WWW-Authenticate"), "Bearer")
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *IntegrationTestSuite) TestBorrowerRegistry() {
+	identity := fmt.Sprintf("%d", time.Now().UnixNano())
+	create := map[string]interface{}{
+		"identity_number": identity,
+		"name":            "Siti Aminah",
+		"phone":           "081234567890",
+		"address":         "Jl. Merdeka 1, Bogor",
+	}
+	w := s.postJSON("/v1/borrowers", create)
+	s.Require().Equal(201, w.Code, w.Body.String())
+	var borrower struct {
+		ID     string
+		Name   string
+		Status string
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &borrower))
+	s.Equal("active", borrower.Status)
+
+	// One registration per identity number.
+	s.Equal(409, s.postJSON("/v1/borrowers", create).Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/borrowers/"+borrower.ID, nil)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/borrowers/BR-DOES-NOT-EXIST", nil)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Equal(404, w.Code)
+
+	newLoan := func(borrowerID string) *httptest.ResponseRecorder {
+		return s.postJSON("/v1/loans", map[string]interface{}{
+			"borrower_id":      borrowerID,
+			"principal_amount": 1000000,
+			"rate":             10.0,
+			"roi":              5.0,
+			"tenor_months":     12,
+		})
+	}
+	s.Equal(422, newLoan("BR-DOES-NOT-EXIST").Code)
+	s.Equal(201, newLoan(borrower.ID).Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/v1/borrowers/"+borrower.ID, nil)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code)
+	s.Equal(422, newLoan(borrower.ID).Code)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":    "Siti Aminah Putri",
+		"phone":   "081234567899",
+		"address": "Jl. Merdeka 2, Bogor",
+		"status":  "active",
+	})
+	req = httptest.NewRequest(http.MethodPut, "/v1/borrowers/"+borrower.ID, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code, w.Body.String())
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &borrower))
+	s.Equal("Siti Aminah Putri", borrower.Name)
+	s.Equal(201, newLoan(borrower.ID).Code)
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *IntegrationTestSuite) TestCancelLoanRefundsInvestors() {
+	investor := fmt.Sprintf("cancel%d@example.com", time.Now().UnixNano())
+
+	w := s.postJSON("/v1/loans", map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 500000,
+		"rate":             12.0,
+		"roi":              8.0,
+		"tenor_months":     6,
+	})
+	s.Require().Equal(201, w.Code)
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	loanID := int(resp["ID"].(float64))
+
+	w = s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
+	s.Require().Equal(200, w.Code)
+
+	s.topUp(investor, 500000)
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/invest", loanID), map[string]interface{}{
+		"investor_email": investor,
+		"amount":         500000,
+	})
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	cancel := func(payload map[string]interface{}) *httptest.ResponseRecorder {
+		return s.postJSON(fmt.Sprintf("/v1/loans/%d/cancel", loanID), payload)
+	}
+	s.Equal(400, cancel(map[string]interface{}{"actor": "BR-CANCEL"}).Code)
+	w = cancel(map[string]interface{}{"actor": "BR-CANCEL", "reason": "no longer needed"})
+	s.Require().Equal(200, w.Code, w.Body.String())
+	s.Equal("cancelled", s.loanStatus(loanID))
+
+	// Cancelled is terminal.
+	s.Equal(409, cancel(map[string]interface{}{"actor": "OPS1", "reason": "again"}).Code)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/investors/%s/wallet", investor), nil)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code)
+	var wallet struct {
+		Balance struct{ Amount string }
+		Held    struct{ Amount string }
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &wallet))
+	s.Equal("500000.00", wallet.Balance.Amount)
+	s.Equal("0.00", wallet.Held.Amount)
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/loans/%d/history", loanID), nil)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code)
+	s.Contains(w.Body.String(), "no longer needed")
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+func (s *IntegrationTestSuite) TestConcurrentInvestmentsNeverOverfund() {
+	const (
+		principal = 100000
+		ticket    = 1000
+		investors = 300
+	)
+
+	create := map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": principal,
+		"rate":             10.0,
+		"roi":              5.0,
+		"tenor_months":     12,
+	}
+	body, _ := json.Marshal(create)
+	req := httptest.NewRequest(http.MethodPost, "/v1/loans", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(201, w.Code)
+
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	idVal, ok := resp["ID"].(float64)
+	s.Require().True(ok, "Expected 'ID' in response")
+	loanID := int(idVal)
+
+	w2 := s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
+	s.Require().Equal(200, w2.Code, w2.Body.String())
+
+	for i := 0; i < investors; i++ {
+		s.topUp(fmt.Sprintf("investor%d@example.com", i), ticket)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		accepted int
+	)
+	start := make(chan struct{})
+	for i := 0; i < investors; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			invest := map[string]interface{}{
+				"investor_email": fmt.Sprintf("investor%d@example.com", i),
+				"amount":         ticket,
+			}
+			invBody, _ := json.Marshal(invest)
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/loans/%d/invest", loanID), bytes.NewBuffer(invBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			<-start
+			s.Server.ServeHTTP(w, req)
+
+			if w.Code == http.StatusOK {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	var total float64
+	s.Require().NoError(s.DB.QueryRow(`SELECT COALESCE(SUM(amount), 0) FROM investments WHERE loan_id = $1`, loanID).Scan(&total))
+	s.LessOrEqual(total, float64(principal))
+	s.Equal(float64(accepted*ticket), total)
+	s.Equal(principal/ticket, accepted)
+
+	var status string
+	s.Require().NoError(s.DB.QueryRow(`SELECT status FROM loans WHERE id = $1`, loanID).Scan(&status))
+	s.Equal("invested", status)
+}
@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *IntegrationTestSuite) TestApprovalRequiresActiveFieldValidator() {
+	w := s.postJSON("/v1/loans", map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 500000,
+		"rate":             12.0,
+		"roi":              8.0,
+		"tenor_months":     6,
+	})
+	s.Require().Equal(201, w.Code)
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	loanID := int(resp["ID"].(float64))
+
+	approve := func(employee string) *httptest.ResponseRecorder {
+		return s.approve(loanID, map[string]string{"employee_id": employee, "date": "2025-06-26"})
+	}
+
+	s.Equal(422, approve("EMP-DOES-NOT-EXIST").Code)
+	s.Equal(403, approve(fieldOfficer).Code)
+
+	validator := fmt.Sprintf("EMP-%d", time.Now().UnixNano())
+	w = s.postJSON("/v1/employees", map[string]interface{}{"id": validator, "name": "Rina", "role": "field_validator"})
+	s.Require().Equal(201, w.Code, w.Body.String())
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "Rina", "role": "field_validator", "status": "inactive"})
+	req := httptest.NewRequest(http.MethodPut, "/v1/employees/"+validator, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code, w.Body.String())
+	s.Equal(403, approve(validator).Code)
+
+	s.Equal(200, approve(fieldValidator).Code)
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+)
+
+// eventLog keeps the events an asynchronous subscriber receives.
+type eventLog struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+func (l *eventLog) record(_ context.Context, e domain.Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+	return nil
+}
+
+// names returns the names of the loan's events, oldest first.
+func (l *eventLog) names(loanID int) []domain.EventName {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var out []domain.EventName
+	for _, e := range l.events {
+		if e.AggregateID() == loanID {
+			out = append(out, e.EventName())
+		}
+	}
+	return out
+}
+
+func (s *IntegrationTestSuite) TestLifecycleEventsReachSubscribers() {
+	investor := fmt.Sprintf("events%d@example.com", time.Now().UnixNano())
+	loanID := s.disbursedLoan(map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 200000,
+		"rate":             10.0,
+		"roi":              5.0,
+		"tenor_months":     2,
+	}, investor)
+
+	want := []domain.EventName{
+		domain.EventLoanCreated,
+		domain.EventLoanApproved,
+		domain.EventInvestmentAdded,
+		domain.EventLoanFullyFunded,
+		domain.EventLoanDisbursed,
+	}
+	s.Eventually(func() bool { return len(s.Events.names(loanID)) == len(want) }, 2*time.Second, 10*time.Millisecond)
+	s.Equal(want, s.Events.names(loanID))
+
+	// A rejected request publishes nothing.
+	w := s.postJSON(fmt.Sprintf("/v1/loans/%d/disburse", loanID), map[string]interface{}{
+		"employee_id": fieldOfficer,
+		"date":        "2025-07-02",
+	})
+	s.Equal(409, w.Code, w.Body.String())
+	time.Sleep(50 * time.Millisecond)
+	s.Len(s.Events.names(loanID), len(want))
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *IntegrationTestSuite) TestFundingDeadlineExpiresAndRefunds() {
+	investor := fmt.Sprintf("expiry%d@example.com", time.Now().UnixNano())
+
+	w := s.postJSON("/v1/loans", map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 500000,
+		"rate":             12.0,
+		"roi":              8.0,
+		"tenor_months":     6,
+	})
+	s.Require().Equal(201, w.Code)
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	loanID := int(resp["ID"].(float64))
+
+	approve := func(deadline string) *httptest.ResponseRecorder {
+		return s.approve(loanID, map[string]string{
+			"employee_id":      fieldValidator,
+			"date":             "2025-06-26",
+			"funding_deadline": deadline,
+		})
+	}
+	s.Equal(400, approve("2020-01-01").Code)
+	s.Require().Equal(200, approve(time.Now().AddDate(0, 0, 7).Format("2006-01-02")).Code)
+
+	s.topUp(investor, 300000)
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/invest", loanID), map[string]interface{}{
+		"investor_email": investor,
+		"amount":         200000,
+	})
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	// Nothing is overdue yet.
+	_, err := s.LoanUC.ExpireOverdueLoans(context.Background(), time.Now())
+	s.Require().NoError(err)
+	s.Equal("approved", s.loanStatus(loanID))
+
+	_, err = s.DB.Exec(`UPDATE loans SET funding_deadline = NOW() - INTERVAL '1 hour' WHERE id = $1`, loanID)
+	s.Require().NoError(err)
+
+	// Investing is refused once the deadline has passed.
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/invest", loanID), map[string]interface{}{
+		"investor_email": investor,
+		"amount":         50000,
+	})
+	s.Equal(409, w.Code)
+
+	_, err = s.LoanUC.ExpireOverdueLoans(context.Background(), time.Now())
+	s.Require().NoError(err)
+	s.Equal("expired", s.loanStatus(loanID))
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/investors/%s/wallet", investor), nil)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code)
+	var wallet struct {
+		Balance struct{ Amount string }
+		Held    struct{ Amount string }
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &wallet))
+	s.Equal("300000.00", wallet.Balance.Amount)
+	s.Equal("0.00", wallet.Held.Amount)
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/loans/%d/investments", loanID), nil)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code)
+	var page struct {
+		Data []struct {
+			RefundedAt *time.Time `json:"refunded_at"`
+		} `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &page))
+	s.Require().Len(page.Data, 1)
+	s.NotNil(page.Data[0].RefundedAt)
+}
+
+func (s *IntegrationTestSuite) loanStatus(id int) string {
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/loans/%d", id), nil)
+	w := httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code)
+	var loan struct{ Status string }
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &loan))
+	return loan.Status
+}
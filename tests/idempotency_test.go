@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *IntegrationTestSuite) TestInvestLoanWithIdempotencyKey() {
+	create := map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 1000000,
+		"rate":             10.0,
+		"roi":              5.0,
+		"tenor_months":     12,
+	}
+	body, _ := json.Marshal(create)
+	req := httptest.NewRequest(http.MethodPost, "/v1/loans", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(201, w.Code)
+
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	idVal, ok := resp["ID"].(float64)
+	s.Require().True(ok, "Expected 'ID' in response")
+	loanID := int(idVal)
+
+	w2 := s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
+	s.Require().Equal(200, w2.Code, w2.Body.String())
+
+	s.topUp("retry@example.com", 250000)
+
+	key := fmt.Sprintf("invest-%d-%d", loanID, time.Now().UnixNano())
+	invest := func(amount int) *httptest.ResponseRecorder {
+		invBody, _ := json.Marshal(map[string]interface{}{
+			"investor_email": "retry@example.com",
+			"amount":         amount,
+		})
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/loans/%d/invest", loanID), bytes.NewBuffer(invBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		s.Server.ServeHTTP(w, req)
+		return w
+	}
+
+	first := invest(250000)
+	s.Equal(200, first.Code)
+
+	retry := invest(250000)
+	s.Equal(200, retry.Code)
+	s.Equal("true", retry.Header().Get("Idempotent-Replayed"))
+	s.Equal(first.Body.String(), retry.Body.String())
+
+	conflict := invest(300000)
+	s.Equal(422, conflict.Code)
+
+	// Keys are scoped to the caller: another investor picking the same key
+	// gets their own investment instead of a conflict.
+	other := fmt.Sprintf("retry-other%d@example.com", time.Now().UnixNano())
+	s.topUp(other, 250000)
+	otherBody, _ := json.Marshal(map[string]interface{}{"investor_email": other, "amount": 250000})
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/loans/%d/invest", loanID), bytes.NewBuffer(otherBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Equal(200, w.Code, w.Body.String())
+	s.Empty(w.Header().Get("Idempotent-Replayed"))
+
+	var count int
+	s.Require().NoError(s.DB.QueryRow(`SELECT COUNT(*) FROM investments WHERE loan_id = $1`, loanID).Scan(&count))
+	s.Equal(2, count)
+}
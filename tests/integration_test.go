@@ -1,23 +1,62 @@
 package tests
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
+	nethttp "net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
+	"github.com/martinusiron/loan-service/auth"
 	"github.com/martinusiron/loan-service/delivery/http"
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/events"
+	"github.com/martinusiron/loan-service/kafka"
+	"github.com/martinusiron/loan-service/kafka/kafkatest"
 	"github.com/martinusiron/loan-service/repository/postgres"
+	"github.com/martinusiron/loan-service/storage"
 	"github.com/martinusiron/loan-service/usecase"
+	"github.com/martinusiron/loan-service/webhook"
 	"github.com/stretchr/testify/suite"
 )
 
+// Employees seeded by SetupSuite for approving and disbursing loans.
+const (
+	fieldValidator = "EMP-IT-VALIDATOR"
+	fieldOfficer   = "EMP-IT-OFFICER"
+)
+
+// authSecret signs the tokens the suite sends; see withOperatorToken.
+var authSecret = []byte("integration-test-secret")
+
 type IntegrationTestSuite struct {
 	suite.Suite
 	DB     *sql.DB
-	Server *gin.Engine
+	Server nethttp.Handler
+	// Router is the bare router, for requests that must reach it without
+	// the operator token Server adds.
+	Router *gin.Engine
+	LoanUC *usecase.LoanUsecase
+	// BlobDir holds the files stored during the run.
+	BlobDir string
+	// Outbox delivers the notifications queued during the run to Mailbox.
+	Outbox  *usecase.OutboxDispatcher
+	Mailbox *mailbox
+	// Events records every event published during the run.
+	Bus    *events.Bus
+	Events *eventLog
+	// Kafka is the fake broker Producer streams the events to.
+	Kafka    *kafkatest.Broker
+	Producer *kafka.Producer
 }
 
 func (s *IntegrationTestSuite) SetupSuite() {
@@ -32,28 +71,100 @@ func (s *IntegrationTestSuite) SetupSuite() {
 	db, err := sql.Open("postgres", dsn)
 	s.Require().NoError(err)
 	s.Require().NoError(db.Ping())
+	// Keep the pool below PostgreSQL's connection limit when tests fire
+	// hundreds of concurrent requests.
+	db.SetMaxOpenConns(20)
 
 	s.DB = db
 
 	loanRepo := postgres.NewLoanRepo(s.DB)
 	approvalRepo := postgres.NewApprovalRepo(s.DB)
+	rejectionRepo := postgres.NewRejectionRepo(s.DB)
 	investmentRepo := postgres.NewInvestmentRepo(s.DB)
+	historyRepo := postgres.NewLoanHistoryRepo(s.DB)
+	scheduleRepo := postgres.NewScheduleRepo(s.DB)
+	repaymentRepo := postgres.NewRepaymentRepo(s.DB)
+	ledgerRepo := postgres.NewLedgerRepo(s.DB)
+	walletRepo := postgres.NewWalletRepo(s.DB)
+	borrowerRepo := postgres.NewBorrowerRepo(s.DB)
+	investorRepo := postgres.NewInvestorRepo(s.DB)
+	employeeRepo := postgres.NewEmployeeRepo(s.DB)
+	agreementRepo := postgres.NewAgreementRepo(s.DB)
+
+	blobDir, err := os.MkdirTemp("", "loan-service-blobs")
+	s.Require().NoError(err)
+	s.BlobDir = blobDir
+	blobs, err := storage.NewLocalStore(blobDir)
+	s.Require().NoError(err)
+
+	outboxRepo := postgres.NewOutboxRepo(s.DB)
+	s.Mailbox = &mailbox{}
+	s.Outbox = usecase.NewOutboxDispatcher(outboxRepo, db)
+	s.Outbox.Register(domain.TopicNotification, usecase.NotificationHandler(s.Mailbox))
 
-	uc := usecase.NewLoanUsecase(loanRepo, approvalRepo, investmentRepo, db)
+	webhookUC := usecase.NewWebhookUsecase(postgres.NewWebhookRepo(s.DB), postgres.NewWebhookDeliveryRepo(s.DB), outboxRepo, webhook.NewSender(5*time.Second), db)
+	s.Outbox.Register(domain.TopicWebhook, webhookUC.DeliveryHandler(s.Outbox.MaxAttempts))
+
+	s.Kafka, err = kafkatest.Start(map[string]int32{"loan-events": 3})
+	s.Require().NoError(err)
+	s.Producer = kafka.NewProducer([]string{s.Kafka.Addr()}, "loan-events", kafka.JSONSerializer{})
+	s.Outbox.Register(domain.TopicEvent, usecase.EventStreamHandler(s.Producer))
+
+	s.Events = &eventLog{}
+	bus := events.NewBus()
+	bus.Subscribe(usecase.NewInvestorNotifier(outboxRepo).Handle, usecase.InvestorNotifierEvents...)
+	bus.Subscribe(webhookUC.HandleEvent)
+	bus.Subscribe(usecase.NewEventStreamer(outboxRepo).Handle)
+	bus.SubscribeAsync(s.Events.record, 1000)
+	s.Bus = bus
+
+	uc := usecase.NewLoanUsecase(loanRepo, approvalRepo, rejectionRepo, investmentRepo, historyRepo, scheduleRepo, ledgerRepo, walletRepo, borrowerRepo, investorRepo, employeeRepo, agreementRepo, blobs, bus, db)
+	walletUC := usecase.NewWalletUsecase(walletRepo, ledgerRepo, db)
+	borrowerUC := usecase.NewBorrowerUsecase(borrowerRepo)
+	investorUC := usecase.NewInvestorUsecase(investorRepo, db)
+	employeeUC := usecase.NewEmployeeUsecase(employeeRepo)
+	fileUC := usecase.NewFileUsecase(blobs, storage.NewURLSigner([]byte("integration-url-secret"), http.FileRoute, 15*time.Minute))
+	repaymentUC := usecase.NewRepaymentUsecase(loanRepo, scheduleRepo, repaymentRepo, investmentRepo, historyRepo, ledgerRepo, walletRepo, domain.DefaultWaterfall, bus, db)
+
+	keys := auth.NewKeySet()
+	keys.AddHMAC("", authSecret)
+	authn := auth.NewJWTAuthenticator(&auth.Verifier{Keys: keys}, auth.ClaimsMapper{})
 
 	r := gin.Default()
-	http.NewHandler(r, uc)
+	http.NewHandler(r, authn, uc, repaymentUC, walletUC, borrowerUC, investorUC, employeeUC, fileUC, webhookUC, postgres.NewIdempotencyRepo(s.DB))
+
+	s.Router = r
+	s.Server = withOperatorToken(s.T(), r)
+	s.LoanUC = uc
 
-	s.Server = r
+	for id, role := range map[string]string{fieldValidator: "field_validator", fieldOfficer: "field_officer"} {
+		w := s.postJSON("/v1/employees", map[string]interface{}{"id": id, "name": "Integration " + role, "role": role})
+		s.Require().Contains([]int{201, 409}, w.Code, w.Body.String())
+	}
 }
 
 func (s *IntegrationTestSuite) TearDownSuite() {
+	if s.Bus != nil {
+		s.Bus.Close(context.Background())
+	}
+	if s.Producer != nil {
+		s.Producer.Close()
+	}
+	if s.Kafka != nil {
+		s.Kafka.Close()
+	}
 	if s.DB != nil {
 		s.DB.Close()
 	}
+	if s.BlobDir != "" {
+		os.RemoveAll(s.BlobDir)
+	}
 }
 
 func TestIntegration(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("set INTEGRATION=1 to run integration tests against PostgreSQL")
+	}
 	suite.Run(t, new(IntegrationTestSuite))
 }
 
@@ -64,3 +175,151 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// token signs a token for the given caller that is valid for an hour.
+func token(t *testing.T, sub, email string, roles ...auth.Role) string {
+	tok, err := auth.Sign("HS256", "", authSecret, auth.Claims{
+		"sub":   sub,
+		"email": email,
+		"roles": roles,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tok
+}
+
+// withOperatorToken authenticates requests that carry no Authorization
+// header as an operator holding every role, so tests that are not about
+// access control can ignore it. Investments are made as the investor named
+// in the body, because investors may only invest as themselves.
+func withOperatorToken(t *testing.T, next nethttp.Handler) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Header.Get("Authorization") == "" {
+			var body struct {
+				InvestorEmail string `json:"investor_email"`
+			}
+			if r.Body != nil {
+				raw, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(raw, &body)
+				r.Body = io.NopCloser(bytes.NewReader(raw))
+			}
+			r.Header.Set("Authorization", "Bearer "+token(t, "EMP-IT-OPERATOR", body.InvestorEmail,
+				auth.RoleAdmin, auth.RoleFieldValidator, auth.RoleFieldOfficer, auth.RoleBorrower, auth.RoleInvestor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *IntegrationTestSuite) postJSON(path string, payload interface{}) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	req := httptest.NewRequest(nethttp.MethodPost, path, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	return w
+}
+
+// testPNG is enough of a PNG file for content sniffing to recognise it. It
+// is sent as the picture proof when approving loans.
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+
+// approvalRequest builds the multipart request approving a loan with the
+// given form fields and testPNG as its picture proof.
+func approvalRequest(loanID int, fields map[string]string) *nethttp.Request {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for name, value := range fields {
+		mw.WriteField(name, value)
+	}
+	part, _ := mw.CreateFormFile("picture_proof", "proof.png")
+	part.Write(testPNG)
+	mw.Close()
+
+	req := httptest.NewRequest(nethttp.MethodPost, fmt.Sprintf("/v1/loans/%d/approve", loanID), &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func (s *IntegrationTestSuite) approve(loanID int, fields map[string]string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.Server.ServeHTTP(w, approvalRequest(loanID, fields))
+	return w
+}
+
+// borrower registers a new active borrower and returns its ID.
+func (s *IntegrationTestSuite) borrower() string {
+	w := s.postJSON("/v1/borrowers", map[string]interface{}{
+		"identity_number": fmt.Sprintf("%d", time.Now().UnixNano()),
+		"name":            "Test Borrower",
+		"phone":           "081200000000",
+		"address":         "Jl. Test 1, Jakarta",
+	})
+	s.Require().Equal(201, w.Code, w.Body.String())
+
+	var resp struct{ ID string }
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.ID
+}
+
+// verifiedInvestor registers the investor and passes their KYC. Investors
+// left over from earlier runs are reused as they are.
+func (s *IntegrationTestSuite) verifiedInvestor(email string) {
+	w := s.postJSON("/v1/investors", map[string]interface{}{
+		"email":           email,
+		"name":            "Test Investor",
+		"phone":           "081300000000",
+		"identity_number": "3174000000000000",
+	})
+	if w.Code == 409 {
+		return
+	}
+	s.Require().Equal(201, w.Code, w.Body.String())
+
+	w = s.postJSON(fmt.Sprintf("/v1/investors/%s/kyc", email), map[string]interface{}{
+		"status": "verified",
+	})
+	s.Require().Equal(200, w.Code, w.Body.String())
+}
+
+// topUp credits a verified investor's wallet so they can afford an
+// investment.
+func (s *IntegrationTestSuite) topUp(email string, amount interface{}) {
+	s.verifiedInvestor(email)
+	w := s.postJSON(fmt.Sprintf("/v1/investors/%s/wallet/top-up", email), map[string]interface{}{
+		"amount": amount,
+	})
+	s.Require().Equal(200, w.Code, w.Body.String())
+}
+
+// disbursedLoan walks a new loan through approval, full funding by a single
+// investor and disbursement, and returns its ID.
+func (s *IntegrationTestSuite) disbursedLoan(create map[string]interface{}, investor string) int {
+	w := s.postJSON("/v1/loans", create)
+	s.Require().Equal(201, w.Code, w.Body.String())
+
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	idVal, ok := resp["ID"].(float64)
+	s.Require().True(ok, "Expected 'ID' in response")
+	loanID := int(idVal)
+
+	w = s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	s.topUp(investor, create["principal_amount"])
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/invest", loanID), map[string]interface{}{
+		"investor_email": investor,
+		"amount":         create["principal_amount"],
+	})
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/disburse", loanID), map[string]interface{}{
+		"employee_id": fieldOfficer,
+		"date":        "2025-07-01",
+	})
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	return loanID
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *IntegrationTestSuite) TestListInvestments() {
+	create := map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 400000,
+		"rate":             12.0,
+		"roi":              8.0,
+		"tenor_months":     12,
+	}
+	body, _ := json.Marshal(create)
+	req := httptest.NewRequest(http.MethodPost, "/v1/loans", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(201, w.Code)
+
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	idVal, ok := resp["ID"].(float64)
+	s.Require().True(ok, "Expected 'ID' in response")
+	loanID := int(idVal)
+
+	w2 := s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
+	s.Require().Equal(200, w2.Code, w2.Body.String())
+
+	investor := fmt.Sprintf("portfolio%d@example.com", time.Now().UnixNano())
+	for _, inv := range []struct {
+		email  string
+		amount int
+	}{
+		{investor, 100000},
+		{"other@example.com", 300000},
+	} {
+		s.topUp(inv.email, inv.amount)
+		invBody, _ := json.Marshal(map[string]interface{}{
+			"investor_email": inv.email,
+			"amount":         inv.amount,
+		})
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/loans/%d/invest", loanID), bytes.NewBuffer(invBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.Server.ServeHTTP(w, req)
+		s.Require().Equal(200, w.Code)
+	}
+
+	type page struct {
+		Data []struct {
+			LoanID           int    `json:"loan_id"`
+			LoanStatus       string `json:"loan_status"`
+			InvestorEmail    string `json:"investor_email"`
+			ShareOfPrincipal string `json:"share_of_principal"`
+			ExpectedReturn   struct {
+				Amount string `json:"amount"`
+			} `json:"expected_return"`
+		} `json:"data"`
+		NextCursor string `json:"next_cursor"`
+	}
+
+	req3 := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/loans/%d/investments?limit=1", loanID), nil)
+	w3 := httptest.NewRecorder()
+	s.Server.ServeHTTP(w3, req3)
+	s.Require().Equal(200, w3.Code)
+	var loanPage page
+	s.Require().NoError(json.Unmarshal(w3.Body.Bytes(), &loanPage))
+	s.Require().Len(loanPage.Data, 1)
+	s.NotEmpty(loanPage.NextCursor)
+	s.Equal("invested", loanPage.Data[0].LoanStatus)
+
+	req4 := httptest.NewRequest(http.MethodGet, "/v1/investors/"+investor+"/investments", nil)
+	w4 := httptest.NewRecorder()
+	s.Server.ServeHTTP(w4, req4)
+	s.Require().Equal(200, w4.Code)
+	var portfolio page
+	s.Require().NoError(json.Unmarshal(w4.Body.Bytes(), &portfolio))
+	s.Require().Len(portfolio.Data, 1)
+	s.Equal(loanID, portfolio.Data[0].LoanID)
+	s.Equal("25.00", portfolio.Data[0].ShareOfPrincipal)
+	s.Equal("8000.00", portfolio.Data[0].ExpectedReturn.Amount)
+	s.Empty(portfolio.NextCursor)
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *IntegrationTestSuite) TestKYCGatesInvestments() {
+	investor := fmt.Sprintf("kyc%d@example.com", time.Now().UnixNano())
+
+	w := s.postJSON("/v1/loans", map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 500000,
+		"rate":             12.0,
+		"roi":              8.0,
+		"tenor_months":     6,
+	})
+	s.Require().Equal(201, w.Code)
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	loanID := int(resp["ID"].(float64))
+
+	w = s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
+	s.Require().Equal(200, w.Code)
+
+	invest := func() *httptest.ResponseRecorder {
+		return s.postJSON(fmt.Sprintf("/v1/loans/%d/invest", loanID), map[string]interface{}{
+			"investor_email": investor,
+			"amount":         100000,
+		})
+	}
+	review := func(status string) *httptest.ResponseRecorder {
+		return s.postJSON(fmt.Sprintf("/v1/investors/%s/kyc", investor), map[string]interface{}{
+			"status": status,
+			// Ignored: the review is recorded under the caller.
+			"reviewer_id": "EMP501",
+		})
+	}
+
+	// Unregistered investors cannot invest.
+	s.Equal(403, invest().Code)
+
+	register := map[string]interface{}{
+		"email":           investor,
+		"name":            "Budi Santoso",
+		"phone":           "081200000001",
+		"identity_number": "3174012501900002",
+	}
+	w = s.postJSON("/v1/investors", register)
+	s.Require().Equal(201, w.Code, w.Body.String())
+	s.Equal(409, s.postJSON("/v1/investors", register).Code)
+
+	w = s.postJSON(fmt.Sprintf("/v1/investors/%s/wallet/top-up", investor), map[string]interface{}{"amount": 100000})
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	// Pending KYC cannot invest, and the refusal says why.
+	w = invest()
+	s.Equal(403, w.Code)
+	var refused struct {
+		Code      string
+		KYCStatus string `json:"kyc_status"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &refused))
+	s.Equal("investor_not_verified", refused.Code)
+	s.Equal("pending", refused.KYCStatus)
+
+	s.Equal(409, review("suspended").Code)
+	w = review("verified")
+	s.Require().Equal(200, w.Code, w.Body.String())
+	var reviewed struct{ KYCReviewedBy string }
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &reviewed))
+	s.Equal("EMP-IT-OPERATOR", reviewed.KYCReviewedBy)
+	s.Require().Equal(200, invest().Code)
+
+	s.Require().Equal(200, review("suspended").Code)
+	s.Equal(403, invest().Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/investors?kyc_status=suspended&limit=100", nil)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code)
+	s.Contains(w.Body.String(), investor)
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/kafka"
+)
+
+// streamed returns the records the fake broker holds for the loan, in the
+// order they were produced.
+func (s *IntegrationTestSuite) streamed(loanID int) []domain.EventRecord {
+	var out []domain.EventRecord
+	for _, m := range s.Kafka.Messages("loan-events") {
+		if string(m.Key) != strconv.Itoa(loanID) {
+			continue
+		}
+		var rec domain.EventRecord
+		s.Require().NoError(json.Unmarshal(m.Value, &rec))
+		s.Equal(string(rec.Name), m.Headers["event"])
+		out = append(out, rec)
+	}
+	return out
+}
+
+func recordNames(records []domain.EventRecord) []domain.EventName {
+	var out []domain.EventName
+	for _, r := range records {
+		out = append(out, r.Name)
+	}
+	return out
+}
+
+func (s *IntegrationTestSuite) TestLoanEventsAreStreamedToKafka() {
+	investor := fmt.Sprintf("kafka%d@example.com", time.Now().UnixNano())
+	loanID := s.disbursedLoan(map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 200000,
+		"rate":             10.0,
+		"roi":              5.0,
+		"tenor_months":     2,
+	}, investor)
+
+	// Nothing is streamed before the outbox is dispatched.
+	s.Empty(s.streamed(loanID))
+	s.drainOutbox()
+
+	records := s.streamed(loanID)
+	s.Equal([]domain.EventName{
+		domain.EventLoanCreated,
+		domain.EventLoanApproved,
+		domain.EventInvestmentAdded,
+		domain.EventLoanFullyFunded,
+		domain.EventLoanDisbursed,
+	}, recordNames(records))
+	for i, r := range records {
+		s.Equal(loanID, r.LoanID)
+		if i > 0 {
+			s.Greater(r.ID, records[i-1].ID)
+		}
+	}
+
+	var partitions []int32
+	for _, m := range s.Kafka.Messages("loan-events") {
+		if string(m.Key) == strconv.Itoa(loanID) {
+			partitions = append(partitions, m.Partition)
+		}
+	}
+	s.Require().NotEmpty(partitions)
+	for _, p := range partitions {
+		s.Equal(partitions[0], p, "a loan's events share one partition")
+	}
+}
+
+func (s *IntegrationTestSuite) TestFailedEventHoldsBackLaterOnes() {
+	w := s.postJSON("/v1/loans", map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 300000,
+		"rate":             12.0,
+		"roi":              8.0,
+		"tenor_months":     3,
+	})
+	s.Require().Equal(201, w.Code, w.Body.String())
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	loanID := int(resp["ID"].(float64))
+	s.drainOutbox()
+
+	// The approval is not accepted by the broker, so the cancellation
+	// after it waits in the outbox until the approval is streamed.
+	s.Kafka.FailProduce(int16(kafka.ErrRequestTimedOut), 1)
+	w = s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
+	s.Require().Equal(200, w.Code, w.Body.String())
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/cancel", loanID), map[string]interface{}{"actor": "BR-KAFKA", "reason": "changed plans"})
+	s.Require().Equal(200, w.Code, w.Body.String())
+	s.drainOutbox()
+	s.Equal([]domain.EventName{domain.EventLoanCreated}, recordNames(s.streamed(loanID)))
+
+	// Once the retry is due, both go out in order.
+	for {
+		n, err := s.Outbox.DispatchDue(context.Background(), time.Now().Add(time.Hour))
+		s.Require().NoError(err)
+		if n == 0 {
+			break
+		}
+	}
+	s.Equal([]domain.EventName{domain.EventLoanCreated, domain.EventLoanApproved, domain.EventLoanCancelled}, recordNames(s.streamed(loanID)))
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/martinusiron/loan-service/ledger"
+	"github.com/martinusiron/loan-service/repository/postgres"
+)
+
+func (s *IntegrationTestSuite) TestLedgerStaysBalanced() {
+	investor := "ledger@example.com"
+	loanID := s.disbursedLoan(map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 300000,
+		"rate":             12.0,
+		"roi":              8.0,
+		"tenor_months":     3,
+	}, investor)
+
+	repo := postgres.NewLedgerRepo(s.DB)
+	ctx := context.Background()
+
+	receivable, err := repo.GetBalance(ctx, ledger.LoanReceivable(loanID))
+	s.Require().NoError(err)
+	s.Equal("300000.00", receivable.String())
+
+	w := s.postJSON(fmt.Sprintf("/v1/loans/%d/repayments", loanID), map[string]interface{}{
+		"amount": 103000,
+		"date":   "2025-08-01",
+	})
+	s.Require().Equal(201, w.Code, w.Body.String())
+
+	receivable, err = repo.GetBalance(ctx, ledger.LoanReceivable(loanID))
+	s.Require().NoError(err)
+	s.Equal("200000.00", receivable.String())
+
+	funding, err := repo.GetBalance(ctx, ledger.LoanFunding(loanID))
+	s.Require().NoError(err)
+	s.Equal("200000.00", funding.String())
+
+	// Every entry, across every loan, must balance.
+	var unbalanced int
+	s.Require().NoError(s.DB.QueryRow(`
+		SELECT COUNT(*) FROM (
+			SELECT entry_id FROM ledger_lines
+			GROUP BY entry_id
+			HAVING SUM(CASE WHEN direction = 'debit' THEN amount ELSE -amount END) <> 0
+		) t`).Scan(&unbalanced))
+	s.Zero(unbalanced)
+
+	_, err = s.DB.Exec(`DELETE FROM ledger_lines WHERE entry_id IN (SELECT id FROM ledger_entries WHERE loan_id = $1)`, loanID)
+	s.Error(err, "ledger lines must be append-only")
+}
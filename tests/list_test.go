@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+)
+
+func (s *IntegrationTestSuite) TestListLoansWithCursor() {
+	borrower := s.borrower()
+	for _, principal := range []int{100000, 200000, 300000} {
+		create := map[string]interface{}{
+			"borrower_id":      borrower,
+			"principal_amount": principal,
+			"rate":             10.0,
+			"roi":              5.0,
+			"tenor_months":     12,
+		}
+		body, _ := json.Marshal(create)
+		req := httptest.NewRequest(http.MethodPost, "/v1/loans", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.Server.ServeHTTP(w, req)
+		s.Require().Equal(201, w.Code)
+	}
+
+	type page struct {
+		Data       []map[string]interface{} `json:"data"`
+		NextCursor string                   `json:"next_cursor"`
+	}
+	list := func(params url.Values) page {
+		req := httptest.NewRequest(http.MethodGet, "/v1/loans?"+params.Encode(), nil)
+		w := httptest.NewRecorder()
+		s.Server.ServeHTTP(w, req)
+		s.Require().Equal(200, w.Code, w.Body.String())
+
+		var p page
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &p))
+		return p
+	}
+
+	params := url.Values{
+		"borrower_id":   {borrower},
+		"status":        {"proposed"},
+		"min_principal": {"150000"},
+		"sort_by":       {"principal_amount"},
+		"order":         {"asc"},
+		"limit":         {"1"},
+	}
+	first := list(params)
+	s.Require().Len(first.Data, 1)
+	s.Require().NotEmpty(first.NextCursor)
+	s.Equal("200000.00", first.Data[0]["PrincipalAmount"].(map[string]interface{})["amount"])
+
+	params.Set("cursor", first.NextCursor)
+	second := list(params)
+	s.Require().Len(second.Data, 1)
+	s.Empty(second.NextCursor)
+	s.Equal("300000.00", second.Data[0]["PrincipalAmount"].(map[string]interface{})["amount"])
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/loans?status=unknown", nil)
+	w := httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Equal(400, w.Code)
+}
@@ -10,10 +10,11 @@ import (
 
 func (s *IntegrationTestSuite) TestCreateAndGetLoan() {
 	payload := map[string]interface{}{
-		"borrower_id":      "BR01",
+		"borrower_id":      s.borrower(),
 		"principal_amount": 1000000,
 		"rate":             10.0,
 		"roi":              5.0,
+		"tenor_months":     12,
 	}
 	body, _ := json.Marshal(payload)
 
@@ -40,12 +41,32 @@ func (s *IntegrationTestSuite) TestCreateAndGetLoan() {
 	s.T().Log("GetLoan response:", w2.Body.String())
 }
 
+func (s *IntegrationTestSuite) TestCreateLoanRejectsSubCentAmount() {
+	payload := map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 1000.005,
+		"rate":             10.0,
+		"roi":              5.0,
+		"tenor_months":     12,
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/loans", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+
+	s.Equal(400, w.Code)
+	s.T().Log("CreateLoan response:", w.Body.String())
+}
+
 func (s *IntegrationTestSuite) TestApproveLoan() {
 	create := map[string]interface{}{
-		"borrower_id":      "BR02",
+		"borrower_id":      s.borrower(),
 		"principal_amount": 500000,
 		"rate":             12.0,
 		"roi":              8.0,
+		"tenor_months":     12,
 	}
 	body, _ := json.Marshal(create)
 	req := httptest.NewRequest(http.MethodPost, "/v1/loans", bytes.NewBuffer(body))
@@ -62,28 +83,63 @@ func (s *IntegrationTestSuite) TestApproveLoan() {
 	s.Require().True(ok, "Expected 'ID' in response")
 	loanID := int(idVal)
 
-	approve := map[string]interface{}{
-		"loan_id":       loanID,
-		"picture_proof": "proof.jpg",
-		"employee_id":   "EMP001",
-		"date":          "2025-06-26",
+	w2 := s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
+
+	s.Equal(200, w2.Code)
+	s.T().Log("ApproveLoan response:", w2.Body.String())
+}
+
+func (s *IntegrationTestSuite) TestRejectLoan() {
+	create := map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 750000,
+		"rate":             11.0,
+		"roi":              7.0,
+		"tenor_months":     12,
+	}
+	body, _ := json.Marshal(create)
+	req := httptest.NewRequest(http.MethodPost, "/v1/loans", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Equal(201, w.Code)
+
+	var resp map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	s.Require().NoError(err)
+	idVal, ok := resp["ID"].(float64)
+	s.Require().True(ok, "Expected 'ID' in response")
+	loanID := int(idVal)
+
+	reject := map[string]interface{}{
+		"employee_id": fieldValidator,
+		"reason_code": "failed_verification",
+		"note":        "borrower address could not be verified",
+		"date":        "2025-06-26",
 	}
-	apBody, _ := json.Marshal(approve)
-	req2 := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/loans/%d/approve", loanID), bytes.NewBuffer(apBody))
+	rjBody, _ := json.Marshal(reject)
+	req2 := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/loans/%d/reject", loanID), bytes.NewBuffer(rjBody))
 	req2.Header.Set("Content-Type", "application/json")
 	w2 := httptest.NewRecorder()
 	s.Server.ServeHTTP(w2, req2)
-
 	s.Equal(200, w2.Code)
-	s.T().Log("ApproveLoan response:", w2.Body.String())
+	s.T().Log("RejectLoan response:", w2.Body.String())
+
+	var status string
+	s.Require().NoError(s.DB.QueryRow(`SELECT status FROM loans WHERE id = $1`, loanID).Scan(&status))
+	s.Equal("rejected", status)
+
+	w3 := s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-27"})
+	s.Equal(409, w3.Code)
 }
 
 func (s *IntegrationTestSuite) TestInvestLoanAndDisburse() {
 	create := map[string]interface{}{
-		"borrower_id":      "BR03",
+		"borrower_id":      s.borrower(),
 		"principal_amount": 1000000,
 		"rate":             10.0,
 		"roi":              5.0,
+		"tenor_months":     12,
 	}
 	body, _ := json.Marshal(create)
 	req := httptest.NewRequest(http.MethodPost, "/v1/loans", bytes.NewBuffer(body))
@@ -100,20 +156,12 @@ func (s *IntegrationTestSuite) TestInvestLoanAndDisburse() {
 	s.Require().True(ok, "Expected 'ID' in response")
 	loanID := int(idVal)
 
-	ap := map[string]interface{}{
-		"loan_id":       loanID,
-		"picture_proof": "proof.jpg",
-		"employee_id":   "EMP002",
-		"date":          "2025-06-26",
-	}
-	apBody, _ := json.Marshal(ap)
-	req2 := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/loans/%d/approve", loanID), bytes.NewBuffer(apBody))
-	req2.Header.Set("Content-Type", "application/json")
-	w2 := httptest.NewRecorder()
-	s.Server.ServeHTTP(w2, req2)
+	w2 := s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
 	s.Equal(200, w2.Code)
 	s.T().Log("ApproveLoan response:", w2.Body.String())
 
+	s.topUp("foo@bar.com", 1000000)
+
 	invest := map[string]interface{}{
 		"loan_id":        loanID,
 		"investor_email": "foo@bar.com",
@@ -128,10 +176,9 @@ func (s *IntegrationTestSuite) TestInvestLoanAndDisburse() {
 	s.T().Log("InvestLoan response:", w3.Body.String())
 
 	dis := map[string]interface{}{
-		"loan_id":               loanID,
-		"agreement_letter_link": "http://example.com/agreement.pdf",
-		"employee_id":           "EMP003",
-		"date":                  "2025-06-26",
+		"loan_id":     loanID,
+		"employee_id": fieldOfficer,
+		"date":        "2025-06-26",
 	}
 	disBody, _ := json.Marshal(dis)
 	req4 := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/loans/%d/disburse", loanID), bytes.NewBuffer(disBody))
@@ -140,6 +187,37 @@ func (s *IntegrationTestSuite) TestInvestLoanAndDisburse() {
 	s.Server.ServeHTTP(w4, req4)
 	s.Equal(200, w4.Code)
 	s.T().Log("DisburseLoan response:", w4.Body.String())
+
+	req5 := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/loans/%d/history", loanID), nil)
+	w5 := httptest.NewRecorder()
+	s.Server.ServeHTTP(w5, req5)
+	s.Equal(200, w5.Code)
+	s.T().Log("GetLoanHistory response:", w5.Body.String())
+
+	var history []map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w5.Body.Bytes(), &history))
+	s.Require().Len(history, 4)
+	s.Equal("proposed", history[0]["ToStatus"])
+	s.Equal("approved", history[1]["ToStatus"])
+	s.Equal("invested", history[2]["ToStatus"])
+	s.Equal("disbursed", history[3]["ToStatus"])
+	s.Equal(fieldOfficer, history[3]["Actor"])
+
+	req6 := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/loans/%d/schedule", loanID), nil)
+	w6 := httptest.NewRecorder()
+	s.Server.ServeHTTP(w6, req6)
+	s.Equal(200, w6.Code)
+	s.T().Log("GetRepaymentSchedule response:", w6.Body.String())
+
+	var schedule struct {
+		RepaymentMethod string                   `json:"repayment_method"`
+		TotalPrincipal  map[string]interface{}   `json:"total_principal"`
+		Installments    []map[string]interface{} `json:"installments"`
+	}
+	s.Require().NoError(json.Unmarshal(w6.Body.Bytes(), &schedule))
+	s.Equal("flat", schedule.RepaymentMethod)
+	s.Len(schedule.Installments, 12)
+	s.Equal("1000000.00", schedule.TotalPrincipal["amount"])
 }
 
 func itoa(i int) string {
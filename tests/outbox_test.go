@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/notify"
+)
+
+// mailbox renders the notifications the outbox delivers with the built-in
+// templates and keeps them instead of sending them.
+type mailbox struct {
+	mu       sync.Mutex
+	messages []notify.Message
+}
+
+func (m *mailbox) Notify(_ context.Context, n domain.Notification) error {
+	msg, err := notify.DefaultCatalogue().Render(n)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// to returns the messages received by the recipient.
+func (m *mailbox) to(recipient string) []notify.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []notify.Message
+	for _, msg := range m.messages {
+		if msg.To == recipient {
+			out = append(out, msg)
+		}
+	}
+	return out
+}
+
+// drainOutbox delivers every message that is due.
+func (s *IntegrationTestSuite) drainOutbox() {
+	for {
+		n, err := s.Outbox.DispatchDue(context.Background(), time.Now())
+		s.Require().NoError(err)
+		if n == 0 {
+			return
+		}
+	}
+}
+
+func (s *IntegrationTestSuite) TestOutboxNotifiesInvestorsAfterCommit() {
+	investor := fmt.Sprintf("outbox%d@example.com", time.Now().UnixNano())
+	w := s.postJSON("/v1/loans", map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 300000,
+		"rate":             12.0,
+		"roi":              6.0,
+		"tenor_months":     3,
+	})
+	s.Require().Equal(201, w.Code, w.Body.String())
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	loanID := int(resp["ID"].(float64))
+	s.Require().Equal(200, s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"}).Code)
+	s.topUp(investor, 500000)
+
+	invest := func(amount int) int {
+		return s.postJSON(fmt.Sprintf("/v1/loans/%d/invest", loanID), map[string]interface{}{
+			"investor_email": investor,
+			"amount":         amount,
+		}).Code
+	}
+
+	// Neither a rejected nor a partial investment queues a notification.
+	s.Require().NotEqual(200, invest(400000))
+	s.Require().Equal(200, invest(100000))
+	s.drainOutbox()
+	s.Empty(s.Mailbox.to(investor))
+
+	// Funding the loan notifies the investor once, with their total.
+	s.Require().Equal(200, invest(200000))
+	s.drainOutbox()
+	msgs := s.Mailbox.to(investor)
+	s.Require().Len(msgs, 1)
+	s.Equal(fmt.Sprintf("Loan #%d has been fully funded", loanID), msgs[0].Subject)
+	s.Contains(msgs[0].Body, "IDR 300,000.00")
+
+	var status string
+	s.Require().NoError(s.DB.QueryRow(`SELECT status FROM outbox_messages WHERE key = $1 AND topic = $2`,
+		fmt.Sprint(loanID), domain.TopicNotification).Scan(&status))
+	s.Equal("done", status)
+
+	// Delivered messages are not delivered again.
+	s.drainOutbox()
+	s.Len(s.Mailbox.to(investor), 1)
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (s *IntegrationTestSuite) TestPictureProofIsStoredAndServedThroughSignedLink() {
+	w := s.postJSON("/v1/loans", map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 500000,
+		"rate":             12.0,
+		"roi":              8.0,
+		"tenor_months":     6,
+	})
+	s.Require().Equal(201, w.Code, w.Body.String())
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	loanID := int(resp["ID"].(float64))
+
+	// Anything that is not an image is turned away before the loan changes.
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("employee_id", fieldValidator)
+	mw.WriteField("date", "2025-06-26")
+	part, _ := mw.CreateFormFile("picture_proof", "proof.png")
+	part.Write([]byte("%PDF-1.4 not a picture"))
+	mw.Close()
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/loans/%d/approve", loanID), &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Equal(415, w.Code, w.Body.String())
+	s.Equal("proposed", s.loanStatus(loanID))
+
+	w = s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/loans/%d/approval", loanID), nil)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code, w.Body.String())
+	var detail struct {
+		Approval struct {
+			PictureProofKey         string
+			PictureProofContentType string
+		} `json:"approval"`
+		PictureProofURL string `json:"picture_proof_url"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &detail))
+	s.Equal("image/png", detail.Approval.PictureProofContentType)
+	s.Contains(detail.PictureProofURL, "/files/"+detail.Approval.PictureProofKey)
+
+	// The signed link works without a token; a tampered one does not.
+	w = httptest.NewRecorder()
+	s.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, detail.PictureProofURL, nil))
+	s.Require().Equal(200, w.Code, w.Body.String())
+	s.Equal("image/png", w.Header().Get("Content-Type"))
+	s.Equal(testPNG, w.Body.Bytes())
+
+	w = httptest.NewRecorder()
+	s.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, detail.PictureProofURL+"0", nil))
+	s.Equal(403, w.Code)
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (s *IntegrationTestSuite) TestRecordRepayments() {
+	loanID := s.disbursedLoan(map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 200000,
+		"rate":             12.0,
+		"roi":              8.0,
+		"tenor_months":     2,
+	}, "repay@example.com")
+
+	type result struct {
+		Repayment struct {
+			Principal struct{ Amount string } `json:"Principal"`
+			Interest  struct{ Amount string } `json:"Interest"`
+			Excess    struct{ Amount string } `json:"Excess"`
+			Margin    struct{ Amount string } `json:"PlatformMargin"`
+			Payouts   []struct {
+				InvestorEmail string
+				Principal     struct{ Amount string }
+				Return        struct{ Amount string }
+			} `json:"Payouts"`
+		} `json:"repayment"`
+		LoanStatus string `json:"loan_status"`
+	}
+
+	// Partial payment: interest of the first instalment first, then principal.
+	w := s.postJSON(fmt.Sprintf("/v1/loans/%d/repayments", loanID), map[string]interface{}{
+		"amount":    50000,
+		"reference": "TRX-1",
+		"date":      "2025-08-01",
+	})
+	s.Require().Equal(201, w.Code, w.Body.String())
+	var r1 result
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &r1))
+	s.Equal("repaying", r1.LoanStatus)
+	s.Equal("2000.00", r1.Repayment.Interest.Amount)
+	s.Equal("48000.00", r1.Repayment.Principal.Amount)
+	// ROI 8% of a 12% rate: investors get two thirds of the interest.
+	s.Equal("666.67", r1.Repayment.Margin.Amount)
+	s.Require().Len(r1.Repayment.Payouts, 1)
+	s.Equal("repay@example.com", r1.Repayment.Payouts[0].InvestorEmail)
+	s.Equal("48000.00", r1.Repayment.Payouts[0].Principal.Amount)
+	s.Equal("1333.33", r1.Repayment.Payouts[0].Return.Amount)
+
+	// Over-payment settles everything and reports the excess.
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/repayments", loanID), map[string]interface{}{
+		"amount": 160000,
+		"date":   "2025-09-01",
+	})
+	s.Require().Equal(201, w.Code, w.Body.String())
+	var r2 result
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &r2))
+	s.Equal("completed", r2.LoanStatus)
+	s.Equal("6000.00", r2.Repayment.Excess.Amount)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/loans/%d/schedule", loanID), nil)
+	w = httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	s.Require().Equal(200, w.Code)
+	var schedule struct {
+		Installments []map[string]interface{} `json:"installments"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &schedule))
+	for _, inst := range schedule.Installments {
+		s.Equal("paid", inst["Status"])
+	}
+
+	// A completed loan accepts no further payments.
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/repayments", loanID), map[string]interface{}{
+		"amount": 1000,
+		"date":   "2025-10-01",
+	})
+	s.Equal(409, w.Code)
+}
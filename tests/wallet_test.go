@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *IntegrationTestSuite) TestWalletHoldsUntilDisbursement() {
+	investor := fmt.Sprintf("wallet%d@example.com", time.Now().UnixNano())
+
+	type wallet struct {
+		Balance struct{ Amount string }
+		Held    struct{ Amount string }
+	}
+	getWallet := func() wallet {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/investors/%s/wallet", investor), nil)
+		w := httptest.NewRecorder()
+		s.Server.ServeHTTP(w, req)
+		s.Require().Equal(200, w.Code)
+		var out wallet
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &out))
+		return out
+	}
+
+	w := s.postJSON("/v1/loans", map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 500000,
+		"rate":             12.0,
+		"roi":              8.0,
+		"tenor_months":     6,
+	})
+	s.Require().Equal(201, w.Code)
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	loanID := int(resp["ID"].(float64))
+
+	w = s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"})
+	s.Require().Equal(200, w.Code)
+
+	invest := func(amount int) *httptest.ResponseRecorder {
+		return s.postJSON(fmt.Sprintf("/v1/loans/%d/invest", loanID), map[string]interface{}{
+			"investor_email": investor,
+			"amount":         amount,
+		})
+	}
+
+	// No funds yet.
+	s.verifiedInvestor(investor)
+	s.Equal(422, invest(100000).Code)
+
+	s.topUp(investor, 600000)
+	s.Require().Equal(200, invest(500000).Code)
+
+	wl := getWallet()
+	s.Equal("600000.00", wl.Balance.Amount)
+	s.Equal("500000.00", wl.Held.Amount)
+
+	// Held funds cannot be withdrawn.
+	w = s.postJSON(fmt.Sprintf("/v1/investors/%s/wallet/withdraw", investor), map[string]interface{}{"amount": 200000})
+	s.Equal(422, w.Code)
+	w = s.postJSON(fmt.Sprintf("/v1/investors/%s/wallet/withdraw", investor), map[string]interface{}{"amount": 100000})
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/disburse", loanID), map[string]interface{}{
+		"employee_id": fieldOfficer,
+		"date":        "2025-07-01",
+	})
+	s.Require().Equal(200, w.Code, w.Body.String())
+
+	wl = getWallet()
+	s.Equal("0.00", wl.Balance.Amount)
+	s.Equal("0.00", wl.Held.Amount)
+
+	// Repayments are paid back into the wallet.
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/repayments", loanID), map[string]interface{}{
+		"amount": "88333.33",
+		"date":   "2025-08-01",
+	})
+	s.Require().Equal(201, w.Code, w.Body.String())
+
+	wl = getWallet()
+	s.Equal("86666.66", wl.Balance.Amount)
+}
@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/martinusiron/loan-service/webhook"
+)
+
+// receiver is a partner endpoint that checks signatures and keeps the
+// envelopes it accepts. It answers with status while status is not 2xx.
+type receiver struct {
+	mu       sync.Mutex
+	secret   []byte
+	status   int
+	received []webhook.Envelope
+	rejected int
+}
+
+func (rc *receiver) ServeHTTP(w nethttp.ResponseWriter, r *nethttp.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if err := webhook.Verify(rc.secret, r.Header, body, webhook.DefaultTolerance, time.Now()); err != nil {
+		rc.rejected++
+		nethttp.Error(w, err.Error(), nethttp.StatusUnauthorized)
+		return
+	}
+	if rc.status != 0 && rc.status/100 != 2 {
+		nethttp.Error(w, "try again later", rc.status)
+		return
+	}
+	var env webhook.Envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		nethttp.Error(w, err.Error(), nethttp.StatusBadRequest)
+		return
+	}
+	rc.received = append(rc.received, env)
+	w.WriteHeader(nethttp.StatusNoContent)
+}
+
+func (rc *receiver) respond(status int) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.status = status
+}
+
+// forLoan returns the envelopes received about the loan.
+func (rc *receiver) forLoan(loanID int) []webhook.Envelope {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	var out []webhook.Envelope
+	for _, env := range rc.received {
+		if env.LoanID == loanID {
+			out = append(out, env)
+		}
+	}
+	return out
+}
+
+func (s *IntegrationTestSuite) getJSON(path string, into interface{}) int {
+	req := httptest.NewRequest(nethttp.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	s.Server.ServeHTTP(w, req)
+	if w.Code == 200 {
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), into))
+	}
+	return w.Code
+}
+
+func (s *IntegrationTestSuite) TestWebhookDeliveries() {
+	rc := &receiver{secret: []byte("integration-webhook-secret")}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	w := s.postJSON("/v1/webhooks", map[string]interface{}{
+		"url":         srv.URL + "/hooks",
+		"event_types": []string{"loan.created", "loan.approved"},
+		"secret":      string(rc.secret),
+	})
+	s.Require().Equal(201, w.Code, w.Body.String())
+	s.NotContains(w.Body.String(), string(rc.secret), "the secret is never returned")
+	var hook map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &hook))
+	hookID := int(hook["ID"].(float64))
+	defer func() {
+		req := httptest.NewRequest(nethttp.MethodDelete, fmt.Sprintf("/v1/webhooks/%d", hookID), nil)
+		rec := httptest.NewRecorder()
+		s.Server.ServeHTTP(rec, req)
+		s.Equal(204, rec.Code)
+	}()
+
+	w = s.postJSON("/v1/loans", map[string]interface{}{
+		"borrower_id":      s.borrower(),
+		"principal_amount": 300000,
+		"rate":             12.0,
+		"roi":              6.0,
+		"tenor_months":     3,
+	})
+	s.Require().Equal(201, w.Code, w.Body.String())
+	var loan map[string]interface{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &loan))
+	loanID := int(loan["ID"].(float64))
+
+	// Deliveries are only attempted by the outbox dispatcher.
+	s.Empty(rc.forLoan(loanID))
+	s.drainOutbox()
+	got := rc.forLoan(loanID)
+	s.Require().Len(got, 1)
+	s.Equal("loan.created", string(got[0].Event))
+	created := got[0].DeliveryID
+
+	// A failing endpoint is retried with backoff until it recovers.
+	rc.respond(503)
+	s.Require().Equal(200, s.approve(loanID, map[string]string{"employee_id": fieldValidator, "date": "2025-06-26"}).Code)
+	s.drainOutbox()
+	s.Len(rc.forLoan(loanID), 1)
+
+	var page struct {
+		Data []struct {
+			ID             int
+			EventName      string
+			LoanID         int
+			Status         string
+			Attempts       int
+			ResponseStatus int
+		} `json:"data"`
+	}
+	s.Require().Equal(200, s.getJSON(fmt.Sprintf("/v1/webhooks/%d/deliveries?status=retrying", hookID), &page))
+	s.Require().Len(page.Data, 1)
+	s.Equal("loan.approved", page.Data[0].EventName)
+	s.Equal(1, page.Data[0].Attempts)
+	s.Equal(503, page.Data[0].ResponseStatus)
+
+	rc.respond(204)
+	_, err := s.Outbox.DispatchDue(context.Background(), time.Now().Add(time.Hour))
+	s.Require().NoError(err)
+	got = rc.forLoan(loanID)
+	s.Require().Len(got, 2)
+	s.Equal("loan.approved", string(got[1].Event))
+
+	s.Require().Equal(200, s.getJSON(fmt.Sprintf("/v1/webhooks/%d/deliveries", hookID), &page))
+	s.Require().Len(page.Data, 2)
+	s.Equal("succeeded", page.Data[0].Status)
+	s.Equal(2, page.Data[0].Attempts)
+
+	// A manual redelivery sends the same delivery again.
+	w = s.postJSON(fmt.Sprintf("/v1/webhooks/%d/deliveries/%d/redeliver", hookID, created), nil)
+	s.Require().Equal(202, w.Code, w.Body.String())
+	s.drainOutbox()
+	got = rc.forLoan(loanID)
+	s.Require().Len(got, 3)
+	s.Equal(created, got[2].DeliveryID)
+	s.Equal(0, rc.rejected)
+
+	// Unsubscribed events are not delivered.
+	w = s.postJSON(fmt.Sprintf("/v1/loans/%d/cancel", loanID), map[string]interface{}{"actor": "ops", "reason": "test"})
+	s.Require().Equal(200, w.Code, w.Body.String())
+	s.drainOutbox()
+	s.Len(rc.forLoan(loanID), 3)
+}
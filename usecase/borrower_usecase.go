@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/repository"
+)
+
+type BorrowerUsecase struct {
+	BorrowerRepo repository.BorrowerRepository
+}
+
+func NewBorrowerUsecase(br repository.BorrowerRepository) *BorrowerUsecase {
+	return &BorrowerUsecase{BorrowerRepo: br}
+}
+
+func (uc *BorrowerUsecase) CreateBorrower(ctx context.Context, payload dto.BorrowerPayload) (*domain.Borrower, error) {
+	b := &domain.Borrower{
+		IdentityNumber: payload.IdentityNumber,
+		Name:           payload.Name,
+		Phone:          payload.Phone,
+		Email:          payload.Email,
+		Address:        payload.Address,
+		Status:         domain.BorrowerActive,
+	}
+	if err := uc.BorrowerRepo.CreateBorrower(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (uc *BorrowerUsecase) GetBorrower(ctx context.Context, id string) (*domain.Borrower, error) {
+	return uc.BorrowerRepo.GetBorrowerByID(ctx, id)
+}
+
+// ListBorrowers returns one page of borrowers, optionally filtered by status.
+func (uc *BorrowerUsecase) ListBorrowers(ctx context.Context, q dto.ListBorrowersQuery) (*dto.BorrowerPage, error) {
+	f := domain.BorrowerFilter{
+		Status: domain.BorrowerStatus(q.Status),
+		Limit:  domain.DefaultPageSize,
+	}
+	if q.Limit > 0 {
+		f.Limit = min(q.Limit, domain.MaxPageSize)
+	}
+	if q.Cursor != "" {
+		after, err := domain.DecodeKeyCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		f.AfterID = after
+	}
+
+	limit := f.Limit
+	f.Limit++
+	borrowers, err := uc.BorrowerRepo.ListBorrowers(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.BorrowerPage{Data: borrowers}
+	if len(borrowers) > limit {
+		page.Data = borrowers[:limit]
+		page.NextCursor = domain.EncodeKeyCursor(page.Data[limit-1].ID)
+	}
+	return page, nil
+}
+
+// UpdateBorrower replaces the borrower's details, or returns nil if the
+// borrower does not exist.
+func (uc *BorrowerUsecase) UpdateBorrower(ctx context.Context, payload dto.UpdateBorrowerPayload) (*domain.Borrower, error) {
+	b, err := uc.BorrowerRepo.GetBorrowerByID(ctx, payload.ID)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	b.Name = payload.Name
+	b.Phone = payload.Phone
+	b.Email = payload.Email
+	b.Address = payload.Address
+	b.Status = payload.Status
+	if err := uc.BorrowerRepo.UpdateBorrower(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// DeactivateBorrower stops the borrower from taking new loans. Borrowers are
+// never deleted because their loans keep referencing them.
+func (uc *BorrowerUsecase) DeactivateBorrower(ctx context.Context, id string) (*domain.Borrower, error) {
+	b, err := uc.BorrowerRepo.GetBorrowerByID(ctx, id)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	b.Status = domain.BorrowerInactive
+	if err := uc.BorrowerRepo.UpdateBorrower(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+
+	mockRepo "github.com/martinusiron/loan-service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBorrower(t *testing.T) {
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	uc := NewBorrowerUsecase(mockBorrowerRepo)
+
+	mockBorrowerRepo.On("CreateBorrower", mock.Anything, mock.MatchedBy(func(b *domain.Borrower) bool {
+		return b.IdentityNumber == "3174012501900001" && b.Status == domain.BorrowerActive
+	})).Return(nil).Once()
+	mockBorrowerRepo.On("CreateBorrower", mock.Anything, mock.Anything).Return(domain.ErrBorrowerExists).Once()
+
+	payload := dto.BorrowerPayload{
+		IdentityNumber: "3174012501900001",
+		Name:           "Siti Aminah",
+		Phone:          "081234567890",
+		Address:        "Jl. Merdeka 1, Bogor",
+	}
+	b, err := uc.CreateBorrower(context.TODO(), payload)
+	require.NoError(t, err)
+	assert.Equal(t, "Siti Aminah", b.Name)
+
+	_, err = uc.CreateBorrower(context.TODO(), payload)
+	assert.ErrorIs(t, err, domain.ErrBorrowerExists)
+}
+
+func TestListBorrowers_Paginates(t *testing.T) {
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	uc := NewBorrowerUsecase(mockBorrowerRepo)
+
+	mockBorrowerRepo.On("ListBorrowers", mock.Anything, domain.BorrowerFilter{Status: domain.BorrowerActive, Limit: 3}).Return([]domain.Borrower{
+		{ID: "BR00000001"}, {ID: "BR00000002"}, {ID: "BR00000003"},
+	}, nil)
+	mockBorrowerRepo.On("ListBorrowers", mock.Anything, domain.BorrowerFilter{Status: domain.BorrowerActive, AfterID: "BR00000002", Limit: 3}).Return([]domain.Borrower{
+		{ID: "BR00000003"},
+	}, nil)
+
+	page, err := uc.ListBorrowers(context.TODO(), dto.ListBorrowersQuery{Status: "active", Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, page.Data, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = uc.ListBorrowers(context.TODO(), dto.ListBorrowersQuery{Status: "active", Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Len(t, page.Data, 1)
+	assert.Empty(t, page.NextCursor)
+
+	_, err = uc.ListBorrowers(context.TODO(), dto.ListBorrowersQuery{Cursor: "not-a-cursor"})
+	assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+}
+
+func TestDeactivateBorrower(t *testing.T) {
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	uc := NewBorrowerUsecase(mockBorrowerRepo)
+
+	mockBorrowerRepo.On("GetBorrowerByID", mock.Anything, "BR00000001").Return(&domain.Borrower{ID: "BR00000001", Status: domain.BorrowerActive}, nil)
+	mockBorrowerRepo.On("GetBorrowerByID", mock.Anything, "BR-MISSING").Return(nil, nil)
+	mockBorrowerRepo.On("UpdateBorrower", mock.Anything, mock.MatchedBy(func(b *domain.Borrower) bool {
+		return b.ID == "BR00000001" && b.Status == domain.BorrowerInactive
+	})).Return(nil)
+
+	b, err := uc.DeactivateBorrower(context.TODO(), "BR00000001")
+	require.NoError(t, err)
+	assert.Equal(t, domain.BorrowerInactive, b.Status)
+
+	b, err = uc.DeactivateBorrower(context.TODO(), "BR-MISSING")
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+	mockBorrowerRepo.AssertNumberOfCalls(t, "UpdateBorrower", 1)
+}
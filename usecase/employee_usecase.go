@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/repository"
+)
+
+type EmployeeUsecase struct {
+	EmployeeRepo repository.EmployeeRepository
+}
+
+func NewEmployeeUsecase(er repository.EmployeeRepository) *EmployeeUsecase {
+	return &EmployeeUsecase{EmployeeRepo: er}
+}
+
+func (uc *EmployeeUsecase) CreateEmployee(ctx context.Context, payload dto.EmployeePayload) (*domain.Employee, error) {
+	e := &domain.Employee{
+		ID:     payload.ID,
+		Name:   payload.Name,
+		Role:   payload.Role,
+		Status: domain.EmployeeActive,
+	}
+	if err := uc.EmployeeRepo.CreateEmployee(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (uc *EmployeeUsecase) GetEmployee(ctx context.Context, id string) (*domain.Employee, error) {
+	return uc.EmployeeRepo.GetEmployeeByID(ctx, id)
+}
+
+// UpdateEmployee changes the employee's name, role or status, or returns nil
+// if the employee does not exist.
+func (uc *EmployeeUsecase) UpdateEmployee(ctx context.Context, payload dto.UpdateEmployeePayload) (*domain.Employee, error) {
+	e, err := uc.EmployeeRepo.GetEmployeeByID(ctx, payload.ID)
+	if err != nil || e == nil {
+		return nil, err
+	}
+
+	e.Name = payload.Name
+	e.Role = payload.Role
+	e.Status = payload.Status
+	if err := uc.EmployeeRepo.UpdateEmployee(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// authorizeEmployee checks that the employee exists, is active and holds a
+// role allowed to perform the action.
+func authorizeEmployee(ctx context.Context, er repository.EmployeeRepository, id string, action domain.EmployeeAction) error {
+	e, err := er.GetEmployeeByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if e == nil {
+		return fmt.Errorf("%w: %s", domain.ErrEmployeeNotFound, id)
+	}
+	return e.CanPerform(action)
+}
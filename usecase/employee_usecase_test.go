@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+
+	mockRepo "github.com/martinusiron/loan-service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateEmployee(t *testing.T) {
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	uc := NewEmployeeUsecase(mockEmployeeRepo)
+
+	mockEmployeeRepo.On("GetEmployeeByID", mock.Anything, "EMP001").Return(&domain.Employee{
+		ID: "EMP001", Name: "Andi", Role: domain.RoleFieldValidator, Status: domain.EmployeeActive,
+	}, nil)
+	mockEmployeeRepo.On("GetEmployeeByID", mock.Anything, "EMP404").Return(nil, nil)
+	mockEmployeeRepo.On("UpdateEmployee", mock.Anything, mock.MatchedBy(func(e *domain.Employee) bool {
+		return e.ID == "EMP001" && e.Role == domain.RoleFieldOfficer && e.Status == domain.EmployeeInactive
+	})).Return(nil)
+
+	e, err := uc.UpdateEmployee(context.TODO(), dto.UpdateEmployeePayload{
+		ID: "EMP001", Name: "Andi", Role: domain.RoleFieldOfficer, Status: domain.EmployeeInactive,
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, e.CanPerform(domain.ActionDisburseLoan), domain.ErrEmployeeInactive)
+
+	e, err = uc.UpdateEmployee(context.TODO(), dto.UpdateEmployeePayload{ID: "EMP404"})
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+	mockEmployeeRepo.AssertNumberOfCalls(t, "UpdateEmployee", 1)
+}
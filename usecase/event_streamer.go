@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/repository"
+)
+
+// EventStreamer queues every loan event for an event stream such as Kafka.
+// Subscribe Handle synchronously, so an event is only streamed if the change
+// raising it commits; EventStreamHandler then publishes it from the outbox.
+type EventStreamer struct {
+	OutboxRepo repository.OutboxRepository
+}
+
+func NewEventStreamer(or repository.OutboxRepository) *EventStreamer {
+	return &EventStreamer{OutboxRepo: or}
+}
+
+// Handle queues e under domain.TopicEvent, keyed by its loan so the events
+// of a loan are streamed in the order they happened.
+func (s *EventStreamer) Handle(ctx context.Context, e domain.Event) error {
+	rec, err := domain.NewEventRecord(e)
+	if err != nil {
+		return err
+	}
+	m, err := domain.NewOutboxMessage(domain.TopicEvent, strconv.Itoa(e.AggregateID()), rec, e.OccurredAt())
+	if err != nil {
+		return err
+	}
+	return s.OutboxRepo.Enqueue(ctx, m)
+}
+
+// EventStreamHandler publishes the events queued under domain.TopicEvent.
+// Each record gets the ID of its outbox message, which stays the same
+// across retries.
+func EventStreamHandler(stream repository.EventStream) OutboxHandler {
+	return func(ctx context.Context, m domain.OutboxMessage) error {
+		var rec domain.EventRecord
+		if err := json.Unmarshal(m.Payload, &rec); err != nil {
+			return permanentError{fmt.Errorf("decode event record: %w", err)}
+		}
+		rec.ID = m.ID
+		return stream.Stream(ctx, rec)
+	}
+}
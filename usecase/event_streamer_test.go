@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+
+	mockRepo "github.com/martinusiron/loan-service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEventStreamer_Handle(t *testing.T) {
+	at := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	mockOutboxRepo := new(mockRepo.OutboxRepository)
+	mockOutboxRepo.On("Enqueue", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+		var rec domain.EventRecord
+		if m.Topic != domain.TopicEvent || m.Key != "7" || json.Unmarshal(m.Payload, &rec) != nil {
+			return false
+		}
+		var data map[string]any
+		return rec.Name == domain.EventLoanApproved && rec.LoanID == 7 && rec.OccurredAt.Equal(at) &&
+			json.Unmarshal(rec.Data, &data) == nil && data["loan_id"] == float64(7)
+	})).Return(nil).Once()
+
+	err := NewEventStreamer(mockOutboxRepo).Handle(context.TODO(), domain.LoanApproved{LoanEvent: domain.NewLoanEvent(7, at)})
+	assert.NoError(t, err)
+	mockOutboxRepo.AssertExpectations(t)
+}
+
+func TestEventStreamHandler(t *testing.T) {
+	payload, _ := json.Marshal(domain.EventRecord{Name: domain.EventLoanDisbursed, LoanID: 7, Data: json.RawMessage(`{"loan_id":7}`)})
+
+	t.Run("streams the record with the message ID", func(t *testing.T) {
+		mockStream := new(mockRepo.EventStream)
+		mockStream.On("Stream", mock.Anything, mock.MatchedBy(func(rec domain.EventRecord) bool {
+			return rec.ID == 42 && rec.Name == domain.EventLoanDisbursed && rec.LoanID == 7
+		})).Return(nil).Once()
+
+		err := EventStreamHandler(mockStream)(context.TODO(), domain.OutboxMessage{ID: 42, Topic: domain.TopicEvent, Payload: payload})
+		assert.NoError(t, err)
+		mockStream.AssertExpectations(t)
+	})
+
+	t.Run("returns stream errors for retry", func(t *testing.T) {
+		mockStream := new(mockRepo.EventStream)
+		mockStream.On("Stream", mock.Anything, mock.Anything).Return(errors.New("broker down")).Once()
+
+		err := EventStreamHandler(mockStream)(context.TODO(), domain.OutboxMessage{ID: 42, Topic: domain.TopicEvent, Payload: payload})
+		assert.EqualError(t, err, "broker down")
+		assert.False(t, isPermanent(err))
+	})
+
+	t.Run("dead-letters undecodable records", func(t *testing.T) {
+		mockStream := new(mockRepo.EventStream)
+
+		err := EventStreamHandler(mockStream)(context.TODO(), domain.OutboxMessage{ID: 42, Topic: domain.TopicEvent, Payload: []byte("{")})
+		assert.True(t, isPermanent(err))
+		mockStream.AssertNotCalled(t, "Stream", mock.Anything, mock.Anything)
+	})
+}
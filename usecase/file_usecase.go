@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/repository"
+	"github.com/martinusiron/loan-service/storage"
+)
+
+// FileUsecase hands out signed download links for stored files and serves
+// the files behind them.
+type FileUsecase struct {
+	Blobs  repository.BlobStore
+	Signer *storage.URLSigner
+}
+
+func NewFileUsecase(blobs repository.BlobStore, signer *storage.URLSigner) *FileUsecase {
+	return &FileUsecase{Blobs: blobs, Signer: signer}
+}
+
+// DownloadURL returns a link to the file under key and when it expires.
+func (uc *FileUsecase) DownloadURL(key string) (string, time.Time) {
+	return uc.Signer.URL(key, time.Now())
+}
+
+// Open checks the link's signature and opens the file. The caller closes
+// the returned reader.
+func (uc *FileUsecase) Open(ctx context.Context, key, expires, signature string) (io.ReadCloser, *domain.BlobInfo, error) {
+	if err := uc.Signer.Verify(key, expires, signature, time.Now()); err != nil {
+		return nil, nil, err
+	}
+	return uc.Blobs.Get(ctx, key)
+}
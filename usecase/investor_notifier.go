@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/repository"
+)
+
+// InvestorNotifier queues the e-mails investors get when a loan they
+// invested in is funded, expires or is cancelled. Subscribe Handle
+// synchronously, so the e-mails are queued in the outbox in the same
+// transaction as the change and go out only if it commits.
+type InvestorNotifier struct {
+	OutboxRepo repository.OutboxRepository
+}
+
+func NewInvestorNotifier(or repository.OutboxRepository) *InvestorNotifier {
+	return &InvestorNotifier{OutboxRepo: or}
+}
+
+// InvestorNotifierEvents are the events Handle acts on.
+var InvestorNotifierEvents = []domain.EventName{
+	domain.EventLoanFullyFunded,
+	domain.EventLoanExpired,
+	domain.EventLoanCancelled,
+}
+
+func (n *InvestorNotifier) Handle(ctx context.Context, e domain.Event) error {
+	switch e := e.(type) {
+	case domain.LoanFullyFunded:
+		return n.queue(ctx, domain.NotifyLoanFunded, e, e.Investors)
+	case domain.LoanExpired:
+		return n.queue(ctx, domain.NotifyLoanExpired, e, e.Refunded)
+	case domain.LoanCancelled:
+		return n.queue(ctx, domain.NotifyLoanCancelled, e, e.Refunded)
+	}
+	return nil
+}
+
+// queue adds a notification of kind to the outbox for every investor. Each
+// investor's notifications about a loan go out in order, and independently
+// of the other investors'.
+func (n *InvestorNotifier) queue(ctx context.Context, kind domain.NotificationKind, e domain.Event, investors []domain.InvestorAmount) error {
+	for _, inv := range investors {
+		key := strconv.Itoa(e.AggregateID()) + "/" + inv.InvestorEmail
+		m, err := domain.NewOutboxMessage(domain.TopicNotification, key, domain.Notification{
+			Kind: kind,
+			To:   inv.InvestorEmail,
+			Data: map[string]any{"LoanID": e.AggregateID(), "Amount": inv.Amount.Format()},
+		}, e.OccurredAt())
+		if err != nil {
+			return err
+		}
+		if err := n.OutboxRepo.Enqueue(ctx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
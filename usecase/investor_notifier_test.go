@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+
+	mockRepo "github.com/martinusiron/loan-service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// queuedNotification matches the outbox message telling the investor about
+// loan 1 and their investment of amount.
+func queuedNotification(kind domain.NotificationKind, to, amount string) interface{} {
+	return mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+		var n domain.Notification
+		if m.Topic != domain.TopicNotification || m.Key != "1/"+to || json.Unmarshal(m.Payload, &n) != nil {
+			return false
+		}
+		return n.Kind == kind && n.To == to && n.Data["LoanID"] == float64(1) && n.Data["Amount"] == amount
+	})
+}
+
+func TestInvestorNotifier_Handle(t *testing.T) {
+	at := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	investors := []domain.InvestorAmount{
+		{InvestorEmail: "a@a.com", Amount: domain.MustParseMoney("600000", domain.CurrencyIDR)},
+		{InvestorEmail: "b@b.com", Amount: domain.MustParseMoney("400000", domain.CurrencyIDR)},
+	}
+
+	for _, tt := range []struct {
+		event domain.Event
+		kind  domain.NotificationKind
+	}{
+		{domain.LoanFullyFunded{LoanEvent: domain.NewLoanEvent(1, at), Investors: investors}, domain.NotifyLoanFunded},
+		{domain.LoanExpired{LoanEvent: domain.NewLoanEvent(1, at), Refunded: investors}, domain.NotifyLoanExpired},
+		{domain.LoanCancelled{LoanEvent: domain.NewLoanEvent(1, at), Refunded: investors}, domain.NotifyLoanCancelled},
+	} {
+		t.Run(string(tt.event.EventName()), func(t *testing.T) {
+			mockOutboxRepo := new(mockRepo.OutboxRepository)
+			mockOutboxRepo.On("Enqueue", mock.Anything, queuedNotification(tt.kind, "a@a.com", "IDR 600,000.00")).Return(nil).Once()
+			mockOutboxRepo.On("Enqueue", mock.Anything, queuedNotification(tt.kind, "b@b.com", "IDR 400,000.00")).Return(nil).Once()
+
+			err := NewInvestorNotifier(mockOutboxRepo).Handle(context.TODO(), tt.event)
+			assert.NoError(t, err)
+			mockOutboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInvestorNotifier_IgnoresOtherEvents(t *testing.T) {
+	mockOutboxRepo := new(mockRepo.OutboxRepository)
+
+	err := NewInvestorNotifier(mockOutboxRepo).Handle(context.TODO(), domain.LoanApproved{LoanEvent: domain.NewLoanEvent(1, time.Now())})
+	assert.NoError(t, err)
+	mockOutboxRepo.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/repository"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type InvestorUsecase struct {
+	InvestorRepo repository.InvestorRepository
+	DB           *sql.DB
+}
+
+func NewInvestorUsecase(ir repository.InvestorRepository, db *sql.DB) *InvestorUsecase {
+	return &InvestorUsecase{InvestorRepo: ir, DB: db}
+}
+
+// RegisterInvestor signs up an investor with KYC pending review.
+func (uc *InvestorUsecase) RegisterInvestor(ctx context.Context, payload dto.RegisterInvestorPayload) (*domain.Investor, error) {
+	i := &domain.Investor{
+		Email:          payload.Email,
+		Name:           payload.Name,
+		Phone:          payload.Phone,
+		IdentityNumber: payload.IdentityNumber,
+		KYCStatus:      domain.KYCPending,
+	}
+	if err := uc.InvestorRepo.CreateInvestor(ctx, i); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (uc *InvestorUsecase) GetInvestor(ctx context.Context, email string) (*domain.Investor, error) {
+	return uc.InvestorRepo.GetInvestorByEmail(ctx, email)
+}
+
+// ListInvestors returns one page of investors, optionally filtered by KYC
+// status so reviewers can work through the pending queue.
+func (uc *InvestorUsecase) ListInvestors(ctx context.Context, q dto.ListInvestorsQuery) (*dto.InvestorPage, error) {
+	f := domain.InvestorFilter{
+		KYCStatus: domain.KYCStatus(q.KYCStatus),
+		Limit:     domain.DefaultPageSize,
+	}
+	if q.Limit > 0 {
+		f.Limit = min(q.Limit, domain.MaxPageSize)
+	}
+	if q.Cursor != "" {
+		after, err := domain.DecodeKeyCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		f.AfterKey = after
+	}
+
+	limit := f.Limit
+	f.Limit++
+	investors, err := uc.InvestorRepo.ListInvestors(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.InvestorPage{Data: investors}
+	if len(investors) > limit {
+		page.Data = investors[:limit]
+		page.NextCursor = domain.EncodeKeyCursor(page.Data[limit-1].Email)
+	}
+	return page, nil
+}
+
+// ReviewKYC moves the investor to the reviewed KYC status, or returns nil if
+// the investor is not registered.
+func (uc *InvestorUsecase) ReviewKYC(ctx context.Context, payload dto.ReviewKYCPayload) (*domain.Investor, error) {
+	var investor *domain.Investor
+	err := utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
+		i, err := uc.InvestorRepo.GetInvestorByEmailForUpdate(txCtx, payload.Email)
+		if err != nil || i == nil {
+			return err
+		}
+		if err := domain.ValidateKYCTransition(i.KYCStatus, payload.Status); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		i.KYCStatus = payload.Status
+		i.KYCNote = payload.Note
+		i.KYCReviewedBy = payload.ReviewerID
+		i.KYCReviewedAt = &now
+		if err := uc.InvestorRepo.UpdateKYC(txCtx, i); err != nil {
+			return err
+		}
+		investor = i
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return investor, nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+
+	mockRepo "github.com/martinusiron/loan-service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterInvestor_StartsPending(t *testing.T) {
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	uc := NewInvestorUsecase(mockInvestorRepo, newTestDB())
+
+	mockInvestorRepo.On("CreateInvestor", mock.Anything, mock.MatchedBy(func(i *domain.Investor) bool {
+		return i.Email == "a@a.com" && i.KYCStatus == domain.KYCPending
+	})).Return(nil)
+
+	i, err := uc.RegisterInvestor(context.TODO(), dto.RegisterInvestorPayload{
+		Email:          "a@a.com",
+		Name:           "Budi",
+		Phone:          "081200000001",
+		IdentityNumber: "3174012501900002",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.KYCPending, i.KYCStatus)
+}
+
+func TestReviewKYC(t *testing.T) {
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	uc := NewInvestorUsecase(mockInvestorRepo, newTestDB())
+
+	mockInvestorRepo.On("GetInvestorByEmailForUpdate", mock.Anything, "a@a.com").Return(&domain.Investor{Email: "a@a.com", KYCStatus: domain.KYCPending}, nil)
+	mockInvestorRepo.On("GetInvestorByEmailForUpdate", mock.Anything, "b@b.com").Return(&domain.Investor{Email: "b@b.com", KYCStatus: domain.KYCRejected}, nil)
+	mockInvestorRepo.On("GetInvestorByEmailForUpdate", mock.Anything, "none@a.com").Return(nil, nil)
+	mockInvestorRepo.On("UpdateKYC", mock.Anything, mock.MatchedBy(func(i *domain.Investor) bool {
+		return i.Email == "a@a.com" && i.KYCStatus == domain.KYCVerified && i.KYCReviewedBy == "EMP010" && i.KYCReviewedAt != nil
+	})).Return(nil)
+
+	i, err := uc.ReviewKYC(context.TODO(), dto.ReviewKYCPayload{Email: "a@a.com", Status: domain.KYCVerified, ReviewerID: "EMP010"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.KYCVerified, i.KYCStatus)
+
+	_, err = uc.ReviewKYC(context.TODO(), dto.ReviewKYCPayload{Email: "b@b.com", Status: domain.KYCVerified, ReviewerID: "EMP010"})
+	assert.ErrorIs(t, err, domain.ErrInvalidKYCTransition)
+
+	i, err = uc.ReviewKYC(context.TODO(), dto.ReviewKYCPayload{Email: "none@a.com", Status: domain.KYCVerified, ReviewerID: "EMP010"})
+	assert.NoError(t, err)
+	assert.Nil(t, i)
+	mockInvestorRepo.AssertNumberOfCalls(t, "UpdateKYC", 1)
+}
@@ -2,13 +2,22 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/martinusiron/loan-service/agreement"
 	"github.com/martinusiron/loan-service/domain"
 	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/ledger"
 	"github.com/martinusiron/loan-service/repository"
 	"github.com/martinusiron/loan-service/utils"
 )
@@ -16,17 +25,85 @@ import (
 type LoanUsecase struct {
 	LoanRepo       repository.LoanRepository
 	ApprovalRepo   repository.ApprovalRepository
+	RejectionRepo  repository.RejectionRepository
 	InvestmentRepo repository.InvestmentRepository
+	HistoryRepo    repository.LoanHistoryRepository
+	ScheduleRepo   repository.ScheduleRepository
+	LedgerRepo     repository.LedgerRepository
+	WalletRepo     repository.WalletRepository
+	BorrowerRepo   repository.BorrowerRepository
+	InvestorRepo   repository.InvestorRepository
+	EmployeeRepo   repository.EmployeeRepository
+	AgreementRepo  repository.AgreementRepository
+	Blobs          repository.BlobStore
+	Events         repository.EventPublisher
 	DB             *sql.DB
+
+	// FundingPeriod is how long an approved loan stays open for investment
+	// when the approval does not name a funding deadline.
+	FundingPeriod time.Duration
+	// MaxPictureProofSize caps the size of the photo uploaded on approval.
+	MaxPictureProofSize int64
+	// Letters renders the agreement letters generated on disbursement.
+	Letters *agreement.Renderer
+	// AgreementBaseURL is put in front of the agreements path to form the
+	// agreement letter link of disbursed loans; empty leaves it relative.
+	AgreementBaseURL string
 }
 
-func NewLoanUsecase(lr repository.LoanRepository, ar repository.ApprovalRepository, ir repository.InvestmentRepository, db *sql.DB) *LoanUsecase {
+func NewLoanUsecase(lr repository.LoanRepository, ar repository.ApprovalRepository, rr repository.RejectionRepository, ir repository.InvestmentRepository, hr repository.LoanHistoryRepository, sr repository.ScheduleRepository, lg repository.LedgerRepository, wr repository.WalletRepository, br repository.BorrowerRepository, inr repository.InvestorRepository, er repository.EmployeeRepository, agr repository.AgreementRepository, blobs repository.BlobStore, events repository.EventPublisher, db *sql.DB) *LoanUsecase {
 	return &LoanUsecase{
 		LoanRepo:       lr,
 		ApprovalRepo:   ar,
+		RejectionRepo:  rr,
 		InvestmentRepo: ir,
+		HistoryRepo:    hr,
+		ScheduleRepo:   sr,
+		LedgerRepo:     lg,
+		WalletRepo:     wr,
+		BorrowerRepo:   br,
+		InvestorRepo:   inr,
+		EmployeeRepo:   er,
+		AgreementRepo:  agr,
+		Blobs:          blobs,
+		Events:         events,
 		DB:             db,
+
+		FundingPeriod:       domain.DefaultFundingPeriod,
+		MaxPictureProofSize: domain.DefaultMaxPictureProofSize,
+		Letters:             agreement.Default(),
+	}
+}
+
+// transition validates a status change against the domain state machine,
+// persists it and appends it to the loan's history trail.
+func (uc *LoanUsecase) transition(ctx context.Context, loan *domain.Loan, to domain.LoanStatus, tc domain.TransitionContext, actor string, metadata map[string]any) error {
+	return transitionLoan(ctx, uc.LoanRepo, uc.HistoryRepo, loan, to, tc, actor, metadata)
+}
+
+// transitionLoan is shared by every usecase that moves a loan between
+// statuses, so all of them go through the same checks and history trail.
+func transitionLoan(ctx context.Context, lr repository.LoanRepository, hr repository.LoanHistoryRepository, loan *domain.Loan, to domain.LoanStatus, tc domain.TransitionContext, actor string, metadata map[string]any) error {
+	tc.Loan = loan
+	if err := domain.ValidateTransition(loan.Status, to, tc); err != nil {
+		return err
+	}
+
+	if err := lr.UpdateLoanStatus(ctx, loan.ID, to); err != nil {
+		return err
 	}
+
+	from := loan.Status
+	loan.Status = to
+
+	return hr.RecordStatusChange(ctx, &domain.LoanStatusHistory{
+		LoanID:     loan.ID,
+		FromStatus: from,
+		ToStatus:   to,
+		Actor:      actor,
+		Metadata:   metadata,
+		ChangedAt:  time.Now(),
+	})
 }
 
 func (uc *LoanUsecase) CreateLoan(ctx context.Context, payload dto.CreateLoanPayload) (*domain.Loan, error) {
@@ -35,12 +112,53 @@ func (uc *LoanUsecase) CreateLoan(ctx context.Context, payload dto.CreateLoanPay
 		PrincipalAmount: payload.PrincipalAmount,
 		Rate:            payload.Rate,
 		ROI:             payload.ROI,
+		TenorMonths:     payload.TenorMonths,
+		RepaymentMethod: domain.RepaymentMethod(payload.RepaymentMethod),
 		Status:          domain.StatusProposed,
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
 	}
 
-	if err := uc.LoanRepo.CreateLoan(ctx, loan); err != nil {
+	if loan.RepaymentMethod == "" {
+		loan.RepaymentMethod = domain.DefaultRepaymentMethod
+	}
+
+	err := utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
+		borrower, err := uc.BorrowerRepo.GetBorrowerByID(txCtx, payload.BorrowerID)
+		if err != nil {
+			return err
+		}
+		if borrower == nil {
+			return fmt.Errorf("%w: %s", domain.ErrBorrowerNotFound, payload.BorrowerID)
+		}
+		if borrower.Status != domain.BorrowerActive {
+			return fmt.Errorf("%w: %s", domain.ErrBorrowerInactive, payload.BorrowerID)
+		}
+
+		if err := uc.LoanRepo.CreateLoan(txCtx, loan); err != nil {
+			return err
+		}
+
+		if err := uc.HistoryRepo.RecordStatusChange(txCtx, &domain.LoanStatusHistory{
+			LoanID:    loan.ID,
+			ToStatus:  domain.StatusProposed,
+			Actor:     payload.BorrowerID,
+			ChangedAt: loan.CreatedAt,
+		}); err != nil {
+			return err
+		}
+
+		return uc.Events.Publish(txCtx, domain.LoanCreated{
+			LoanEvent:       domain.NewLoanEvent(loan.ID, loan.CreatedAt),
+			BorrowerID:      loan.BorrowerID,
+			PrincipalAmount: loan.PrincipalAmount,
+			Rate:            loan.Rate,
+			ROI:             loan.ROI,
+			TenorMonths:     loan.TenorMonths,
+			RepaymentMethod: loan.RepaymentMethod,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -48,92 +166,723 @@ func (uc *LoanUsecase) CreateLoan(ctx context.Context, payload dto.CreateLoanPay
 }
 
 func (uc *LoanUsecase) ApproveLoan(ctx context.Context, payload dto.ApproveLoanPayload) error {
+	// The photo is stored before the transaction, since the blob store
+	// cannot take part in it, and removed again if the approval fails.
+	proof, err := uc.storePictureProof(ctx, payload.LoanID, payload.PictureProof.Data)
+	if err != nil {
+		return err
+	}
+
+	err = utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
+		if err := authorizeEmployee(txCtx, uc.EmployeeRepo, payload.EmployeeID, domain.ActionApproveLoan); err != nil {
+			return err
+		}
+
+		loan, err := uc.LoanRepo.GetLoanByIDForUpdate(txCtx, payload.LoanID)
+		if err != nil || loan == nil {
+			return errors.New("loan not found")
+		}
+
+		deadline := time.Now().Add(uc.FundingPeriod)
+		if payload.FundingDeadline != nil {
+			deadline = *payload.FundingDeadline
+		}
+
+		if err := uc.transition(txCtx, loan, domain.StatusApproved, domain.TransitionContext{}, payload.EmployeeID, map[string]any{
+			"picture_proof":        proof.PictureProofKey,
+			"picture_proof_sha256": proof.PictureProofChecksum,
+			"approved_at":          payload.Date.Format("2006-01-02"),
+			"funding_deadline":     deadline.Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+
+		if err := uc.LoanRepo.SetFundingDeadline(txCtx, loan.ID, deadline); err != nil {
+			return err
+		}
+
+		proof.EmployeeID = payload.EmployeeID
+		proof.ApprovedAt = payload.Date
+		if err := uc.ApprovalRepo.CreateApproval(txCtx, proof); err != nil {
+			return err
+		}
+
+		return uc.Events.Publish(txCtx, domain.LoanApproved{
+			LoanEvent:       domain.NewLoanEvent(loan.ID, time.Now()),
+			EmployeeID:      payload.EmployeeID,
+			ApprovedAt:      payload.Date,
+			FundingDeadline: deadline,
+		})
+	})
+	if err != nil {
+		if derr := uc.Blobs.Delete(context.WithoutCancel(ctx), proof.PictureProofKey); derr != nil {
+			log.Printf("failed to remove picture proof %s of failed approval: %v", proof.PictureProofKey, derr)
+		}
+		return err
+	}
+	return nil
+}
+
+// storePictureProof checks that data is an image of an accepted type and
+// size, stores it under a new key and returns an approval carrying the key,
+// checksum and content type.
+func (uc *LoanUsecase) storePictureProof(ctx context.Context, loanID int, data []byte) (*domain.LoanApproval, error) {
+	if int64(len(data)) > uc.MaxPictureProofSize {
+		return nil, fmt.Errorf("%w: %d bytes, at most %d allowed", domain.ErrPictureProofTooLarge, len(data), uc.MaxPictureProofSize)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", domain.ErrPictureProofType)
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := domain.PictureProofTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: got %s", domain.ErrPictureProofType, contentType)
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	proof := &domain.LoanApproval{
+		LoanID:                  loanID,
+		PictureProofKey:         fmt.Sprintf("picture-proofs/loan-%d/%s%s", loanID, hex.EncodeToString(suffix), ext),
+		PictureProofChecksum:    hex.EncodeToString(sum[:]),
+		PictureProofContentType: contentType,
+	}
+	if err := uc.Blobs.Put(ctx, proof.PictureProofKey, data, contentType); err != nil {
+		return nil, fmt.Errorf("store picture proof: %w", err)
+	}
+	return proof, nil
+}
+
+// GetApproval returns the approval record of a loan, or nil when the loan
+// was never approved.
+func (uc *LoanUsecase) GetApproval(ctx context.Context, loanID int) (*domain.LoanApproval, error) {
+	return uc.ApprovalRepo.GetApprovalByLoanID(ctx, loanID)
+}
+
+func (uc *LoanUsecase) RejectLoan(ctx context.Context, payload dto.RejectLoanPayload) error {
 	return utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
-		loan, err := uc.LoanRepo.GetLoanByID(txCtx, payload.LoanID)
+		if err := authorizeEmployee(txCtx, uc.EmployeeRepo, payload.EmployeeID, domain.ActionRejectLoan); err != nil {
+			return err
+		}
+
+		loan, err := uc.LoanRepo.GetLoanByIDForUpdate(txCtx, payload.LoanID)
 		if err != nil || loan == nil {
 			return errors.New("loan not found")
 		}
 
-		if loan.Status != domain.StatusProposed {
-			return errors.New("loan is not in proposed state")
+		if err := uc.transition(txCtx, loan, domain.StatusRejected, domain.TransitionContext{}, payload.EmployeeID, map[string]any{
+			"reason_code": payload.ReasonCode,
+			"note":        payload.Note,
+			"rejected_at": payload.Date.Format("2006-01-02"),
+		}); err != nil {
+			return err
 		}
 
-		if err := uc.ApprovalRepo.CreateApproval(txCtx, &domain.LoanApproval{
-			LoanID:       payload.LoanID,
-			PictureProof: payload.PictureProof,
-			EmployeeID:   payload.EmployeeID,
-			ApprovedAt:   payload.Date,
+		if err := uc.RejectionRepo.CreateRejection(txCtx, &domain.LoanRejection{
+			LoanID:     payload.LoanID,
+			EmployeeID: payload.EmployeeID,
+			ReasonCode: payload.ReasonCode,
+			Note:       payload.Note,
+			RejectedAt: payload.Date,
 		}); err != nil {
 			return err
 		}
 
-		return uc.LoanRepo.UpdateLoanStatus(txCtx, payload.LoanID, domain.StatusApproved)
+		return uc.Events.Publish(txCtx, domain.LoanRejected{
+			LoanEvent:  domain.NewLoanEvent(loan.ID, time.Now()),
+			EmployeeID: payload.EmployeeID,
+			ReasonCode: payload.ReasonCode,
+			RejectedAt: payload.Date,
+		})
 	})
 }
 
 func (uc *LoanUsecase) InvestLoan(ctx context.Context, payload dto.InvestLoanPayload) error {
 	return utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
-		loan, err := uc.LoanRepo.GetLoanByID(txCtx, payload.LoanID)
+		loan, err := uc.LoanRepo.GetLoanByIDForUpdate(txCtx, payload.LoanID)
 		if err != nil || loan == nil {
 			return errors.New("loan not found")
 		}
 
-		if loan.Status != domain.StatusApproved && loan.Status != domain.StatusInvested {
+		if loan.Status != domain.StatusApproved {
 			return errors.New("loan not available for investment")
 		}
+		if loan.FundingClosed(time.Now()) {
+			return domain.ErrFundingClosed
+		}
+
+		investor, err := uc.InvestorRepo.GetInvestorByEmail(txCtx, payload.InvestorEmail)
+		if err != nil {
+			return err
+		}
+		if investor == nil {
+			return &domain.KYCError{InvestorEmail: payload.InvestorEmail}
+		}
+		if err := investor.CanInvest(); err != nil {
+			return err
+		}
 
 		totalInvested, err := uc.InvestmentRepo.GetTotalInvested(txCtx, payload.LoanID)
 		if err != nil {
 			return err
 		}
 
-		if totalInvested+payload.Amount > loan.PrincipalAmount {
+		if err := payload.Amount.CheckCurrency(loan.PrincipalAmount); err != nil {
+			return err
+		}
+
+		newTotal := totalInvested.Add(payload.Amount)
+		if newTotal.Cmp(loan.PrincipalAmount) > 0 {
 			return fmt.Errorf("investment exceeds loan principal")
 		}
 
+		if err := placeHold(txCtx, uc.WalletRepo, payload.InvestorEmail, payload.LoanID, payload.Amount); err != nil {
+			return err
+		}
+		investedAt := time.Now()
 		if err := uc.InvestmentRepo.AddInvestment(txCtx, &domain.Investment{
 			LoanID:        payload.LoanID,
 			InvestorEmail: payload.InvestorEmail,
 			Amount:        payload.Amount,
-			InvestedAt:    time.Now(),
+			InvestedAt:    investedAt,
+		}); err != nil {
+			return err
+		}
+		if err := uc.Events.Publish(txCtx, domain.InvestmentAdded{
+			LoanEvent:     domain.NewLoanEvent(loan.ID, investedAt),
+			InvestorEmail: payload.InvestorEmail,
+			Amount:        payload.Amount,
+			TotalInvested: newTotal,
 		}); err != nil {
 			return err
 		}
 
-		newTotal := totalInvested + payload.Amount
-		if newTotal == loan.PrincipalAmount && loan.Status != domain.StatusInvested {
-			if err := uc.LoanRepo.UpdateLoanStatus(txCtx, payload.LoanID, domain.StatusInvested); err != nil {
+		tc := domain.TransitionContext{Loan: loan, TotalInvested: newTotal}
+		if domain.ValidateTransition(loan.Status, domain.StatusInvested, tc) == nil {
+			if err := uc.transition(txCtx, loan, domain.StatusInvested, tc, payload.InvestorEmail, map[string]any{
+				"total_invested": newTotal.String(),
+			}); err != nil {
 				return err
 			}
-			investors, _ := uc.InvestmentRepo.GetInvestorsByLoan(txCtx, payload.LoanID)
-			for _, inv := range investors {
-				go utils.SendDummyEmail(inv.InvestorEmail, loan.ID)
+			investors, err := uc.InvestmentRepo.GetInvestorsByLoan(txCtx, payload.LoanID)
+			if err != nil {
+				return err
 			}
+			return uc.Events.Publish(txCtx, domain.LoanFullyFunded{
+				LoanEvent:       domain.NewLoanEvent(loan.ID, investedAt),
+				PrincipalAmount: loan.PrincipalAmount,
+				Investors:       domain.InvestorAmounts(investors),
+			})
 		}
 		return nil
 	})
 }
 
 func (uc *LoanUsecase) DisburseLoan(ctx context.Context, payload dto.DisburseLoanPayload) error {
-	return utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
-		loan, err := uc.LoanRepo.GetLoanByID(txCtx, payload.LoanID)
+	// Agreement letters are stored while the loan is locked, so they match
+	// what is committed, and removed again if the disbursement fails.
+	var letterKeys []string
+	err := utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
+		if err := authorizeEmployee(txCtx, uc.EmployeeRepo, payload.EmployeeID, domain.ActionDisburseLoan); err != nil {
+			return err
+		}
+
+		loan, err := uc.LoanRepo.GetLoanByIDForUpdate(txCtx, payload.LoanID)
 		if err != nil || loan == nil {
 			return errors.New("loan not found")
 		}
 
-		if loan.Status != domain.StatusInvested {
-			return errors.New("loan is not ready for disbursement")
+		link := uc.agreementLink(loan.ID)
+		if err := uc.transition(txCtx, loan, domain.StatusDisbursed, domain.TransitionContext{}, payload.EmployeeID, map[string]any{
+			"agreement_letter_link": link,
+			"disbursed_at":          payload.Date.Format("2006-01-02"),
+		}); err != nil {
+			return err
 		}
 
-		if err := uc.LoanRepo.SetAgreementLink(txCtx, payload.LoanID, payload.AgreementLink); err != nil {
+		installments, err := domain.GenerateSchedule(*loan, payload.Date)
+		if err != nil {
+			return err
+		}
+		if err := uc.ScheduleRepo.CreateInstallments(txCtx, installments); err != nil {
+			return err
+		}
+
+		if err := uc.storeAgreements(txCtx, loan, installments, payload.Date, &letterKeys); err != nil {
+			return err
+		}
+		if err := uc.LoanRepo.SetAgreementLink(txCtx, payload.LoanID, link); err != nil {
 			return err
 		}
 
-		return uc.LoanRepo.UpdateLoanStatus(txCtx, payload.LoanID, domain.StatusDisbursed)
+		if err := captureLoanHolds(txCtx, uc.WalletRepo, uc.LedgerRepo, loan.ID); err != nil {
+			return err
+		}
+		if err := uc.LedgerRepo.PostEntry(txCtx, ledger.DisbursementEntry(*loan)); err != nil {
+			return err
+		}
+
+		return uc.Events.Publish(txCtx, domain.LoanDisbursed{
+			LoanEvent:     domain.NewLoanEvent(loan.ID, time.Now()),
+			EmployeeID:    payload.EmployeeID,
+			DisbursedAt:   payload.Date,
+			AgreementLink: link,
+		})
+	})
+	if err != nil {
+		for _, key := range letterKeys {
+			if derr := uc.Blobs.Delete(context.WithoutCancel(ctx), key); derr != nil {
+				log.Printf("failed to remove agreement letter %s of failed disbursement: %v", key, derr)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// agreementLinkPath is where a disbursed loan's agreement letters are
+// listed; see Handler.GetAgreements.
+const agreementLinkPath = "/v1/loans/%d/agreements"
+
+// agreementLink is the link stored as the loan's agreement letter link.
+func (uc *LoanUsecase) agreementLink(loanID int) string {
+	return strings.TrimSuffix(uc.AgreementBaseURL, "/") + fmt.Sprintf(agreementLinkPath, loanID)
+}
+
+// storeAgreements renders the agreement letters of a loan being disbursed,
+// stores them in the blob store and records them. The key of every stored
+// letter is appended to keys, so the caller can remove them if the
+// disbursement fails.
+func (uc *LoanUsecase) storeAgreements(ctx context.Context, loan *domain.Loan, schedule []domain.Installment, disbursedAt time.Time, keys *[]string) error {
+	borrower, err := uc.BorrowerRepo.GetBorrowerByID(ctx, loan.BorrowerID)
+	if err != nil {
+		return err
+	}
+	if borrower == nil {
+		return fmt.Errorf("%w: %s", domain.ErrBorrowerNotFound, loan.BorrowerID)
+	}
+	investments, err := uc.InvestmentRepo.GetInvestorsByLoan(ctx, loan.ID)
+	if err != nil {
+		return err
+	}
+	investors := map[string]domain.Investor{}
+	for _, inv := range investments {
+		if _, ok := investors[inv.InvestorEmail]; ok {
+			continue
+		}
+		investor, err := uc.InvestorRepo.GetInvestorByEmail(ctx, inv.InvestorEmail)
+		if err != nil {
+			return err
+		}
+		if investor != nil {
+			investors[inv.InvestorEmail] = *investor
+		}
+	}
+
+	letters, err := uc.Letters.Render(agreement.Terms{
+		Loan:        *loan,
+		Borrower:    *borrower,
+		Investments: investments,
+		Investors:   investors,
+		Schedule:    schedule,
+		DisbursedAt: disbursedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, l := range letters {
+		name := string(l.Party)
+		if l.InvestorEmail != "" {
+			// Keys end up in download links, so they name investors by a
+			// hash of their email rather than the email itself.
+			sum := sha256.Sum256([]byte(l.InvestorEmail))
+			name += "-" + hex.EncodeToString(sum[:8])
+		}
+		key := fmt.Sprintf("agreements/loan-%d/%s.%s", loan.ID, name, l.Format)
+		if err := uc.Blobs.Put(ctx, key, l.Data, l.ContentType); err != nil {
+			return fmt.Errorf("store agreement letter: %w", err)
+		}
+		*keys = append(*keys, key)
+
+		sum := sha256.Sum256(l.Data)
+		if err := uc.AgreementRepo.CreateAgreement(ctx, &domain.Agreement{
+			LoanID:        loan.ID,
+			Party:         l.Party,
+			InvestorEmail: l.InvestorEmail,
+			Format:        l.Format,
+			Key:           key,
+			Checksum:      hex.EncodeToString(sum[:]),
+			ContentType:   l.ContentType,
+			CreatedAt:     now,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAgreements returns the agreement letters generated when the loan was
+// disbursed.
+func (uc *LoanUsecase) GetAgreements(ctx context.Context, loanID int) ([]domain.Agreement, error) {
+	return uc.AgreementRepo.ListAgreementsByLoan(ctx, loanID)
+}
+
+// ExpireOverdueLoans moves approved loans whose funding deadline has passed
+// without being fully funded to expired, releases the investors' wallet holds
+// and marks their investments refunded. Each loan is expired in its own
+// transaction so one failure does not hold back the rest. It returns the
+// number of loans expired.
+func (uc *LoanUsecase) ExpireOverdueLoans(ctx context.Context, now time.Time) (int, error) {
+	ids, err := uc.LoanRepo.ListLoanIDsPastFundingDeadline(ctx, now, expiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	var errs []error
+	for _, id := range ids {
+		ok, err := uc.expireLoan(ctx, id, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("expire loan %d: %w", id, err))
+			continue
+		}
+		if ok {
+			expired++
+		}
+	}
+	return expired, errors.Join(errs...)
+}
+
+// expiryBatchSize caps how many loans one expiry run picks up.
+const expiryBatchSize = 100
+
+func (uc *LoanUsecase) expireLoan(ctx context.Context, id int, now time.Time) (bool, error) {
+	var loan *domain.Loan
+	err := utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
+		l, err := uc.LoanRepo.GetLoanByIDForUpdate(txCtx, id)
+		if err != nil || l == nil {
+			return errors.New("loan not found")
+		}
+		// The loan may have been funded or expired since it was listed.
+		if l.Status != domain.StatusApproved || !l.FundingClosed(now) {
+			return nil
+		}
+
+		totalInvested, err := uc.InvestmentRepo.GetTotalInvested(txCtx, id)
+		if err != nil {
+			return err
+		}
+		tc := domain.TransitionContext{TotalInvested: totalInvested, Now: now}
+		if err := uc.transition(txCtx, l, domain.StatusExpired, tc, "system", map[string]any{
+			"funding_deadline": l.FundingDeadline.Format(time.RFC3339),
+			"total_invested":   totalInvested.String(),
+		}); err != nil {
+			return err
+		}
+
+		investors, err := uc.refundInvestments(txCtx, id, now)
+		if err != nil {
+			return err
+		}
+		if err := uc.Events.Publish(txCtx, domain.LoanExpired{
+			LoanEvent:       domain.NewLoanEvent(id, now),
+			FundingDeadline: *l.FundingDeadline,
+			TotalInvested:   totalInvested,
+			Refunded:        domain.InvestorAmounts(investors),
+		}); err != nil {
+			return err
+		}
+		loan = l
+		return nil
+	})
+	if err != nil || loan == nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// refundInvestments gives the investors' held funds back and marks their
+// investments in the loan refunded. It returns the refunded investments.
+func (uc *LoanUsecase) refundInvestments(ctx context.Context, loanID int, at time.Time) ([]domain.Investment, error) {
+	if err := releaseLoanHolds(ctx, uc.WalletRepo, loanID); err != nil {
+		return nil, err
+	}
+	investors, err := uc.InvestmentRepo.GetInvestorsByLoan(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.InvestmentRepo.MarkRefunded(ctx, loanID, at); err != nil {
+		return nil, err
+	}
+	return investors, nil
+}
+
+// CancelLoan withdraws a loan that has not been disbursed yet. Any
+// investments in it are refunded to the investors' wallets.
+func (uc *LoanUsecase) CancelLoan(ctx context.Context, payload dto.CancelLoanPayload) error {
+	return utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
+		loan, err := uc.LoanRepo.GetLoanByIDForUpdate(txCtx, payload.LoanID)
+		if err != nil || loan == nil {
+			return errors.New("loan not found")
+		}
+
+		if err := uc.transition(txCtx, loan, domain.StatusCancelled, domain.TransitionContext{}, payload.Actor, map[string]any{
+			"reason": payload.Reason,
+		}); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		investors, err := uc.refundInvestments(txCtx, loan.ID, now)
+		if err != nil {
+			return err
+		}
+		return uc.Events.Publish(txCtx, domain.LoanCancelled{
+			LoanEvent: domain.NewLoanEvent(loan.ID, now),
+			Actor:     payload.Actor,
+			Reason:    payload.Reason,
+			Refunded:  domain.InvestorAmounts(investors),
+		})
 	})
 }
 
 func (uc *LoanUsecase) GetLoan(ctx context.Context, id int) (*domain.Loan, error) {
-	
+
 	return uc.LoanRepo.GetLoanByID(ctx, id)
 }
+
+// ListLoans returns one page of loans matching the query together with the
+// cursor for the next page, if there is one.
+func (uc *LoanUsecase) ListLoans(ctx context.Context, q dto.ListLoansQuery) (*dto.LoanPage, error) {
+	filter, err := buildLoanFilter(q)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra row to learn whether another page follows.
+	limit := filter.Limit
+	filter.Limit++
+	loans, err := uc.LoanRepo.ListLoans(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.LoanPage{Data: loans}
+	if len(loans) > limit {
+		page.Data = loans[:limit]
+		page.NextCursor = domain.NewLoanCursor(page.Data[limit-1], filter.SortBy, filter.SortDesc).Encode()
+	}
+	return page, nil
+}
+
+func buildLoanFilter(q dto.ListLoansQuery) (domain.LoanFilter, error) {
+	f := domain.LoanFilter{
+		BorrowerID:    q.BorrowerID,
+		InvestorEmail: q.InvestorEmail,
+		MinRate:       q.MinRate,
+		MaxRate:       q.MaxRate,
+		SortBy:        domain.SortByCreatedAt,
+		SortDesc:      q.Order != "asc",
+		Limit:         domain.DefaultPageSize,
+	}
+
+	if q.SortBy != "" {
+		f.SortBy = domain.LoanSortField(q.SortBy)
+	}
+	if q.Limit > 0 {
+		f.Limit = min(q.Limit, domain.MaxPageSize)
+	}
+
+	for _, raw := range q.Status {
+		for _, st := range strings.Split(raw, ",") {
+			if st = strings.TrimSpace(st); st == "" {
+				continue
+			}
+			status := domain.LoanStatus(st)
+			if !status.IsValid() {
+				return f, fmt.Errorf("%w: unknown status %q", domain.ErrInvalidFilter, st)
+			}
+			f.Statuses = append(f.Statuses, status)
+		}
+	}
+
+	for _, bound := range []struct {
+		raw string
+		dst **domain.Money
+	}{
+		{q.MinPrincipal, &f.MinPrincipal},
+		{q.MaxPrincipal, &f.MaxPrincipal},
+	} {
+		if bound.raw == "" {
+			continue
+		}
+		m, err := domain.ParseMoney(bound.raw, domain.DefaultCurrency)
+		if err != nil {
+			return f, fmt.Errorf("%w: %v", domain.ErrInvalidFilter, err)
+		}
+		*bound.dst = &m
+	}
+
+	for _, bound := range []struct {
+		raw string
+		dst **time.Time
+	}{
+		{q.CreatedFrom, &f.CreatedFrom},
+		{q.CreatedTo, &f.CreatedTo},
+	} {
+		if bound.raw == "" {
+			continue
+		}
+		t, err := parseTimeBound(bound.raw)
+		if err != nil {
+			return f, fmt.Errorf("%w: %v", domain.ErrInvalidFilter, err)
+		}
+		*bound.dst = &t
+	}
+
+	if q.Cursor != "" {
+		cursor, err := domain.DecodeLoanCursor(q.Cursor)
+		if err != nil {
+			return f, err
+		}
+		if cursor.SortBy != f.SortBy || cursor.SortDesc != f.SortDesc {
+			return f, fmt.Errorf("%w: cursor was issued for a different sort order", domain.ErrInvalidFilter)
+		}
+		f.After = cursor
+	}
+
+	return f, nil
+}
+
+// parseTimeBound accepts either a date (YYYY-MM-DD) or an RFC 3339 timestamp.
+func parseTimeBound(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// ListLoanInvestments returns one page of the investments funding a loan, or
+// nil if the loan does not exist.
+func (uc *LoanUsecase) ListLoanInvestments(ctx context.Context, loanID int, q dto.PageQuery) (*dto.InvestmentPage, error) {
+	loan, err := uc.LoanRepo.GetLoanByID(ctx, loanID)
+	if err != nil || loan == nil {
+		return nil, err
+	}
+
+	return uc.listInvestments(ctx, domain.InvestmentFilter{LoanID: loanID}, q)
+}
+
+// HasInvested reports whether the investor has invested in the loan.
+func (uc *LoanUsecase) HasInvested(ctx context.Context, loanID int, email string) (bool, error) {
+	investments, err := uc.InvestmentRepo.GetInvestorsByLoan(ctx, loanID)
+	if err != nil {
+		return false, err
+	}
+	for _, i := range investments {
+		if strings.EqualFold(i.InvestorEmail, email) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ListInvestorInvestments returns one page of an investor's portfolio.
+func (uc *LoanUsecase) ListInvestorInvestments(ctx context.Context, email string, q dto.PageQuery) (*dto.InvestmentPage, error) {
+	return uc.listInvestments(ctx, domain.InvestmentFilter{InvestorEmail: email}, q)
+}
+
+func (uc *LoanUsecase) listInvestments(ctx context.Context, f domain.InvestmentFilter, q dto.PageQuery) (*dto.InvestmentPage, error) {
+	limit := domain.DefaultPageSize
+	if q.Limit > 0 {
+		limit = min(q.Limit, domain.MaxPageSize)
+	}
+	if q.Cursor != "" {
+		after, err := domain.DecodeIDCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		f.AfterID = after
+	}
+
+	f.Limit = limit + 1
+	details, err := uc.InvestmentRepo.ListInvestments(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.InvestmentPage{Data: []dto.InvestmentSummary{}}
+	if len(details) > limit {
+		details = details[:limit]
+		page.NextCursor = domain.EncodeIDCursor(details[limit-1].ID)
+	}
+	for _, d := range details {
+		page.Data = append(page.Data, summarizeInvestment(d))
+	}
+	return page, nil
+}
+
+func summarizeInvestment(d domain.InvestmentDetail) dto.InvestmentSummary {
+	share := "0.00"
+	if d.PrincipalAmount.IsPositive() {
+		ratio := new(big.Rat).Quo(d.Amount.Rat(), d.PrincipalAmount.Rat())
+		share = ratio.Mul(ratio, big.NewRat(100, 1)).FloatString(2)
+	}
+
+	return dto.InvestmentSummary{
+		ID:               d.ID,
+		LoanID:           d.LoanID,
+		LoanStatus:       d.LoanStatus,
+		InvestorEmail:    d.InvestorEmail,
+		Amount:           d.Amount,
+		ShareOfPrincipal: share,
+		ROI:              d.ROI,
+		ExpectedReturn:   d.Amount.Percent(d.ROI, domain.RoundHalfEven),
+		InvestedAt:       d.InvestedAt,
+		RefundedAt:       d.RefundedAt,
+	}
+}
+
+// GetRepaymentSchedule returns the instalments of a loan, or nil if the loan
+// does not exist. Loans that are not disbursed yet have no instalments.
+func (uc *LoanUsecase) GetRepaymentSchedule(ctx context.Context, id int) (*dto.RepaymentSchedule, error) {
+	loan, err := uc.LoanRepo.GetLoanByID(ctx, id)
+	if err != nil || loan == nil {
+		return nil, err
+	}
+
+	installments, err := uc.ScheduleRepo.GetScheduleByLoan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &dto.RepaymentSchedule{
+		LoanID:          loan.ID,
+		RepaymentMethod: loan.RepaymentMethod,
+		TenorMonths:     loan.TenorMonths,
+		TotalPrincipal:  domain.Zero(loan.PrincipalAmount.Currency),
+		TotalInterest:   domain.Zero(loan.PrincipalAmount.Currency),
+		Installments:    installments,
+	}
+	for _, inst := range installments {
+		schedule.TotalPrincipal = schedule.TotalPrincipal.Add(inst.PrincipalDue)
+		schedule.TotalInterest = schedule.TotalInterest.Add(inst.InterestDue)
+	}
+	return schedule, nil
+}
+
+// GetLoanHistory returns the status trail of a loan, or nil if the loan does
+// not exist.
+func (uc *LoanUsecase) GetLoanHistory(ctx context.Context, id int) ([]domain.LoanStatusHistory, error) {
+	loan, err := uc.LoanRepo.GetLoanByID(ctx, id)
+	if err != nil || loan == nil {
+		return nil, err
+	}
+
+	return uc.HistoryRepo.GetHistoryByLoan(ctx, id)
+}
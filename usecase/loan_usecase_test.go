@@ -2,12 +2,16 @@ package usecase
 
 import (
 	"context"
-	"database/sql"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/martinusiron/loan-service/domain"
 	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/ledger"
 
 	mockRepo "github.com/martinusiron/loan-service/mocks"
 
@@ -15,19 +19,89 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// testPNG is enough of a PNG file for content sniffing to recognise it.
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+
+var testPNGChecksum = func() string {
+	sum := sha256.Sum256(testPNG)
+	return hex.EncodeToString(sum[:])
+}()
+
+// expectBlobs lets ApproveLoan store and remove picture proofs.
+func expectBlobs(bs *mockRepo.BlobStore) {
+	bs.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	bs.On("Delete", mock.Anything, mock.Anything).Return(nil)
+}
+
+// expectEvents accepts every event the usecase publishes.
+func expectEvents(m *mockRepo.EventPublisher) {
+	m.On("Publish", mock.Anything, mock.Anything).Return(nil)
+}
+
+// expectAgreementParties provides the borrower and the single investor named
+// in agreement letters of loan 1.
+func expectAgreementParties(br *mockRepo.BorrowerRepository, ir *mockRepo.InvestmentRepository, inr *mockRepo.InvestorRepository) {
+	br.On("GetBorrowerByID", mock.Anything, "BRW-1").Return(&domain.Borrower{ID: "BRW-1", Name: "Budi", Status: domain.BorrowerActive}, nil)
+	ir.On("GetInvestorsByLoan", mock.Anything, 1).Return([]domain.Investment{
+		{ID: 7, LoanID: 1, InvestorEmail: "a@a.com", Amount: domain.MustParseMoney("1200000", domain.CurrencyIDR)},
+	}, nil)
+	inr.On("GetInvestorByEmail", mock.Anything, "a@a.com").Return(&domain.Investor{Email: "a@a.com", Name: "Ani", KYCStatus: domain.KYCVerified}, nil)
+}
+
+// expectHold lets InvestLoan place holds on wallets with the given balance.
+func expectHold(wr *mockRepo.WalletRepository, balance string) {
+	wr.On("GetWalletForUpdate", mock.Anything, mock.Anything, domain.CurrencyIDR).Return(func(_ context.Context, email string, _ domain.Currency) *domain.Wallet {
+		return &domain.Wallet{
+			InvestorEmail: email,
+			Balance:       domain.MustParseMoney(balance, domain.CurrencyIDR),
+			Held:          domain.Zero(domain.CurrencyIDR),
+		}
+	}, nil)
+	wr.On("UpdateWallet", mock.Anything, mock.Anything).Return(nil)
+	wr.On("CreateHold", mock.Anything, mock.AnythingOfType("*domain.WalletHold")).Return(nil)
+}
+
+// expectVerifiedInvestors lets every investor pass the KYC check.
+func expectVerifiedInvestors(ir *mockRepo.InvestorRepository) {
+	ir.On("GetInvestorByEmail", mock.Anything, mock.Anything).Return(func(_ context.Context, email string) *domain.Investor {
+		return &domain.Investor{Email: email, KYCStatus: domain.KYCVerified}
+	}, nil)
+}
+
+// expectStaff lets every employee approve and disburse loans.
+func expectStaff(er *mockRepo.EmployeeRepository) {
+	er.On("GetEmployeeByID", mock.Anything, mock.Anything).Return(func(_ context.Context, id string) *domain.Employee {
+		return &domain.Employee{ID: id, Role: domain.RoleAdmin, Status: domain.EmployeeActive}
+	}, nil)
+}
+
 func TestCreateLoan(t *testing.T) {
 	mockLoanRepo := new(mockRepo.LoanRepository)
 	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
 	mockInvestRepo := new(mockRepo.InvestmentRepository)
-	db := &sql.DB{}
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
 
-	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockInvestRepo, db)
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	expectEvents(mockEvents)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusHistory")).Return(nil)
 
 	mockLoanRepo.On("CreateLoan", mock.Anything, mock.AnythingOfType("*domain.Loan")).Return(nil)
+	mockBorrowerRepo.On("GetBorrowerByID", mock.Anything, "BR123").Return(&domain.Borrower{ID: "BR123", Status: domain.BorrowerActive}, nil)
 
 	payload := dto.CreateLoanPayload{
 		BorrowerID:      "BR123",
-		PrincipalAmount: 1000000,
+		PrincipalAmount: domain.MustParseMoney("1000000", domain.CurrencyIDR),
 		Rate:            10.0,
 		ROI:             5.0,
 	}
@@ -35,90 +109,1120 @@ func TestCreateLoan(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.Equal(t, "BR123", loan.BorrowerID)
-	assert.Equal(t, float64(1000000), loan.PrincipalAmount)
+	assert.Equal(t, domain.MustParseMoney("1000000", domain.CurrencyIDR), loan.PrincipalAmount)
+	mockEvents.AssertCalled(t, "Publish", mock.Anything, mock.MatchedBy(func(e domain.LoanCreated) bool {
+		return e.BorrowerID == "BR123" && e.PrincipalAmount.String() == "1000000.00" && e.RepaymentMethod == domain.DefaultRepaymentMethod
+	}))
+}
+
+func TestCreateLoan_BorrowerNotEligible(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	mockBorrowerRepo.On("GetBorrowerByID", mock.Anything, "BR-GONE").Return(nil, nil)
+	mockBorrowerRepo.On("GetBorrowerByID", mock.Anything, "BR-OFF").Return(&domain.Borrower{ID: "BR-OFF", Status: domain.BorrowerInactive}, nil)
+
+	payload := dto.CreateLoanPayload{
+		BorrowerID:      "BR-GONE",
+		PrincipalAmount: domain.MustParseMoney("1000000", domain.CurrencyIDR),
+		Rate:            10.0,
+		ROI:             5.0,
+	}
+	_, err := uc.CreateLoan(context.TODO(), payload)
+	assert.ErrorIs(t, err, domain.ErrBorrowerNotFound)
+
+	payload.BorrowerID = "BR-OFF"
+	_, err = uc.CreateLoan(context.TODO(), payload)
+	assert.ErrorIs(t, err, domain.ErrBorrowerInactive)
+
+	mockLoanRepo.AssertNotCalled(t, "CreateLoan", mock.Anything, mock.Anything)
 }
 
 func TestApproveLoan(t *testing.T) {
 	mockLoanRepo := new(mockRepo.LoanRepository)
 	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
 	mockInvestRepo := new(mockRepo.InvestmentRepository)
-	db := &sql.DB{}
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
 
-	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockInvestRepo, db)
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	expectEvents(mockEvents)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusHistory")).Return(nil)
 
 	loan := &domain.Loan{
 		ID:         1,
 		Status:     domain.StatusProposed,
 		BorrowerID: "B01",
 	}
-	mockLoanRepo.On("GetLoanByID", mock.Anything, 1).Return(loan, nil)
-	mockApprovalRepo.On("CreateApproval", mock.Anything, mock.AnythingOfType("*domain.LoanApproval")).Return(nil)
+	expectStaff(mockEmployeeRepo)
+	expectBlobs(mockBlobStore)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockApprovalRepo.On("CreateApproval", mock.Anything, mock.MatchedBy(func(a *domain.LoanApproval) bool {
+		return a.LoanID == 1 && a.EmployeeID == "EMP001" &&
+			a.PictureProofChecksum == testPNGChecksum &&
+			a.PictureProofContentType == "image/png" &&
+			strings.HasSuffix(a.PictureProofKey, ".png")
+	})).Return(nil)
 	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusApproved).Return(nil)
+	mockLoanRepo.On("SetFundingDeadline", mock.Anything, 1, mock.MatchedBy(func(d time.Time) bool {
+		return d.Sub(time.Now().Add(domain.DefaultFundingPeriod)).Abs() < time.Minute
+	})).Return(nil)
 
 	payload := dto.ApproveLoanPayload{
 		LoanID:       1,
-		PictureProof: "proof.jpg",
+		PictureProof: dto.Upload{Filename: "proof.png", Data: testPNG},
 		EmployeeID:   "EMP001",
 		Date:         time.Now(),
 	}
 	err := uc.ApproveLoan(context.TODO(), payload)
 	assert.NoError(t, err)
+	mockLoanRepo.AssertExpectations(t)
+	mockApprovalRepo.AssertExpectations(t)
+	mockBlobStore.AssertCalled(t, "Put", mock.Anything, mock.Anything, testPNG, "image/png")
+	mockBlobStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestApproveLoan_ExplicitFundingDeadline(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	expectEvents(mockEvents)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusHistory")).Return(nil)
+
+	deadline := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	expectStaff(mockEmployeeRepo)
+	expectBlobs(mockBlobStore)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(&domain.Loan{ID: 1, Status: domain.StatusProposed}, nil)
+	mockApprovalRepo.On("CreateApproval", mock.Anything, mock.AnythingOfType("*domain.LoanApproval")).Return(nil)
+	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusApproved).Return(nil)
+	mockLoanRepo.On("SetFundingDeadline", mock.Anything, 1, deadline).Return(nil)
+
+	err := uc.ApproveLoan(context.TODO(), dto.ApproveLoanPayload{
+		LoanID:          1,
+		PictureProof:    dto.Upload{Filename: "proof.png", Data: testPNG},
+		EmployeeID:      "EMP001",
+		Date:            time.Now(),
+		FundingDeadline: &deadline,
+	})
+	assert.NoError(t, err)
+	mockLoanRepo.AssertExpectations(t)
+}
+
+func TestApproveLoan_EmployeeNotAllowed(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	expectBlobs(mockBlobStore)
+	mockEmployeeRepo.On("GetEmployeeByID", mock.Anything, "GHOST").Return(nil, nil)
+	mockEmployeeRepo.On("GetEmployeeByID", mock.Anything, "OFFICER").Return(&domain.Employee{ID: "OFFICER", Role: domain.RoleFieldOfficer, Status: domain.EmployeeActive}, nil)
+	mockEmployeeRepo.On("GetEmployeeByID", mock.Anything, "LEFT").Return(&domain.Employee{ID: "LEFT", Role: domain.RoleFieldValidator, Status: domain.EmployeeInactive}, nil)
+
+	for id, want := range map[string]error{
+		"GHOST":   domain.ErrEmployeeNotFound,
+		"OFFICER": domain.ErrEmployeeForbidden,
+		"LEFT":    domain.ErrEmployeeInactive,
+	} {
+		err := uc.ApproveLoan(context.TODO(), dto.ApproveLoanPayload{LoanID: 1, EmployeeID: id, Date: time.Now(), PictureProof: dto.Upload{Data: testPNG}})
+		assert.ErrorIs(t, err, want, id)
+	}
+	mockLoanRepo.AssertNotCalled(t, "GetLoanByIDForUpdate", mock.Anything, mock.Anything)
+	// Each refused approval removes the picture proof it uploaded.
+	mockBlobStore.AssertNumberOfCalls(t, "Delete", 3)
+}
+
+func TestApproveLoan_InvalidPictureProof(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	uc.MaxPictureProofSize = 100
+
+	for name, tc := range map[string]struct {
+		data []byte
+		want error
+	}{
+		"empty":     {nil, domain.ErrPictureProofType},
+		"pdf":       {[]byte("%PDF-1.4 not a photo"), domain.ErrPictureProofType},
+		"too large": {append(append([]byte{}, testPNG...), make([]byte, 100)...), domain.ErrPictureProofTooLarge},
+	} {
+		err := uc.ApproveLoan(context.TODO(), dto.ApproveLoanPayload{LoanID: 1, EmployeeID: "EMP001", Date: time.Now(), PictureProof: dto.Upload{Data: tc.data}})
+		assert.ErrorIs(t, err, tc.want, name)
+	}
+	mockBlobStore.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockEmployeeRepo.AssertNotCalled(t, "GetEmployeeByID", mock.Anything, mock.Anything)
+}
+
+func TestRejectLoan(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	expectEvents(mockEvents)
+	expectStaff(mockEmployeeRepo)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusHistory")).Return(nil)
+
+	loan := &domain.Loan{
+		ID:         1,
+		Status:     domain.StatusProposed,
+		BorrowerID: "B01",
+	}
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockRejectionRepo.On("CreateRejection", mock.Anything, mock.MatchedBy(func(r *domain.LoanRejection) bool {
+		return r.LoanID == 1 && r.ReasonCode == domain.ReasonSuspectedFraud && r.EmployeeID == "EMP001"
+	})).Return(nil)
+	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusRejected).Return(nil)
+
+	payload := dto.RejectLoanPayload{
+		LoanID:     1,
+		EmployeeID: "EMP001",
+		ReasonCode: domain.ReasonSuspectedFraud,
+		Note:       "identity card does not match borrower",
+		Date:       time.Now(),
+	}
+	err := uc.RejectLoan(context.TODO(), payload)
+	assert.NoError(t, err)
+	mockRejectionRepo.AssertExpectations(t)
+	mockLoanRepo.AssertExpectations(t)
+}
+
+func TestRejectLoan_EmployeeNotAllowed(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	mockEmployeeRepo.On("GetEmployeeByID", mock.Anything, "GHOST").Return(nil, nil)
+	mockEmployeeRepo.On("GetEmployeeByID", mock.Anything, "OFFICER").Return(&domain.Employee{ID: "OFFICER", Role: domain.RoleFieldOfficer, Status: domain.EmployeeActive}, nil)
+	mockEmployeeRepo.On("GetEmployeeByID", mock.Anything, "LEFT").Return(&domain.Employee{ID: "LEFT", Role: domain.RoleFieldValidator, Status: domain.EmployeeInactive}, nil)
+
+	for id, want := range map[string]error{
+		"GHOST":   domain.ErrEmployeeNotFound,
+		"OFFICER": domain.ErrEmployeeForbidden,
+		"LEFT":    domain.ErrEmployeeInactive,
+	} {
+		err := uc.RejectLoan(context.TODO(), dto.RejectLoanPayload{LoanID: 1, EmployeeID: id, ReasonCode: domain.ReasonOther, Date: time.Now()})
+		assert.ErrorIs(t, err, want, id)
+	}
+	mockLoanRepo.AssertNotCalled(t, "GetLoanByIDForUpdate", mock.Anything, mock.Anything)
+	mockRejectionRepo.AssertNotCalled(t, "CreateRejection", mock.Anything, mock.Anything)
+}
+
+func TestRejectedLoan_RefusesTransitions(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusHistory")).Return(nil)
+
+	loan := &domain.Loan{
+		ID:     1,
+		Status: domain.StatusRejected,
+	}
+	expectStaff(mockEmployeeRepo)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+
+	assert.Error(t, uc.ApproveLoan(context.TODO(), dto.ApproveLoanPayload{LoanID: 1, Date: time.Now()}))
+	assert.Error(t, uc.RejectLoan(context.TODO(), dto.RejectLoanPayload{LoanID: 1, ReasonCode: domain.ReasonOther, Date: time.Now()}))
+	assert.Error(t, uc.InvestLoan(context.TODO(), dto.InvestLoanPayload{LoanID: 1, InvestorEmail: "a@a.com", Amount: domain.MustParseMoney("100", domain.CurrencyIDR)}))
+	assert.Error(t, uc.DisburseLoan(context.TODO(), dto.DisburseLoanPayload{LoanID: 1, Date: time.Now()}))
+
+	mockLoanRepo.AssertNotCalled(t, "UpdateLoanStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockHistoryRepo.AssertNotCalled(t, "RecordStatusChange", mock.Anything, mock.Anything)
+}
+
+func TestApproveLoan_RecordsHistory(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	expectEvents(mockEvents)
+
+	loan := &domain.Loan{ID: 1, Status: domain.StatusProposed}
+	expectStaff(mockEmployeeRepo)
+	expectBlobs(mockBlobStore)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockApprovalRepo.On("CreateApproval", mock.Anything, mock.AnythingOfType("*domain.LoanApproval")).Return(nil)
+	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusApproved).Return(nil)
+	mockLoanRepo.On("SetFundingDeadline", mock.Anything, 1, mock.AnythingOfType("time.Time")).Return(nil)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.MatchedBy(func(h *domain.LoanStatusHistory) bool {
+		return h.LoanID == 1 &&
+			h.FromStatus == domain.StatusProposed &&
+			h.ToStatus == domain.StatusApproved &&
+			h.Actor == "EMP001" &&
+			strings.HasPrefix(h.Metadata["picture_proof"].(string), "picture-proofs/loan-1/") &&
+			h.Metadata["picture_proof_sha256"] == testPNGChecksum
+	})).Return(nil)
+
+	err := uc.ApproveLoan(context.TODO(), dto.ApproveLoanPayload{
+		LoanID:       1,
+		PictureProof: dto.Upload{Filename: "proof.png", Data: testPNG},
+		EmployeeID:   "EMP001",
+		Date:         time.Now(),
+	})
+	assert.NoError(t, err)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestDisburseLoan_InvalidTransition(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	loan := &domain.Loan{ID: 1, Status: domain.StatusApproved}
+	expectStaff(mockEmployeeRepo)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+
+	err := uc.DisburseLoan(context.TODO(), dto.DisburseLoanPayload{LoanID: 1, Date: time.Now()})
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	mockLoanRepo.AssertNotCalled(t, "SetAgreementLink", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestInvestLoan_Full(t *testing.T) {
 	mockLoanRepo := new(mockRepo.LoanRepository)
 	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
 	mockInvestRepo := new(mockRepo.InvestmentRepository)
-	db := &sql.DB{}
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
 
-	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockInvestRepo, db)
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusHistory")).Return(nil)
 
 	loan := &domain.Loan{
 		ID:              1,
 		Status:          domain.StatusApproved,
-		PrincipalAmount: 1000000,
+		PrincipalAmount: domain.MustParseMoney("1000000", domain.CurrencyIDR),
 	}
 
-	mockLoanRepo.On("GetLoanByID", mock.Anything, 1).Return(loan, nil)
-	mockInvestRepo.On("GetTotalInvested", mock.Anything, 1).Return(900000.0, nil)
+	expectVerifiedInvestors(mockInvestorRepo)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockInvestRepo.On("GetTotalInvested", mock.Anything, 1).Return(domain.MustParseMoney("900000", domain.CurrencyIDR), nil)
 	mockInvestRepo.On("AddInvestment", mock.Anything, mock.AnythingOfType("*domain.Investment")).Return(nil)
+	expectHold(mockWalletRepo, "10000000")
 	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusInvested).Return(nil)
 	mockInvestRepo.On("GetInvestorsByLoan", mock.Anything, 1).Return([]domain.Investment{
-		{InvestorEmail: "a@a.com", Amount: 500000},
-		{InvestorEmail: "b@b.com", Amount: 500000},
+		{InvestorEmail: "a@a.com", Amount: domain.MustParseMoney("200000", domain.CurrencyIDR)},
+		{InvestorEmail: "b@b.com", Amount: domain.MustParseMoney("500000", domain.CurrencyIDR)},
+		{InvestorEmail: "a@a.com", Amount: domain.MustParseMoney("300000", domain.CurrencyIDR)},
 	}, nil)
+	mockEvents.On("Publish", mock.Anything, mock.MatchedBy(func(e domain.InvestmentAdded) bool {
+		return e.LoanID == 1 && e.InvestorEmail == "new@investor.com" &&
+			e.Amount.String() == "100000.00" && e.TotalInvested.String() == "1000000.00"
+	})).Return(nil).Once()
+	// Investors who invested more than once are listed once, with their total.
+	mockEvents.On("Publish", mock.Anything, mock.MatchedBy(func(e domain.LoanFullyFunded) bool {
+		return e.LoanID == 1 && assert.ObjectsAreEqual([]domain.InvestorAmount{
+			{InvestorEmail: "a@a.com", Amount: domain.MustParseMoney("500000", domain.CurrencyIDR)},
+			{InvestorEmail: "b@b.com", Amount: domain.MustParseMoney("500000", domain.CurrencyIDR)},
+		}, e.Investors)
+	})).Return(nil).Once()
 
 	payload := dto.InvestLoanPayload{
 		LoanID:        1,
 		InvestorEmail: "new@investor.com",
-		Amount:        100000,
+		Amount:        domain.MustParseMoney("100000", domain.CurrencyIDR),
 	}
 	err := uc.InvestLoan(context.TODO(), payload)
 	assert.NoError(t, err)
+	mockEvents.AssertExpectations(t)
+}
+
+func TestInvestLoan_ExactDecimalFunding(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	expectEvents(mockEvents)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusHistory")).Return(nil)
+
+	// 0.1 + 0.2 never equals 0.3 in float64; the loan must still be funded.
+	loan := &domain.Loan{
+		ID:              1,
+		Status:          domain.StatusApproved,
+		PrincipalAmount: domain.MustParseMoney("0.3", domain.CurrencyIDR),
+	}
+	expectVerifiedInvestors(mockInvestorRepo)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockInvestRepo.On("GetTotalInvested", mock.Anything, 1).Return(domain.MustParseMoney("0.1", domain.CurrencyIDR), nil)
+	mockInvestRepo.On("AddInvestment", mock.Anything, mock.AnythingOfType("*domain.Investment")).Return(nil)
+	expectHold(mockWalletRepo, "10000000")
+	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusInvested).Return(nil)
+	mockInvestRepo.On("GetInvestorsByLoan", mock.Anything, 1).Return([]domain.Investment{}, nil)
+
+	err := uc.InvestLoan(context.TODO(), dto.InvestLoanPayload{
+		LoanID:        1,
+		InvestorEmail: "a@a.com",
+		Amount:        domain.MustParseMoney("0.2", domain.CurrencyIDR),
+	})
+	assert.NoError(t, err)
+	mockLoanRepo.AssertCalled(t, "UpdateLoanStatus", mock.Anything, 1, domain.StatusInvested)
+}
+
+func TestInvestLoan_CurrencyMismatch(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	loan := &domain.Loan{
+		ID:              1,
+		Status:          domain.StatusApproved,
+		PrincipalAmount: domain.MustParseMoney("1000", domain.CurrencyIDR),
+	}
+	expectVerifiedInvestors(mockInvestorRepo)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockInvestRepo.On("GetTotalInvested", mock.Anything, 1).Return(domain.Zero(domain.CurrencyIDR), nil)
+
+	err := uc.InvestLoan(context.TODO(), dto.InvestLoanPayload{
+		LoanID:        1,
+		InvestorEmail: "a@a.com",
+		Amount:        domain.MustParseMoney("10", domain.Currency("USD")),
+	})
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+	mockInvestRepo.AssertNotCalled(t, "AddInvestment", mock.Anything, mock.Anything)
+}
+
+func TestInvestLoan_InsufficientFunds(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	loan := &domain.Loan{
+		ID:              1,
+		Status:          domain.StatusApproved,
+		PrincipalAmount: domain.MustParseMoney("1000000", domain.CurrencyIDR),
+	}
+	expectVerifiedInvestors(mockInvestorRepo)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockInvestRepo.On("GetTotalInvested", mock.Anything, 1).Return(domain.Zero(domain.CurrencyIDR), nil)
+	mockWalletRepo.On("GetWalletForUpdate", mock.Anything, "a@a.com", domain.CurrencyIDR).Return(&domain.Wallet{
+		InvestorEmail: "a@a.com",
+		Balance:       domain.MustParseMoney("300000", domain.CurrencyIDR),
+		Held:          domain.MustParseMoney("250000", domain.CurrencyIDR),
+	}, nil)
+
+	err := uc.InvestLoan(context.TODO(), dto.InvestLoanPayload{
+		LoanID:        1,
+		InvestorEmail: "a@a.com",
+		Amount:        domain.MustParseMoney("100000", domain.CurrencyIDR),
+	})
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	var fundsErr *domain.InsufficientFundsError
+	if assert.ErrorAs(t, err, &fundsErr) {
+		assert.Equal(t, "50000.00", fundsErr.Available.String())
+	}
+	mockWalletRepo.AssertNotCalled(t, "CreateHold", mock.Anything, mock.Anything)
+	mockInvestRepo.AssertNotCalled(t, "AddInvestment", mock.Anything, mock.Anything)
+}
+
+func TestInvestLoan_KYCNotVerified(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(&domain.Loan{
+		ID:              1,
+		Status:          domain.StatusApproved,
+		PrincipalAmount: domain.MustParseMoney("1000000", domain.CurrencyIDR),
+	}, nil)
+	mockInvestorRepo.On("GetInvestorByEmail", mock.Anything, "pending@a.com").Return(&domain.Investor{Email: "pending@a.com", KYCStatus: domain.KYCPending}, nil)
+	mockInvestorRepo.On("GetInvestorByEmail", mock.Anything, "unknown@a.com").Return(nil, nil)
+
+	for email, want := range map[string]domain.KYCStatus{"pending@a.com": domain.KYCPending, "unknown@a.com": ""} {
+		err := uc.InvestLoan(context.TODO(), dto.InvestLoanPayload{
+			LoanID:        1,
+			InvestorEmail: email,
+			Amount:        domain.MustParseMoney("100000", domain.CurrencyIDR),
+		})
+		assert.ErrorIs(t, err, domain.ErrInvestorNotVerified)
+		var kycErr *domain.KYCError
+		if assert.ErrorAs(t, err, &kycErr) {
+			assert.Equal(t, want, kycErr.Status)
+		}
+	}
+	mockWalletRepo.AssertNotCalled(t, "GetWalletForUpdate", mock.Anything, mock.Anything, mock.Anything)
+	mockInvestRepo.AssertNotCalled(t, "AddInvestment", mock.Anything, mock.Anything)
+}
+
+func TestInvestLoan_FundingClosed(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	deadline := time.Now().Add(-time.Hour)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(&domain.Loan{
+		ID:              1,
+		Status:          domain.StatusApproved,
+		PrincipalAmount: domain.MustParseMoney("1000000", domain.CurrencyIDR),
+		FundingDeadline: &deadline,
+	}, nil)
+
+	err := uc.InvestLoan(context.TODO(), dto.InvestLoanPayload{
+		LoanID:        1,
+		InvestorEmail: "a@a.com",
+		Amount:        domain.MustParseMoney("100000", domain.CurrencyIDR),
+	})
+	assert.ErrorIs(t, err, domain.ErrFundingClosed)
+	mockWalletRepo.AssertNotCalled(t, "GetWalletForUpdate", mock.Anything, mock.Anything, mock.Anything)
+	mockInvestRepo.AssertNotCalled(t, "AddInvestment", mock.Anything, mock.Anything)
+}
+
+func TestExpireOverdueLoans(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	now := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	deadline := now.Add(-time.Hour)
+	principal := domain.MustParseMoney("1000000", domain.CurrencyIDR)
+
+	mockLoanRepo.On("ListLoanIDsPastFundingDeadline", mock.Anything, now, mock.Anything).Return([]int{1, 2}, nil)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(&domain.Loan{
+		ID: 1, Status: domain.StatusApproved, PrincipalAmount: principal, FundingDeadline: &deadline,
+	}, nil)
+	// Loan 2 was fully funded after it was listed.
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 2).Return(&domain.Loan{
+		ID: 2, Status: domain.StatusInvested, PrincipalAmount: principal, FundingDeadline: &deadline,
+	}, nil)
+
+	mockInvestRepo.On("GetTotalInvested", mock.Anything, 1).Return(domain.MustParseMoney("250000", domain.CurrencyIDR), nil)
+	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusExpired).Return(nil)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.MatchedBy(func(h *domain.LoanStatusHistory) bool {
+		return h.LoanID == 1 && h.ToStatus == domain.StatusExpired && h.Actor == "system"
+	})).Return(nil)
+	mockWalletRepo.On("GetActiveHoldsByLoan", mock.Anything, 1).Return([]domain.WalletHold{
+		{ID: 3, InvestorEmail: "a@a.com", LoanID: 1, Amount: domain.MustParseMoney("250000", domain.CurrencyIDR), Status: domain.HoldActive},
+	}, nil)
+	mockWalletRepo.On("GetWalletForUpdate", mock.Anything, "a@a.com", domain.CurrencyIDR).Return(&domain.Wallet{
+		InvestorEmail: "a@a.com",
+		Balance:       domain.MustParseMoney("400000", domain.CurrencyIDR),
+		Held:          domain.MustParseMoney("250000", domain.CurrencyIDR),
+	}, nil)
+	mockWalletRepo.On("UpdateWallet", mock.Anything, mock.MatchedBy(func(w *domain.Wallet) bool {
+		return w.Balance.String() == "400000.00" && w.Held.IsZero()
+	})).Return(nil)
+	mockWalletRepo.On("UpdateHoldStatus", mock.Anything, 3, domain.HoldReleased).Return(nil)
+	mockInvestRepo.On("GetInvestorsByLoan", mock.Anything, 1).Return([]domain.Investment{
+		{InvestorEmail: "a@a.com", Amount: domain.MustParseMoney("250000", domain.CurrencyIDR)},
+	}, nil)
+	mockInvestRepo.On("MarkRefunded", mock.Anything, 1, now).Return(nil)
+	mockEvents.On("Publish", mock.Anything, domain.LoanExpired{
+		LoanEvent:       domain.NewLoanEvent(1, now),
+		FundingDeadline: deadline,
+		TotalInvested:   domain.MustParseMoney("250000", domain.CurrencyIDR),
+		Refunded:        []domain.InvestorAmount{{InvestorEmail: "a@a.com", Amount: domain.MustParseMoney("250000", domain.CurrencyIDR)}},
+	}).Return(nil).Once()
+
+	expired, err := uc.ExpireOverdueLoans(context.TODO(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	mockLoanRepo.AssertExpectations(t)
+	mockWalletRepo.AssertExpectations(t)
+	mockInvestRepo.AssertExpectations(t)
+	mockEvents.AssertExpectations(t)
+	mockLoanRepo.AssertNotCalled(t, "UpdateLoanStatus", mock.Anything, 2, mock.Anything)
+}
+
+func TestCancelLoan_RefundsInvestors(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(&domain.Loan{ID: 1, Status: domain.StatusInvested}, nil)
+	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusCancelled).Return(nil)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.MatchedBy(func(h *domain.LoanStatusHistory) bool {
+		return h.FromStatus == domain.StatusInvested &&
+			h.ToStatus == domain.StatusCancelled &&
+			h.Actor == "BR123" &&
+			h.Metadata["reason"] == "no longer needed"
+	})).Return(nil)
+	mockWalletRepo.On("GetActiveHoldsByLoan", mock.Anything, 1).Return([]domain.WalletHold{
+		{ID: 5, InvestorEmail: "a@a.com", LoanID: 1, Amount: domain.MustParseMoney("1000", domain.CurrencyIDR), Status: domain.HoldActive},
+	}, nil)
+	mockWalletRepo.On("GetWalletForUpdate", mock.Anything, "a@a.com", domain.CurrencyIDR).Return(&domain.Wallet{
+		InvestorEmail: "a@a.com",
+		Balance:       domain.MustParseMoney("1000", domain.CurrencyIDR),
+		Held:          domain.MustParseMoney("1000", domain.CurrencyIDR),
+	}, nil)
+	mockWalletRepo.On("UpdateWallet", mock.Anything, mock.MatchedBy(func(w *domain.Wallet) bool {
+		return w.Held.IsZero()
+	})).Return(nil)
+	mockWalletRepo.On("UpdateHoldStatus", mock.Anything, 5, domain.HoldReleased).Return(nil)
+	mockInvestRepo.On("GetInvestorsByLoan", mock.Anything, 1).Return([]domain.Investment{
+		{InvestorEmail: "a@a.com", Amount: domain.MustParseMoney("1000", domain.CurrencyIDR)},
+	}, nil)
+	mockInvestRepo.On("MarkRefunded", mock.Anything, 1, mock.AnythingOfType("time.Time")).Return(nil)
+	mockEvents.On("Publish", mock.Anything, mock.MatchedBy(func(e domain.LoanCancelled) bool {
+		return e.LoanID == 1 && e.Actor == "BR123" && e.Reason == "no longer needed" &&
+			len(e.Refunded) == 1 && e.Refunded[0].InvestorEmail == "a@a.com" && e.Refunded[0].Amount.String() == "1000.00"
+	})).Return(nil).Once()
+
+	err := uc.CancelLoan(context.TODO(), dto.CancelLoanPayload{LoanID: 1, Actor: "BR123", Reason: "no longer needed"})
+	assert.NoError(t, err)
+	mockHistoryRepo.AssertExpectations(t)
+	mockWalletRepo.AssertExpectations(t)
+	mockInvestRepo.AssertExpectations(t)
+	mockEvents.AssertExpectations(t)
+}
+
+func TestCancelLoan_AfterDisbursement(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(&domain.Loan{ID: 1, Status: domain.StatusDisbursed}, nil)
+
+	err := uc.CancelLoan(context.TODO(), dto.CancelLoanPayload{LoanID: 1, Actor: "OPS1", Reason: "duplicate"})
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	mockWalletRepo.AssertNotCalled(t, "GetActiveHoldsByLoan", mock.Anything, mock.Anything)
+	mockInvestRepo.AssertNotCalled(t, "MarkRefunded", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestDisburseLoan(t *testing.T) {
 	mockLoanRepo := new(mockRepo.LoanRepository)
 	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
 	mockInvestRepo := new(mockRepo.InvestmentRepository)
-	db := &sql.DB{}
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
 
-	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockInvestRepo, db)
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	expectEvents(mockEvents)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusHistory")).Return(nil)
 
 	loan := &domain.Loan{
-		ID:     1,
-		Status: domain.StatusInvested,
+		ID:              1,
+		BorrowerID:      "BRW-1",
+		Status:          domain.StatusInvested,
+		PrincipalAmount: domain.MustParseMoney("1200000", domain.CurrencyIDR),
+		Rate:            12.0,
+		TenorMonths:     3,
+		RepaymentMethod: domain.RepaymentFlat,
 	}
-	mockLoanRepo.On("GetLoanByID", mock.Anything, 1).Return(loan, nil)
-	mockLoanRepo.On("SetAgreementLink", mock.Anything, 1, "http://link.com/file.pdf").Return(nil)
+	uc.AgreementBaseURL = "https://loans.example.com/"
+	expectStaff(mockEmployeeRepo)
+	expectAgreementParties(mockBorrowerRepo, mockInvestRepo, mockInvestorRepo)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockLoanRepo.On("SetAgreementLink", mock.Anything, 1, "https://loans.example.com/v1/loans/1/agreements").Return(nil)
+	mockBlobStore.On("Put", mock.Anything, "agreements/loan-1/borrower.pdf", mock.MatchedBy(func(data []byte) bool {
+		return strings.HasPrefix(string(data), "%PDF-")
+	}), "application/pdf").Return(nil).Once()
+	mockBlobStore.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "agreements/loan-1/") && !strings.Contains(key, "a@a.com")
+	}), mock.Anything, mock.Anything).Return(nil).Times(3)
+	mockAgreementRepo.On("CreateAgreement", mock.Anything, mock.MatchedBy(func(a *domain.Agreement) bool {
+		return a.LoanID == 1 && len(a.Checksum) == 64 &&
+			(a.Party == domain.AgreementBorrower && a.InvestorEmail == "" || a.Party == domain.AgreementInvestor && a.InvestorEmail == "a@a.com")
+	})).Return(nil).Times(4)
 	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusDisbursed).Return(nil)
+	mockScheduleRepo.On("CreateInstallments", mock.Anything, mock.MatchedBy(func(insts []domain.Installment) bool {
+		return len(insts) == 3 &&
+			insts[0].PrincipalDue.String() == "400000.00" &&
+			insts[0].InterestDue.String() == "12000.00"
+	})).Return(nil)
+	mockWalletRepo.On("GetActiveHoldsByLoan", mock.Anything, 1).Return([]domain.WalletHold{
+		{ID: 5, InvestorEmail: "a@a.com", LoanID: 1, Amount: domain.MustParseMoney("1200000", domain.CurrencyIDR), Status: domain.HoldActive},
+	}, nil)
+	mockWalletRepo.On("GetWalletForUpdate", mock.Anything, "a@a.com", domain.CurrencyIDR).Return(&domain.Wallet{
+		InvestorEmail: "a@a.com",
+		Balance:       domain.MustParseMoney("1500000", domain.CurrencyIDR),
+		Held:          domain.MustParseMoney("1200000", domain.CurrencyIDR),
+	}, nil)
+	mockWalletRepo.On("UpdateWallet", mock.Anything, mock.MatchedBy(func(w *domain.Wallet) bool {
+		return w.Balance.String() == "300000.00" && w.Held.IsZero()
+	})).Return(nil)
+	mockWalletRepo.On("UpdateHoldStatus", mock.Anything, 5, domain.HoldCaptured).Return(nil)
+	mockLedgerRepo.On("PostEntry", mock.Anything, mock.MatchedBy(func(e *ledger.Entry) bool {
+		return e.Kind == ledger.EntryInvestment && e.Validate() == nil
+	})).Return(nil).Once()
+	mockLedgerRepo.On("PostEntry", mock.Anything, mock.MatchedBy(func(e *ledger.Entry) bool {
+		return e.Kind == ledger.EntryDisbursement && e.Validate() == nil
+	})).Return(nil).Once()
 
 	payload := dto.DisburseLoanPayload{
-		LoanID:        1,
-		AgreementLink: "http://link.com/file.pdf",
-		EmployeeID:    "EMP02",
-		Date:          time.Now(),
+		LoanID:     1,
+		EmployeeID: "EMP02",
+		Date:       time.Now(),
 	}
 	err := uc.DisburseLoan(context.TODO(), payload)
 	assert.NoError(t, err)
+	mockBlobStore.AssertExpectations(t)
+	mockAgreementRepo.AssertExpectations(t)
+	mockBlobStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockEvents.AssertCalled(t, "Publish", mock.Anything, mock.MatchedBy(func(e domain.LoanDisbursed) bool {
+		return e.LoanID == 1 && e.EmployeeID == "EMP02" && e.AgreementLink == "https://loans.example.com/v1/loans/1/agreements"
+	}))
+}
+
+func TestDisburseLoan_FailureRemovesAgreementLetters(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.AnythingOfType("*domain.LoanStatusHistory")).Return(nil)
+
+	loan := &domain.Loan{
+		ID:              1,
+		BorrowerID:      "BRW-1",
+		Status:          domain.StatusInvested,
+		PrincipalAmount: domain.MustParseMoney("1200000", domain.CurrencyIDR),
+		Rate:            12.0,
+		TenorMonths:     3,
+		RepaymentMethod: domain.RepaymentFlat,
+	}
+	expectStaff(mockEmployeeRepo)
+	expectBlobs(mockBlobStore)
+	expectAgreementParties(mockBorrowerRepo, mockInvestRepo, mockInvestorRepo)
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusDisbursed).Return(nil)
+	mockScheduleRepo.On("CreateInstallments", mock.Anything, mock.Anything).Return(nil)
+	mockAgreementRepo.On("CreateAgreement", mock.Anything, mock.Anything).Return(nil)
+	mockLoanRepo.On("SetAgreementLink", mock.Anything, 1, "/v1/loans/1/agreements").Return(errors.New("connection reset"))
+
+	err := uc.DisburseLoan(context.TODO(), dto.DisburseLoanPayload{LoanID: 1, EmployeeID: "EMP02", Date: time.Now()})
+	assert.EqualError(t, err, "connection reset")
+	mockBlobStore.AssertNumberOfCalls(t, "Put", 4)
+	mockBlobStore.AssertCalled(t, "Delete", mock.Anything, "agreements/loan-1/borrower.pdf")
+	mockBlobStore.AssertNumberOfCalls(t, "Delete", 4)
+}
+
+func TestListLoans_Paginates(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	loans := []domain.Loan{
+		{ID: 3, PrincipalAmount: domain.MustParseMoney("3000", domain.CurrencyIDR)},
+		{ID: 2, PrincipalAmount: domain.MustParseMoney("2000", domain.CurrencyIDR)},
+		{ID: 1, PrincipalAmount: domain.MustParseMoney("1000", domain.CurrencyIDR)},
+	}
+	mockLoanRepo.On("ListLoans", mock.Anything, mock.MatchedBy(func(f domain.LoanFilter) bool {
+		return f.Limit == 3 &&
+			f.SortBy == domain.SortByPrincipalAmount &&
+			f.SortDesc &&
+			len(f.Statuses) == 2 &&
+			f.MinPrincipal != nil && f.MinPrincipal.String() == "500.00"
+	})).Return(loans, nil)
+
+	page, err := uc.ListLoans(context.TODO(), dto.ListLoansQuery{
+		Status:       []string{"approved,invested"},
+		MinPrincipal: "500",
+		SortBy:       "principal_amount",
+		Order:        "desc",
+		Limit:        2,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, page.Data, 2)
+	assert.NotEmpty(t, page.NextCursor)
+
+	cursor, err := domain.DecodeLoanCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cursor.ID)
+	assert.Equal(t, "2000.00", cursor.Value)
+}
+
+func TestListLoans_InvalidQuery(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	rateCursor := domain.LoanCursor{SortBy: domain.SortByRate, SortDesc: true, Value: "10", ID: 5}.Encode()
+
+	for _, q := range []dto.ListLoansQuery{
+		{Status: []string{"pending"}},
+		{MinPrincipal: "10.001"},
+		{CreatedFrom: "yesterday"},
+		{Cursor: "not-a-cursor"},
+		{Cursor: rateCursor, SortBy: "created_at"},
+	} {
+		_, err := uc.ListLoans(context.TODO(), q)
+		assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+	}
+	mockLoanRepo.AssertNotCalled(t, "ListLoans", mock.Anything, mock.Anything)
+}
+
+func TestListInvestorInvestments(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	detail := func(id int, amount string) domain.InvestmentDetail {
+		return domain.InvestmentDetail{
+			Investment: domain.Investment{
+				ID:            id,
+				LoanID:        7,
+				InvestorEmail: "a@a.com",
+				Amount:        domain.MustParseMoney(amount, domain.CurrencyIDR),
+			},
+			LoanStatus:      domain.StatusInvested,
+			PrincipalAmount: domain.MustParseMoney("1000000", domain.CurrencyIDR),
+			ROI:             7.5,
+		}
+	}
+	mockInvestRepo.On("ListInvestments", mock.Anything, domain.InvestmentFilter{
+		InvestorEmail: "a@a.com",
+		AfterID:       4,
+		Limit:         3,
+	}).Return([]domain.InvestmentDetail{
+		detail(5, "250000"),
+		detail(6, "333333.33"),
+		detail(9, "100"),
+	}, nil)
+
+	page, err := uc.ListInvestorInvestments(context.TODO(), "a@a.com", dto.PageQuery{
+		Limit:  2,
+		Cursor: domain.EncodeIDCursor(4),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, page.Data, 2)
+	assert.Equal(t, domain.EncodeIDCursor(6), page.NextCursor)
+
+	assert.Equal(t, "25.00", page.Data[0].ShareOfPrincipal)
+	assert.Equal(t, "18750.00", page.Data[0].ExpectedReturn.String())
+	assert.Equal(t, domain.StatusInvested, page.Data[0].LoanStatus)
+	assert.Equal(t, "33.33", page.Data[1].ShareOfPrincipal)
+	assert.Equal(t, "25000.00", page.Data[1].ExpectedReturn.String())
+}
+
+func TestListLoanInvestments_LoanNotFound(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockApprovalRepo := new(mockRepo.ApprovalRepository)
+	mockRejectionRepo := new(mockRepo.RejectionRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockBorrowerRepo := new(mockRepo.BorrowerRepository)
+	mockInvestorRepo := new(mockRepo.InvestorRepository)
+	mockEmployeeRepo := new(mockRepo.EmployeeRepository)
+	mockAgreementRepo := new(mockRepo.AgreementRepository)
+	mockBlobStore := new(mockRepo.BlobStore)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewLoanUsecase(mockLoanRepo, mockApprovalRepo, mockRejectionRepo, mockInvestRepo, mockHistoryRepo, mockScheduleRepo, mockLedgerRepo, mockWalletRepo, mockBorrowerRepo, mockInvestorRepo, mockEmployeeRepo, mockAgreementRepo, mockBlobStore, mockEvents, db)
+
+	mockLoanRepo.On("GetLoanByID", mock.Anything, 42).Return(nil, nil)
+
+	page, err := uc.ListLoanInvestments(context.TODO(), 42, dto.PageQuery{})
+	assert.NoError(t, err)
+	assert.Nil(t, page)
+	mockInvestRepo.AssertNotCalled(t, "ListInvestments", mock.Anything, mock.Anything)
 }
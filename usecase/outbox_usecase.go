@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/repository"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+// OutboxHandler carries out one outbox message. A handler may see the same
+// message again if the process dies after it ran but before the message was
+// marked done, so it should tolerate duplicates.
+//
+// Errors with a Permanent() bool method returning true are not retried.
+type OutboxHandler func(ctx context.Context, m domain.OutboxMessage) error
+
+// OutboxDispatcher delivers committed outbox messages to the handler
+// registered for their topic. Each message is claimed and marked in its own
+// transaction with SKIP LOCKED, so several dispatchers can share the table
+// without delivering a message twice.
+type OutboxDispatcher struct {
+	OutboxRepo repository.OutboxRepository
+	DB         *sql.DB
+
+	// MaxAttempts is how often a message is tried before it is dead-lettered.
+	MaxAttempts int
+	// InitialBackoff and MaxBackoff bound the wait between attempts, which
+	// doubles after every failure and is jittered.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// BatchSize caps how many messages one DispatchDue call handles.
+	BatchSize int
+
+	handlers map[domain.OutboxTopic]OutboxHandler
+}
+
+func NewOutboxDispatcher(or repository.OutboxRepository, db *sql.DB) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		OutboxRepo: or,
+		DB:         db,
+
+		MaxAttempts:    10,
+		InitialBackoff: 5 * time.Second,
+		MaxBackoff:     time.Hour,
+		BatchSize:      100,
+
+		handlers: map[domain.OutboxTopic]OutboxHandler{},
+	}
+}
+
+// Register routes the messages of topic to h. It must not be called once
+// the dispatcher is running.
+func (d *OutboxDispatcher) Register(topic domain.OutboxTopic, h OutboxHandler) {
+	d.handlers[topic] = h
+}
+
+// DispatchDue handles the messages due at now until none is left or
+// BatchSize is reached, and returns how many it handled, whether they were
+// delivered, rescheduled or dead-lettered.
+func (d *OutboxDispatcher) DispatchDue(ctx context.Context, now time.Time) (int, error) {
+	handled := 0
+	for handled < d.BatchSize {
+		claimed := false
+		err := utils.WithTransaction(ctx, d.DB, func(txCtx context.Context) error {
+			m, err := d.OutboxRepo.ClaimNext(txCtx, now)
+			if err != nil || m == nil {
+				return err
+			}
+			claimed = true
+			return d.dispatch(ctx, txCtx, m)
+		})
+		if err != nil {
+			return handled, err
+		}
+		if !claimed {
+			break
+		}
+		handled++
+	}
+	return handled, nil
+}
+
+// dispatch hands m to its handler and records the outcome. The handler runs
+// outside the claiming transaction, so its own writes do not depend on it.
+func (d *OutboxDispatcher) dispatch(ctx, txCtx context.Context, m *domain.OutboxMessage) error {
+	attempts := m.Attempts + 1
+
+	h, ok := d.handlers[m.Topic]
+	if !ok {
+		return d.deadLetter(txCtx, m, attempts, fmt.Errorf("no handler for topic %q", m.Topic))
+	}
+
+	err := h(ctx, *m)
+	if err == nil {
+		return d.OutboxRepo.MarkDone(txCtx, m.ID, attempts, time.Now())
+	}
+	if isPermanent(err) || attempts >= d.MaxAttempts {
+		return d.deadLetter(txCtx, m, attempts, err)
+	}
+	next := time.Now().Add(d.backoff(attempts))
+	log.Printf("outbox: %s message %d failed (attempt %d), retrying at %s: %v", m.Topic, m.ID, attempts, next.Format(time.RFC3339), err)
+	return d.OutboxRepo.MarkRetry(txCtx, m.ID, attempts, next, err.Error())
+}
+
+func (d *OutboxDispatcher) deadLetter(ctx context.Context, m *domain.OutboxMessage, attempts int, cause error) error {
+	log.Printf("outbox: dead-lettering %s message %d after %d attempt(s): %v", m.Topic, m.ID, attempts, cause)
+	return d.OutboxRepo.MarkDead(ctx, m.ID, attempts, cause.Error(), time.Now())
+}
+
+// backoff returns how long to wait after the given failed attempt, counting
+// from 1: between half and all of InitialBackoff doubled for every earlier
+// failure, capped at MaxBackoff.
+func (d *OutboxDispatcher) backoff(attempt int) time.Duration {
+	wait := d.InitialBackoff
+	for i := 1; i < attempt && wait < d.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if d.MaxBackoff > 0 && wait > d.MaxBackoff {
+		wait = d.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
+
+func isPermanent(err error) bool {
+	var p interface{ Permanent() bool }
+	return errors.As(err, &p) && p.Permanent()
+}
+
+// permanentError marks a failure that retrying cannot fix.
+type permanentError struct{ error }
+
+func (e permanentError) Unwrap() error   { return e.error }
+func (e permanentError) Permanent() bool { return true }
+
+// NotificationHandler sends the notifications queued under
+// domain.TopicNotification.
+func NotificationHandler(n repository.Notifier) OutboxHandler {
+	return func(ctx context.Context, m domain.OutboxMessage) error {
+		var notification domain.Notification
+		// Keep numbers such as loan IDs as they were written rather than
+		// turning them into floats.
+		dec := json.NewDecoder(bytes.NewReader(m.Payload))
+		dec.UseNumber()
+		if err := dec.Decode(&notification); err != nil {
+			return permanentError{fmt.Errorf("decode notification: %w", err)}
+		}
+		return n.Notify(ctx, notification)
+	}
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+
+	mockRepo "github.com/martinusiron/loan-service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// permanent is an error the dispatcher must not retry.
+type permanent struct{}
+
+func (permanent) Error() string   { return "550 no such user" }
+func (permanent) Permanent() bool { return true }
+
+func TestOutboxDispatcher_DispatchDue(t *testing.T) {
+	mockOutboxRepo := new(mockRepo.OutboxRepository)
+	db := newTestDB()
+
+	d := NewOutboxDispatcher(mockOutboxRepo, db)
+	d.InitialBackoff = time.Minute
+	d.MaxAttempts = 3
+
+	failures := map[int64]error{
+		2: errors.New("connection refused"),
+		3: errors.New("connection refused"),
+		4: permanent{},
+	}
+	var delivered []int64
+	d.Register("test", func(_ context.Context, m domain.OutboxMessage) error {
+		if err := failures[m.ID]; err != nil {
+			return err
+		}
+		delivered = append(delivered, m.ID)
+		return nil
+	})
+
+	now := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	for _, m := range []domain.OutboxMessage{
+		{ID: 1, Topic: "test"},
+		{ID: 2, Topic: "test", Attempts: 0},
+		{ID: 3, Topic: "test", Attempts: 2},
+		{ID: 4, Topic: "test"},
+		{ID: 5, Topic: "unknown"},
+	} {
+		mockOutboxRepo.On("ClaimNext", mock.Anything, now).Return(&m, nil).Once()
+	}
+	mockOutboxRepo.On("ClaimNext", mock.Anything, now).Return(nil, nil).Once()
+
+	mockOutboxRepo.On("MarkDone", mock.Anything, int64(1), 1, mock.AnythingOfType("time.Time")).Return(nil)
+	// A first failure is retried between half a minute and a minute later.
+	mockOutboxRepo.On("MarkRetry", mock.Anything, int64(2), 1, mock.MatchedBy(func(next time.Time) bool {
+		wait := time.Until(next)
+		return wait > 29*time.Second && wait <= time.Minute
+	}), "connection refused").Return(nil)
+	// Out of attempts, permanently failed and unroutable messages are
+	// dead-lettered.
+	mockOutboxRepo.On("MarkDead", mock.Anything, int64(3), 3, "connection refused", mock.AnythingOfType("time.Time")).Return(nil)
+	mockOutboxRepo.On("MarkDead", mock.Anything, int64(4), 1, "550 no such user", mock.AnythingOfType("time.Time")).Return(nil)
+	mockOutboxRepo.On("MarkDead", mock.Anything, int64(5), 1, `no handler for topic "unknown"`, mock.AnythingOfType("time.Time")).Return(nil)
+
+	n, err := d.DispatchDue(context.TODO(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []int64{1}, delivered)
+	mockOutboxRepo.AssertExpectations(t)
+}
+
+func TestOutboxDispatcher_BatchSize(t *testing.T) {
+	mockOutboxRepo := new(mockRepo.OutboxRepository)
+	db := newTestDB()
+
+	d := NewOutboxDispatcher(mockOutboxRepo, db)
+	d.BatchSize = 2
+	d.Register("test", func(context.Context, domain.OutboxMessage) error { return nil })
+
+	mockOutboxRepo.On("ClaimNext", mock.Anything, mock.Anything).Return(&domain.OutboxMessage{ID: 1, Topic: "test"}, nil)
+	mockOutboxRepo.On("MarkDone", mock.Anything, int64(1), 1, mock.Anything).Return(nil)
+
+	n, err := d.DispatchDue(context.TODO(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	mockOutboxRepo.AssertNumberOfCalls(t, "ClaimNext", 2)
+}
+
+func TestOutboxDispatcher_Backoff(t *testing.T) {
+	d := &OutboxDispatcher{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, max := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 30: 5 * time.Second} {
+		wait := d.backoff(attempt)
+		assert.GreaterOrEqual(t, wait, max/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, wait, max, "attempt %d", attempt)
+	}
+}
+
+func TestNotificationHandler(t *testing.T) {
+	mockNotifier := new(mockRepo.Notifier)
+	h := NotificationHandler(mockNotifier)
+
+	m, err := domain.NewOutboxMessage(domain.TopicNotification, "1000000", domain.Notification{
+		Kind: domain.NotifyLoanFunded,
+		To:   "a@a.com",
+		Data: map[string]any{"LoanID": 1000000, "Amount": "IDR 500,000.00"},
+	}, time.Now())
+	require.NoError(t, err)
+
+	// Loan IDs come back as written, not as 1e+06.
+	mockNotifier.On("Notify", mock.Anything, mock.MatchedBy(func(n domain.Notification) bool {
+		return n.Kind == domain.NotifyLoanFunded && n.To == "a@a.com" &&
+			n.Data["LoanID"] == json.Number("1000000") && n.Data["Amount"] == "IDR 500,000.00"
+	})).Return(nil).Once()
+	require.NoError(t, h(context.TODO(), *m))
+	mockNotifier.AssertExpectations(t)
+
+	err = h(context.TODO(), domain.OutboxMessage{Topic: domain.TopicNotification, Payload: []byte("{")})
+	assert.True(t, isPermanent(err), "a payload that cannot be decoded is never worth retrying")
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/ledger"
+	"github.com/martinusiron/loan-service/repository"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type RepaymentUsecase struct {
+	LoanRepo       repository.LoanRepository
+	ScheduleRepo   repository.ScheduleRepository
+	RepaymentRepo  repository.RepaymentRepository
+	InvestmentRepo repository.InvestmentRepository
+	HistoryRepo    repository.LoanHistoryRepository
+	LedgerRepo     repository.LedgerRepository
+	WalletRepo     repository.WalletRepository
+	Waterfall      domain.Waterfall
+	Events         repository.EventPublisher
+	DB             *sql.DB
+}
+
+func NewRepaymentUsecase(lr repository.LoanRepository, sr repository.ScheduleRepository, rp repository.RepaymentRepository, ir repository.InvestmentRepository, hr repository.LoanHistoryRepository, lg repository.LedgerRepository, wr repository.WalletRepository, waterfall domain.Waterfall, events repository.EventPublisher, db *sql.DB) *RepaymentUsecase {
+	if len(waterfall) == 0 {
+		waterfall = domain.DefaultWaterfall
+	}
+	return &RepaymentUsecase{
+		LoanRepo:       lr,
+		ScheduleRepo:   sr,
+		RepaymentRepo:  rp,
+		InvestmentRepo: ir,
+		HistoryRepo:    hr,
+		LedgerRepo:     lg,
+		WalletRepo:     wr,
+		Waterfall:      waterfall,
+		Events:         events,
+		DB:             db,
+	}
+}
+
+// RecordRepayment applies a borrower payment to the loan's schedule and shares
+// it out to the loan's investors pro rata. The first payment moves a disbursed
+// loan to repaying; the payment that settles the last instalment completes it.
+// Every payment publishes LoanRepaymentRecorded, and completion LoanCompleted.
+func (uc *RepaymentUsecase) RecordRepayment(ctx context.Context, payload dto.RecordRepaymentPayload) (*dto.RepaymentResult, error) {
+	var result *dto.RepaymentResult
+	err := utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
+		loan, err := uc.LoanRepo.GetLoanByIDForUpdate(txCtx, payload.LoanID)
+		if err != nil || loan == nil {
+			return errors.New("loan not found")
+		}
+
+		if loan.Status != domain.StatusDisbursed && loan.Status != domain.StatusRepaying {
+			return fmt.Errorf("%w: loan is %s", domain.ErrRepaymentNotAllowed, loan.Status)
+		}
+		if err := loan.PrincipalAmount.CheckCurrency(payload.Amount); err != nil {
+			return err
+		}
+
+		installments, err := uc.ScheduleRepo.GetScheduleByLoan(txCtx, loan.ID)
+		if err != nil {
+			return err
+		}
+		if len(installments) == 0 {
+			return fmt.Errorf("%w: loan has no repayment schedule", domain.ErrRepaymentNotAllowed)
+		}
+
+		repayment, touched, err := domain.AllocateRepayment(installments, payload.Amount, uc.Waterfall)
+		if err != nil {
+			return err
+		}
+		for _, idx := range touched {
+			if err := uc.ScheduleRepo.UpdateInstallmentPayment(txCtx, installments[idx]); err != nil {
+				return err
+			}
+		}
+
+		repayment.LoanID = loan.ID
+		repayment.Reference = payload.Reference
+		repayment.PaidAt = payload.Date
+
+		investments, err := uc.InvestmentRepo.GetInvestorsByLoan(txCtx, loan.ID)
+		if err != nil {
+			return err
+		}
+		dist, err := domain.DistributeRepayment(*loan, *repayment, investments)
+		if err != nil {
+			return err
+		}
+		repayment.Payouts = dist.Payouts
+		repayment.PlatformMargin = dist.PlatformMargin
+
+		if err := uc.RepaymentRepo.CreateRepayment(txCtx, repayment); err != nil {
+			return err
+		}
+		if err := creditPayouts(txCtx, uc.WalletRepo, repayment.Payouts); err != nil {
+			return err
+		}
+		if err := uc.LedgerRepo.PostEntry(txCtx, ledger.RepaymentEntry(*repayment)); err != nil {
+			return err
+		}
+
+		metadata := map[string]any{
+			"repayment_id": repayment.ID,
+			"amount":       repayment.Amount.String(),
+		}
+		settled := domain.ScheduleSettled(installments)
+		if loan.Status == domain.StatusDisbursed && !settled {
+			if err := transitionLoan(txCtx, uc.LoanRepo, uc.HistoryRepo, loan, domain.StatusRepaying, domain.TransitionContext{}, loan.BorrowerID, metadata); err != nil {
+				return err
+			}
+		}
+		if settled {
+			if err := transitionLoan(txCtx, uc.LoanRepo, uc.HistoryRepo, loan, domain.StatusCompleted, domain.TransitionContext{ScheduleSettled: true}, loan.BorrowerID, metadata); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		if err := uc.Events.Publish(txCtx, domain.LoanRepaymentRecorded{
+			LoanEvent:   domain.NewLoanEvent(loan.ID, now),
+			RepaymentID: repayment.ID,
+			Amount:      repayment.Amount,
+			Reference:   repayment.Reference,
+			PaidAt:      repayment.PaidAt,
+			LoanStatus:  loan.Status,
+		}); err != nil {
+			return err
+		}
+		if settled {
+			if err := uc.Events.Publish(txCtx, domain.LoanCompleted{
+				LoanEvent:   domain.NewLoanEvent(loan.ID, now),
+				CompletedAt: repayment.PaidAt,
+			}); err != nil {
+				return err
+			}
+		}
+
+		result = &dto.RepaymentResult{Repayment: repayment, LoanStatus: loan.Status}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/ledger"
+
+	mockRepo "github.com/martinusiron/loan-service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func repaymentSchedule(t *testing.T) []domain.Installment {
+	loan := domain.Loan{
+		ID:              1,
+		PrincipalAmount: domain.MustParseMoney("200000", domain.CurrencyIDR),
+		Rate:            12,
+		TenorMonths:     2,
+		RepaymentMethod: domain.RepaymentFlat,
+	}
+	insts, err := domain.GenerateSchedule(loan, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	for i := range insts {
+		insts[i].ID = i + 1
+	}
+	return insts
+}
+
+func repaymentInvestors() []domain.Investment {
+	return []domain.Investment{
+		{ID: 10, LoanID: 1, InvestorEmail: "a@example.com", Amount: domain.MustParseMoney("150000", domain.CurrencyIDR)},
+		{ID: 11, LoanID: 1, InvestorEmail: "b@example.com", Amount: domain.MustParseMoney("50000", domain.CurrencyIDR)},
+	}
+}
+
+// expectWalletCredit expects an empty wallet to be credited with amount.
+func expectWalletCredit(wr *mockRepo.WalletRepository, email, amount string) {
+	wr.On("GetWalletForUpdate", mock.Anything, email, domain.CurrencyIDR).Return(&domain.Wallet{
+		InvestorEmail: email,
+		Balance:       domain.Zero(domain.CurrencyIDR),
+		Held:          domain.Zero(domain.CurrencyIDR),
+	}, nil).Once()
+	wr.On("UpdateWallet", mock.Anything, mock.MatchedBy(func(w *domain.Wallet) bool {
+		return w.InvestorEmail == email && w.Balance.String() == amount
+	})).Return(nil).Once()
+}
+
+func TestRecordRepayment_FirstPaymentStartsRepaying(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockRepaymentRepo := new(mockRepo.RepaymentRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewRepaymentUsecase(mockLoanRepo, mockScheduleRepo, mockRepaymentRepo, mockInvestRepo, mockHistoryRepo, mockLedgerRepo, mockWalletRepo, domain.DefaultWaterfall, mockEvents, db)
+
+	loan := &domain.Loan{ID: 1, BorrowerID: "B001", Status: domain.StatusDisbursed, Rate: 12, ROI: 9, PrincipalAmount: domain.MustParseMoney("200000", domain.CurrencyIDR)}
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockScheduleRepo.On("GetScheduleByLoan", mock.Anything, 1).Return(repaymentSchedule(t), nil)
+	mockScheduleRepo.On("UpdateInstallmentPayment", mock.Anything, mock.MatchedBy(func(inst domain.Installment) bool {
+		return inst.ID == 1 && inst.Status == domain.InstallmentPaid
+	})).Return(nil).Once()
+	mockInvestRepo.On("GetInvestorsByLoan", mock.Anything, 1).Return(repaymentInvestors(), nil)
+	mockRepaymentRepo.On("CreateRepayment", mock.Anything, mock.AnythingOfType("*domain.Repayment")).Return(nil)
+	mockLedgerRepo.On("PostEntry", mock.Anything, mock.MatchedBy(func(e *ledger.Entry) bool {
+		return e.Kind == ledger.EntryRepayment && e.Validate() == nil
+	})).Return(nil)
+	expectWalletCredit(mockWalletRepo, "a@example.com", "76125.00")
+	expectWalletCredit(mockWalletRepo, "b@example.com", "25375.00")
+	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusRepaying).Return(nil)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.MatchedBy(func(h *domain.LoanStatusHistory) bool {
+		return h.FromStatus == domain.StatusDisbursed && h.ToStatus == domain.StatusRepaying && h.Actor == "B001"
+	})).Return(nil)
+	mockEvents.On("Publish", mock.Anything, mock.MatchedBy(func(e domain.LoanRepaymentRecorded) bool {
+		return e.LoanID == 1 && e.Amount.String() == "102000.00" && e.LoanStatus == domain.StatusRepaying
+	})).Return(nil).Once()
+
+	res, err := uc.RecordRepayment(context.TODO(), dto.RecordRepaymentPayload{
+		LoanID: 1,
+		Amount: domain.MustParseMoney("102000", domain.CurrencyIDR),
+		Date:   time.Now(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusRepaying, res.LoanStatus)
+	assert.Equal(t, "100000.00", res.Repayment.Principal.String())
+	assert.Equal(t, "2000.00", res.Repayment.Interest.String())
+	assert.Equal(t, "500.00", res.Repayment.PlatformMargin.String())
+	require.Len(t, res.Repayment.Payouts, 2)
+	assert.Equal(t, "75000.00", res.Repayment.Payouts[0].Principal.String())
+	assert.Equal(t, "1125.00", res.Repayment.Payouts[0].Return.String())
+	assert.Equal(t, "25000.00", res.Repayment.Payouts[1].Principal.String())
+	assert.Equal(t, "375.00", res.Repayment.Payouts[1].Return.String())
+	mockScheduleRepo.AssertExpectations(t)
+	mockLedgerRepo.AssertExpectations(t)
+	mockWalletRepo.AssertExpectations(t)
+	mockEvents.AssertExpectations(t)
+	mockEvents.AssertNumberOfCalls(t, "Publish", 1)
+}
+
+func TestRecordRepayment_FinalPaymentCompletes(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockRepaymentRepo := new(mockRepo.RepaymentRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewRepaymentUsecase(mockLoanRepo, mockScheduleRepo, mockRepaymentRepo, mockInvestRepo, mockHistoryRepo, mockLedgerRepo, mockWalletRepo, domain.DefaultWaterfall, mockEvents, db)
+
+	insts := repaymentSchedule(t)
+	insts[0].PrincipalPaid = insts[0].PrincipalDue
+	insts[0].InterestPaid = insts[0].InterestDue
+	insts[0].Status = domain.InstallmentPaid
+
+	loan := &domain.Loan{ID: 1, Status: domain.StatusRepaying, PrincipalAmount: domain.MustParseMoney("200000", domain.CurrencyIDR)}
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+	mockScheduleRepo.On("GetScheduleByLoan", mock.Anything, 1).Return(insts, nil)
+	mockScheduleRepo.On("UpdateInstallmentPayment", mock.Anything, mock.Anything).Return(nil)
+	mockInvestRepo.On("GetInvestorsByLoan", mock.Anything, 1).Return(repaymentInvestors(), nil)
+	mockRepaymentRepo.On("CreateRepayment", mock.Anything, mock.Anything).Return(nil)
+	mockLedgerRepo.On("PostEntry", mock.Anything, mock.Anything).Return(nil)
+	expectWalletCredit(mockWalletRepo, "a@example.com", "75000.00")
+	expectWalletCredit(mockWalletRepo, "b@example.com", "25000.00")
+	mockLoanRepo.On("UpdateLoanStatus", mock.Anything, 1, domain.StatusCompleted).Return(nil)
+	mockHistoryRepo.On("RecordStatusChange", mock.Anything, mock.Anything).Return(nil)
+	var published []domain.EventName
+	mockEvents.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(1).(domain.Event).EventName())
+	}).Return(nil)
+
+	res, err := uc.RecordRepayment(context.TODO(), dto.RecordRepaymentPayload{
+		LoanID: 1,
+		Amount: domain.MustParseMoney("110000", domain.CurrencyIDR),
+		Date:   time.Now(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCompleted, res.LoanStatus)
+	assert.Equal(t, "8000.00", res.Repayment.Excess.String())
+	assert.Equal(t, []domain.EventName{domain.EventLoanRepaymentRecorded, domain.EventLoanCompleted}, published)
+}
+
+func TestRecordRepayment_NotDisbursed(t *testing.T) {
+	mockLoanRepo := new(mockRepo.LoanRepository)
+	mockScheduleRepo := new(mockRepo.ScheduleRepository)
+	mockRepaymentRepo := new(mockRepo.RepaymentRepository)
+	mockInvestRepo := new(mockRepo.InvestmentRepository)
+	mockHistoryRepo := new(mockRepo.LoanHistoryRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockEvents := new(mockRepo.EventPublisher)
+	db := newTestDB()
+
+	uc := NewRepaymentUsecase(mockLoanRepo, mockScheduleRepo, mockRepaymentRepo, mockInvestRepo, mockHistoryRepo, mockLedgerRepo, mockWalletRepo, domain.DefaultWaterfall, mockEvents, db)
+
+	loan := &domain.Loan{ID: 1, Status: domain.StatusInvested}
+	mockLoanRepo.On("GetLoanByIDForUpdate", mock.Anything, 1).Return(loan, nil)
+
+	_, err := uc.RecordRepayment(context.TODO(), dto.RecordRepaymentPayload{
+		LoanID: 1,
+		Amount: domain.MustParseMoney("1000", domain.CurrencyIDR),
+		Date:   time.Now(),
+	})
+	assert.ErrorIs(t, err, domain.ErrRepaymentNotAllowed)
+	mockRepaymentRepo.AssertNotCalled(t, "CreateRepayment", mock.Anything, mock.Anything)
+	mockEvents.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
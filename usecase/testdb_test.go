@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// noopDriver lets utils.WithTransaction begin and commit against a *sql.DB
+// while every query is served by the repository mocks.
+type noopDriver struct{}
+
+type noopConn struct{}
+
+type noopTx struct{}
+
+func (noopDriver) Open(string) (driver.Conn, error) { return noopConn{}, nil }
+
+func (noopConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("noop driver does not execute queries")
+}
+func (noopConn) Close() error              { return nil }
+func (noopConn) Begin() (driver.Tx, error) { return noopTx{}, nil }
+func (noopConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return noopTx{}, nil
+}
+
+func (noopTx) Commit() error   { return nil }
+func (noopTx) Rollback() error { return nil }
+
+func init() {
+	sql.Register("noop", noopDriver{})
+}
+
+func newTestDB() *sql.DB {
+	db, _ := sql.Open("noop", "")
+	return db
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/ledger"
+	"github.com/martinusiron/loan-service/repository"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+type WalletUsecase struct {
+	WalletRepo repository.WalletRepository
+	LedgerRepo repository.LedgerRepository
+	DB         *sql.DB
+}
+
+func NewWalletUsecase(wr repository.WalletRepository, lg repository.LedgerRepository, db *sql.DB) *WalletUsecase {
+	return &WalletUsecase{
+		WalletRepo: wr,
+		LedgerRepo: lg,
+		DB:         db,
+	}
+}
+
+// GetWallet returns the investor's wallet. Investors who never topped up get
+// an empty wallet rather than an error.
+func (uc *WalletUsecase) GetWallet(ctx context.Context, email string) (*domain.Wallet, error) {
+	w, err := uc.WalletRepo.GetWallet(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		w = &domain.Wallet{
+			InvestorEmail: email,
+			Balance:       domain.Zero(domain.DefaultCurrency),
+			Held:          domain.Zero(domain.DefaultCurrency),
+		}
+	}
+	return w, nil
+}
+
+func (uc *WalletUsecase) TopUp(ctx context.Context, payload dto.WalletTransferPayload) (*domain.Wallet, error) {
+	var wallet *domain.Wallet
+	err := utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
+		w, err := uc.WalletRepo.GetWalletForUpdate(txCtx, payload.InvestorEmail, payload.Amount.Currency)
+		if err != nil {
+			return err
+		}
+		if err := w.Balance.CheckCurrency(payload.Amount); err != nil {
+			return err
+		}
+
+		w.Balance = w.Balance.Add(payload.Amount)
+		if err := uc.WalletRepo.UpdateWallet(txCtx, w); err != nil {
+			return err
+		}
+		wallet = w
+		return uc.LedgerRepo.PostEntry(txCtx, ledger.TopUpEntry(payload.InvestorEmail, payload.Amount, payload.Reference))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// Withdraw pays money out of the wallet. Only the available balance can be
+// withdrawn; funds held for pending investments stay put.
+func (uc *WalletUsecase) Withdraw(ctx context.Context, payload dto.WalletTransferPayload) (*domain.Wallet, error) {
+	var wallet *domain.Wallet
+	err := utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
+		w, err := uc.WalletRepo.GetWalletForUpdate(txCtx, payload.InvestorEmail, payload.Amount.Currency)
+		if err != nil {
+			return err
+		}
+		if err := w.Reserve(payload.Amount); err != nil {
+			return err
+		}
+
+		w.Balance = w.Balance.Sub(payload.Amount)
+		if err := uc.WalletRepo.UpdateWallet(txCtx, w); err != nil {
+			return err
+		}
+		wallet = w
+		return uc.LedgerRepo.PostEntry(txCtx, ledger.WithdrawalEntry(payload.InvestorEmail, payload.Amount, payload.Reference))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// placeHold reserves amount in the investor's wallet for an investment in the
+// loan. It fails with an *domain.InsufficientFundsError when the available
+// balance is too low.
+func placeHold(ctx context.Context, wr repository.WalletRepository, email string, loanID int, amount domain.Money) error {
+	w, err := wr.GetWalletForUpdate(ctx, email, amount.Currency)
+	if err != nil {
+		return err
+	}
+	if err := w.Reserve(amount); err != nil {
+		return err
+	}
+
+	w.Held = w.Held.Add(amount)
+	if err := wr.UpdateWallet(ctx, w); err != nil {
+		return err
+	}
+	return wr.CreateHold(ctx, &domain.WalletHold{
+		InvestorEmail: email,
+		LoanID:        loanID,
+		Amount:        amount,
+		Status:        domain.HoldActive,
+	})
+}
+
+// captureLoanHolds turns every active hold on the loan into a debit of the
+// investor's wallet and posts the matching ledger entry.
+func captureLoanHolds(ctx context.Context, wr repository.WalletRepository, lg repository.LedgerRepository, loanID int) error {
+	return settleLoanHolds(ctx, wr, loanID, domain.HoldCaptured, func(w *domain.Wallet, h domain.WalletHold) error {
+		w.Balance = w.Balance.Sub(h.Amount)
+		return lg.PostEntry(ctx, ledger.InvestmentEntry(domain.Investment{
+			LoanID:        h.LoanID,
+			InvestorEmail: h.InvestorEmail,
+			Amount:        h.Amount,
+		}))
+	})
+}
+
+// releaseLoanHolds gives every active hold on the loan back to the investors'
+// available balance, for loans that will not be disbursed.
+func releaseLoanHolds(ctx context.Context, wr repository.WalletRepository, loanID int) error {
+	return settleLoanHolds(ctx, wr, loanID, domain.HoldReleased, nil)
+}
+
+// creditPayouts pays repayment payouts into the investors' wallets. Wallets
+// are locked in email order so concurrent repayments cannot deadlock.
+func creditPayouts(ctx context.Context, wr repository.WalletRepository, payouts []domain.InvestorPayout) error {
+	totals := map[string]domain.Money{}
+	var emails []string
+	for _, p := range payouts {
+		amount := p.Principal.Add(p.Return)
+		if prev, ok := totals[p.InvestorEmail]; ok {
+			totals[p.InvestorEmail] = prev.Add(amount)
+			continue
+		}
+		totals[p.InvestorEmail] = amount
+		emails = append(emails, p.InvestorEmail)
+	}
+	sort.Strings(emails)
+
+	for _, email := range emails {
+		amount := totals[email]
+		if amount.IsZero() {
+			continue
+		}
+		w, err := wr.GetWalletForUpdate(ctx, email, amount.Currency)
+		if err != nil {
+			return err
+		}
+		w.Balance = w.Balance.Add(amount)
+		if err := wr.UpdateWallet(ctx, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func settleLoanHolds(ctx context.Context, wr repository.WalletRepository, loanID int, status domain.HoldStatus, apply func(*domain.Wallet, domain.WalletHold) error) error {
+	holds, err := wr.GetActiveHoldsByLoan(ctx, loanID)
+	if err != nil {
+		return err
+	}
+
+	for _, h := range holds {
+		w, err := wr.GetWalletForUpdate(ctx, h.InvestorEmail, h.Amount.Currency)
+		if err != nil {
+			return err
+		}
+		if w.Held.Cmp(h.Amount) < 0 {
+			return errors.New("wallet holds less than the hold being settled")
+		}
+
+		w.Held = w.Held.Sub(h.Amount)
+		if apply != nil {
+			if err := apply(w, h); err != nil {
+				return err
+			}
+		}
+		if err := wr.UpdateWallet(ctx, w); err != nil {
+			return err
+		}
+		if err := wr.UpdateHoldStatus(ctx, h.ID, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
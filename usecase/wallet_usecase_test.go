@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/ledger"
+
+	mockRepo "github.com/martinusiron/loan-service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopUp(t *testing.T) {
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	db := newTestDB()
+
+	uc := NewWalletUsecase(mockWalletRepo, mockLedgerRepo, db)
+
+	mockWalletRepo.On("GetWalletForUpdate", mock.Anything, "a@a.com", domain.CurrencyIDR).Return(&domain.Wallet{
+		InvestorEmail: "a@a.com",
+		Balance:       domain.MustParseMoney("100", domain.CurrencyIDR),
+		Held:          domain.Zero(domain.CurrencyIDR),
+	}, nil)
+	mockWalletRepo.On("UpdateWallet", mock.Anything, mock.AnythingOfType("*domain.Wallet")).Return(nil)
+	mockLedgerRepo.On("PostEntry", mock.Anything, mock.MatchedBy(func(e *ledger.Entry) bool {
+		return e.Kind == ledger.EntryTopUp && e.Validate() == nil
+	})).Return(nil)
+
+	w, err := uc.TopUp(context.TODO(), dto.WalletTransferPayload{
+		InvestorEmail: "a@a.com",
+		Amount:        domain.MustParseMoney("50.25", domain.CurrencyIDR),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "150.25", w.Balance.String())
+	mockLedgerRepo.AssertExpectations(t)
+}
+
+func TestWithdraw_OnlyAvailableBalance(t *testing.T) {
+	mockWalletRepo := new(mockRepo.WalletRepository)
+	mockLedgerRepo := new(mockRepo.LedgerRepository)
+	db := newTestDB()
+
+	uc := NewWalletUsecase(mockWalletRepo, mockLedgerRepo, db)
+
+	mockWalletRepo.On("GetWalletForUpdate", mock.Anything, "a@a.com", domain.CurrencyIDR).Return(func(context.Context, string, domain.Currency) *domain.Wallet {
+		return &domain.Wallet{
+			InvestorEmail: "a@a.com",
+			Balance:       domain.MustParseMoney("1000", domain.CurrencyIDR),
+			Held:          domain.MustParseMoney("600", domain.CurrencyIDR),
+		}
+	}, nil)
+	mockWalletRepo.On("UpdateWallet", mock.Anything, mock.AnythingOfType("*domain.Wallet")).Return(nil)
+	mockLedgerRepo.On("PostEntry", mock.Anything, mock.AnythingOfType("*ledger.Entry")).Return(nil)
+
+	_, err := uc.Withdraw(context.TODO(), dto.WalletTransferPayload{
+		InvestorEmail: "a@a.com",
+		Amount:        domain.MustParseMoney("400.01", domain.CurrencyIDR),
+	})
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	w, err := uc.Withdraw(context.TODO(), dto.WalletTransferPayload{
+		InvestorEmail: "a@a.com",
+		Amount:        domain.MustParseMoney("400", domain.CurrencyIDR),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "600.00", w.Balance.String())
+	assert.True(t, w.Available().IsZero())
+}
+
+func TestReleaseLoanHolds(t *testing.T) {
+	mockWalletRepo := new(mockRepo.WalletRepository)
+
+	mockWalletRepo.On("GetActiveHoldsByLoan", mock.Anything, 7).Return([]domain.WalletHold{
+		{ID: 1, InvestorEmail: "a@a.com", LoanID: 7, Amount: domain.MustParseMoney("250", domain.CurrencyIDR), Status: domain.HoldActive},
+	}, nil)
+	mockWalletRepo.On("GetWalletForUpdate", mock.Anything, "a@a.com", domain.CurrencyIDR).Return(&domain.Wallet{
+		InvestorEmail: "a@a.com",
+		Balance:       domain.MustParseMoney("1000", domain.CurrencyIDR),
+		Held:          domain.MustParseMoney("250", domain.CurrencyIDR),
+	}, nil)
+	mockWalletRepo.On("UpdateWallet", mock.Anything, mock.MatchedBy(func(w *domain.Wallet) bool {
+		return w.Balance.String() == "1000.00" && w.Held.IsZero()
+	})).Return(nil)
+	mockWalletRepo.On("UpdateHoldStatus", mock.Anything, 1, domain.HoldReleased).Return(nil)
+
+	require.NoError(t, releaseLoanHolds(context.TODO(), mockWalletRepo, 7))
+	mockWalletRepo.AssertExpectations(t)
+}
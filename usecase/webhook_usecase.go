@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+	"github.com/martinusiron/loan-service/repository"
+	"github.com/martinusiron/loan-service/utils"
+)
+
+// WebhookUsecase manages webhook subscriptions and delivers loan events to
+// them. HandleEvent fans an event out to the subscribed webhooks inside the
+// publisher's transaction; the deliveries are then attempted, and retried
+// with backoff, by the outbox dispatcher through DeliveryHandler.
+type WebhookUsecase struct {
+	WebhookRepo  repository.WebhookRepository
+	DeliveryRepo repository.WebhookDeliveryRepository
+	OutboxRepo   repository.OutboxRepository
+	Sender       repository.WebhookSender
+	DB           *sql.DB
+}
+
+func NewWebhookUsecase(wr repository.WebhookRepository, dr repository.WebhookDeliveryRepository, or repository.OutboxRepository, sender repository.WebhookSender, db *sql.DB) *WebhookUsecase {
+	return &WebhookUsecase{WebhookRepo: wr, DeliveryRepo: dr, OutboxRepo: or, Sender: sender, DB: db}
+}
+
+func (uc *WebhookUsecase) CreateWebhook(ctx context.Context, payload dto.WebhookPayload) (*domain.Webhook, error) {
+	w := &domain.Webhook{
+		URL:        payload.URL,
+		EventTypes: payload.EventTypes,
+		Secret:     payload.Secret,
+		Status:     domain.WebhookActive,
+	}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.WebhookRepo.CreateWebhook(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (uc *WebhookUsecase) GetWebhook(ctx context.Context, id int) (*domain.Webhook, error) {
+	return uc.WebhookRepo.GetWebhookByID(ctx, id)
+}
+
+func (uc *WebhookUsecase) ListWebhooks(ctx context.Context, q dto.PageQuery) (*dto.WebhookPage, error) {
+	f := domain.WebhookFilter{Limit: domain.DefaultPageSize}
+	if q.Limit > 0 {
+		f.Limit = min(q.Limit, domain.MaxPageSize)
+	}
+	if q.Cursor != "" {
+		after, err := domain.DecodeIDCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		f.AfterID = after
+	}
+
+	limit := f.Limit
+	f.Limit++
+	webhooks, err := uc.WebhookRepo.ListWebhooks(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.WebhookPage{Data: webhooks}
+	if len(webhooks) > limit {
+		page.Data = webhooks[:limit]
+		page.NextCursor = domain.EncodeIDCursor(page.Data[limit-1].ID)
+	}
+	return page, nil
+}
+
+// UpdateWebhook replaces the webhook's settings, or returns nil if the
+// webhook does not exist.
+func (uc *WebhookUsecase) UpdateWebhook(ctx context.Context, payload dto.UpdateWebhookPayload) (*domain.Webhook, error) {
+	w, err := uc.WebhookRepo.GetWebhookByID(ctx, payload.ID)
+	if err != nil || w == nil {
+		return nil, err
+	}
+
+	w.URL = payload.URL
+	w.EventTypes = payload.EventTypes
+	w.Status = payload.Status
+	if payload.Secret != "" {
+		w.Secret = payload.Secret
+	}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.WebhookRepo.UpdateWebhook(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// DeleteWebhook removes the webhook together with its delivery log, and
+// reports false if it did not exist. Deliveries still queued are dropped.
+func (uc *WebhookUsecase) DeleteWebhook(ctx context.Context, id int) (bool, error) {
+	return uc.WebhookRepo.DeleteWebhook(ctx, id)
+}
+
+// ListDeliveries returns one page of the webhook's deliveries, newest
+// first, or nil if the webhook does not exist.
+func (uc *WebhookUsecase) ListDeliveries(ctx context.Context, q dto.ListWebhookDeliveriesQuery) (*dto.WebhookDeliveryPage, error) {
+	w, err := uc.WebhookRepo.GetWebhookByID(ctx, q.WebhookID)
+	if err != nil || w == nil {
+		return nil, err
+	}
+
+	f := domain.WebhookDeliveryFilter{
+		WebhookID: w.ID,
+		Status:    domain.WebhookDeliveryStatus(q.Status),
+		Limit:     domain.DefaultPageSize,
+	}
+	if q.Limit > 0 {
+		f.Limit = min(q.Limit, domain.MaxPageSize)
+	}
+	if q.Cursor != "" {
+		before, err := domain.DecodeIDCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		f.BeforeID = before
+	}
+
+	limit := f.Limit
+	f.Limit++
+	deliveries, err := uc.DeliveryRepo.ListDeliveries(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.WebhookDeliveryPage{Data: deliveries}
+	if len(deliveries) > limit {
+		page.Data = deliveries[:limit]
+		page.NextCursor = domain.EncodeIDCursor(page.Data[limit-1].ID)
+	}
+	return page, nil
+}
+
+// Redeliver queues a finished delivery to be sent again, with the same
+// payload and delivery ID. It returns nil if the webhook has no such
+// delivery.
+func (uc *WebhookUsecase) Redeliver(ctx context.Context, webhookID, deliveryID int) (*domain.WebhookDelivery, error) {
+	var d *domain.WebhookDelivery
+	err := utils.WithTransaction(ctx, uc.DB, func(txCtx context.Context) error {
+		w, err := uc.WebhookRepo.GetWebhookByID(txCtx, webhookID)
+		if err != nil || w == nil {
+			return err
+		}
+		if w.Status != domain.WebhookActive {
+			return domain.ErrWebhookInactive
+		}
+
+		found, err := uc.DeliveryRepo.GetDeliveryByID(txCtx, deliveryID)
+		if err != nil || found == nil || found.WebhookID != w.ID {
+			return err
+		}
+		if found.Status == domain.DeliveryPending || found.Status == domain.DeliveryRetrying {
+			return domain.ErrWebhookDeliveryInProgress
+		}
+
+		found.Status = domain.DeliveryPending
+		if err := uc.DeliveryRepo.UpdateDelivery(txCtx, found); err != nil {
+			return err
+		}
+		if err := uc.queueDelivery(txCtx, found, time.Now()); err != nil {
+			return err
+		}
+		d = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// HandleEvent records a delivery of e for every active webhook subscribed
+// to it and queues them in the outbox. Subscribe it synchronously, so the
+// deliveries are only sent if the change commits.
+func (uc *WebhookUsecase) HandleEvent(ctx context.Context, e domain.Event) error {
+	webhooks, err := uc.WebhookRepo.ListActiveWebhooksForEvent(ctx, e.EventName())
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	for _, w := range webhooks {
+		d := &domain.WebhookDelivery{
+			WebhookID: w.ID,
+			EventName: e.EventName(),
+			LoanID:    e.AggregateID(),
+			Payload:   payload,
+			Status:    domain.DeliveryPending,
+			CreatedAt: e.OccurredAt(),
+		}
+		if err := uc.DeliveryRepo.CreateDelivery(ctx, d); err != nil {
+			return err
+		}
+		if err := uc.queueDelivery(ctx, d, e.OccurredAt()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queueDelivery asks the outbox to attempt d. A webhook gets the events of a
+// loan in order, and a failing webhook does not hold back the others.
+func (uc *WebhookUsecase) queueDelivery(ctx context.Context, d *domain.WebhookDelivery, now time.Time) error {
+	key := strconv.Itoa(d.WebhookID) + "/" + strconv.Itoa(d.LoanID)
+	m, err := domain.NewOutboxMessage(domain.TopicWebhook, key, domain.WebhookDeliveryJob{DeliveryID: d.ID}, now)
+	if err != nil {
+		return err
+	}
+	return uc.OutboxRepo.Enqueue(ctx, m)
+}
+
+// DeliveryHandler attempts the deliveries queued under domain.TopicWebhook
+// and records every attempt in the delivery log. maxAttempts must match the
+// dispatcher's, so the log shows a delivery as failed once the dispatcher
+// gives up on it.
+func (uc *WebhookUsecase) DeliveryHandler(maxAttempts int) OutboxHandler {
+	return func(ctx context.Context, m domain.OutboxMessage) error {
+		var job domain.WebhookDeliveryJob
+		if err := json.Unmarshal(m.Payload, &job); err != nil {
+			return permanentError{fmt.Errorf("decode webhook delivery: %w", err)}
+		}
+
+		// The delivery and its webhook are gone when the webhook was
+		// deleted in the meantime.
+		d, err := uc.DeliveryRepo.GetDeliveryByID(ctx, job.DeliveryID)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return permanentError{fmt.Errorf("webhook delivery %d no longer exists", job.DeliveryID)}
+		}
+		w, err := uc.WebhookRepo.GetWebhookByID(ctx, d.WebhookID)
+		if err != nil {
+			return err
+		}
+		if w == nil {
+			return permanentError{domain.ErrWebhookNotFound}
+		}
+
+		now := time.Now()
+		d.Attempts++
+		d.LastAttemptAt = &now
+
+		var sendErr error
+		if w.Status != domain.WebhookActive {
+			d.ResponseStatus, sendErr = 0, permanentError{domain.ErrWebhookInactive}
+		} else {
+			d.ResponseStatus, sendErr = uc.Sender.Send(ctx, *w, *d)
+		}
+
+		switch {
+		case sendErr == nil:
+			d.Status = domain.DeliverySucceeded
+			d.LastError = ""
+			d.DeliveredAt = &now
+		case isPermanent(sendErr) || m.Attempts+1 >= maxAttempts:
+			d.Status = domain.DeliveryFailed
+			d.LastError = sendErr.Error()
+		default:
+			d.Status = domain.DeliveryRetrying
+			d.LastError = sendErr.Error()
+		}
+		if err := uc.DeliveryRepo.UpdateDelivery(ctx, d); err != nil {
+			return errors.Join(sendErr, err)
+		}
+		return sendErr
+	}
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+	"github.com/martinusiron/loan-service/dto"
+
+	mockRepo "github.com/martinusiron/loan-service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newWebhookTestUsecase() (*WebhookUsecase, *mockRepo.WebhookRepository, *mockRepo.WebhookDeliveryRepository, *mockRepo.OutboxRepository, *mockRepo.WebhookSender) {
+	mockWebhookRepo := new(mockRepo.WebhookRepository)
+	mockDeliveryRepo := new(mockRepo.WebhookDeliveryRepository)
+	mockOutboxRepo := new(mockRepo.OutboxRepository)
+	mockSender := new(mockRepo.WebhookSender)
+	db := newTestDB()
+
+	uc := NewWebhookUsecase(mockWebhookRepo, mockDeliveryRepo, mockOutboxRepo, mockSender, db)
+	return uc, mockWebhookRepo, mockDeliveryRepo, mockOutboxRepo, mockSender
+}
+
+// queuedDelivery matches the outbox message asking for the delivery of loan
+// 1's event to the webhook.
+func queuedDelivery(webhookID, id int) interface{} {
+	return mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+		var job domain.WebhookDeliveryJob
+		return m.Topic == domain.TopicWebhook && m.Key == strconv.Itoa(webhookID)+"/1" &&
+			json.Unmarshal(m.Payload, &job) == nil && job.DeliveryID == id
+	})
+}
+
+func TestCreateWebhook_Validates(t *testing.T) {
+	uc, mockWebhookRepo, _, _, _ := newWebhookTestUsecase()
+
+	mockWebhookRepo.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(w *domain.Webhook) bool {
+		return w.Status == domain.WebhookActive && w.Secret == "0123456789abcdef"
+	})).Return(nil).Once()
+
+	payload := dto.WebhookPayload{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []domain.EventName{domain.EventLoanApproved},
+		Secret:     "0123456789abcdef",
+	}
+	_, err := uc.CreateWebhook(context.TODO(), payload)
+	require.NoError(t, err)
+
+	payload.EventTypes = []domain.EventName{"loan.updated"}
+	_, err = uc.CreateWebhook(context.TODO(), payload)
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+
+	payload.EventTypes = []domain.EventName{domain.EventLoanApproved}
+	payload.URL = "ftp://partner.example.com/hooks"
+	_, err = uc.CreateWebhook(context.TODO(), payload)
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+	mockWebhookRepo.AssertNumberOfCalls(t, "CreateWebhook", 1)
+}
+
+func TestUpdateWebhook_KeepsSecretUnlessGiven(t *testing.T) {
+	uc, mockWebhookRepo, _, _, _ := newWebhookTestUsecase()
+
+	mockWebhookRepo.On("GetWebhookByID", mock.Anything, 3).Return(&domain.Webhook{
+		ID: 3, URL: "https://old.example.com", EventTypes: []domain.EventName{domain.EventLoanCreated}, Secret: "old-secret-0123456", Status: domain.WebhookActive,
+	}, nil)
+	mockWebhookRepo.On("UpdateWebhook", mock.Anything, mock.Anything).Return(nil)
+
+	w, err := uc.UpdateWebhook(context.TODO(), dto.UpdateWebhookPayload{
+		ID: 3, URL: "https://new.example.com", EventTypes: []domain.EventName{domain.EventLoanDisbursed}, Status: domain.WebhookInactive,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://new.example.com", w.URL)
+	assert.Equal(t, "old-secret-0123456", w.Secret)
+	assert.Equal(t, domain.WebhookInactive, w.Status)
+}
+
+func TestWebhookHandleEvent(t *testing.T) {
+	uc, mockWebhookRepo, mockDeliveryRepo, mockOutboxRepo, _ := newWebhookTestUsecase()
+
+	at := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	e := domain.LoanApproved{LoanEvent: domain.NewLoanEvent(1, at), EmployeeID: "EMP01"}
+
+	mockWebhookRepo.On("ListActiveWebhooksForEvent", mock.Anything, domain.EventLoanApproved).Return([]domain.Webhook{{ID: 3}, {ID: 4}}, nil)
+	nextID := 10
+	mockDeliveryRepo.On("CreateDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+		var payload domain.LoanApproved
+		return d.EventName == domain.EventLoanApproved && d.LoanID == 1 && d.Status == domain.DeliveryPending &&
+			json.Unmarshal(d.Payload, &payload) == nil && payload.EmployeeID == "EMP01"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.WebhookDelivery).ID = nextID
+		nextID++
+	}).Return(nil).Twice()
+	mockOutboxRepo.On("Enqueue", mock.Anything, queuedDelivery(3, 10)).Return(nil).Once()
+	mockOutboxRepo.On("Enqueue", mock.Anything, queuedDelivery(4, 11)).Return(nil).Once()
+
+	require.NoError(t, uc.HandleEvent(context.TODO(), e))
+	mockDeliveryRepo.AssertExpectations(t)
+	mockOutboxRepo.AssertExpectations(t)
+
+	// Nothing is recorded when nobody is subscribed.
+	mockWebhookRepo.On("ListActiveWebhooksForEvent", mock.Anything, domain.EventLoanCreated).Return([]domain.Webhook{}, nil)
+	require.NoError(t, uc.HandleEvent(context.TODO(), domain.LoanCreated{LoanEvent: domain.NewLoanEvent(1, at)}))
+	mockDeliveryRepo.AssertNumberOfCalls(t, "CreateDelivery", 2)
+}
+
+func TestWebhookDeliveryHandler(t *testing.T) {
+	job, err := domain.NewOutboxMessage(domain.TopicWebhook, "1", domain.WebhookDeliveryJob{DeliveryID: 10}, time.Now())
+	require.NoError(t, err)
+	hook := &domain.Webhook{ID: 3, URL: "https://partner.example.com", Status: domain.WebhookActive}
+
+	for _, tt := range []struct {
+		name       string
+		attempts   int
+		sendStatus int
+		sendErr    error
+		wantStatus domain.WebhookDeliveryStatus
+	}{
+		{name: "delivered", sendStatus: 204, wantStatus: domain.DeliverySucceeded},
+		{name: "retried", sendStatus: 503, sendErr: errors.New("webhook endpoint responded 503"), wantStatus: domain.DeliveryRetrying},
+		{name: "out of attempts", attempts: 2, sendErr: errors.New("connection refused"), wantStatus: domain.DeliveryFailed},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, mockWebhookRepo, mockDeliveryRepo, _, mockSender := newWebhookTestUsecase()
+
+			mockDeliveryRepo.On("GetDeliveryByID", mock.Anything, 10).Return(&domain.WebhookDelivery{ID: 10, WebhookID: 3, Attempts: tt.attempts}, nil)
+			mockWebhookRepo.On("GetWebhookByID", mock.Anything, 3).Return(hook, nil)
+			mockSender.On("Send", mock.Anything, *hook, mock.Anything).Return(tt.sendStatus, tt.sendErr).Once()
+			mockDeliveryRepo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+				return d.Status == tt.wantStatus && d.Attempts == tt.attempts+1 && d.ResponseStatus == tt.sendStatus &&
+					d.LastAttemptAt != nil && (d.DeliveredAt != nil) == (tt.sendErr == nil)
+			})).Return(nil).Once()
+
+			m := *job
+			m.Attempts = tt.attempts
+			err := uc.DeliveryHandler(3)(context.TODO(), m)
+			assert.Equal(t, tt.sendErr, err)
+			mockDeliveryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookDeliveryHandler_Permanent(t *testing.T) {
+	uc, mockWebhookRepo, mockDeliveryRepo, _, mockSender := newWebhookTestUsecase()
+	job, err := domain.NewOutboxMessage(domain.TopicWebhook, "1", domain.WebhookDeliveryJob{DeliveryID: 10}, time.Now())
+	require.NoError(t, err)
+
+	// A webhook deactivated since the event is not sent to.
+	mockDeliveryRepo.On("GetDeliveryByID", mock.Anything, 10).Return(&domain.WebhookDelivery{ID: 10, WebhookID: 3}, nil).Once()
+	mockWebhookRepo.On("GetWebhookByID", mock.Anything, 3).Return(&domain.Webhook{ID: 3, Status: domain.WebhookInactive}, nil)
+	mockDeliveryRepo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+		return d.Status == domain.DeliveryFailed && d.LastError == domain.ErrWebhookInactive.Error()
+	})).Return(nil).Once()
+
+	err = uc.DeliveryHandler(10)(context.TODO(), *job)
+	assert.True(t, isPermanent(err))
+	mockSender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+
+	// A deleted webhook takes its deliveries with it.
+	mockDeliveryRepo.On("GetDeliveryByID", mock.Anything, 10).Return(nil, nil).Once()
+	err = uc.DeliveryHandler(10)(context.TODO(), *job)
+	assert.True(t, isPermanent(err))
+	mockDeliveryRepo.AssertExpectations(t)
+}
+
+func TestRedeliver(t *testing.T) {
+	uc, mockWebhookRepo, mockDeliveryRepo, mockOutboxRepo, _ := newWebhookTestUsecase()
+
+	mockWebhookRepo.On("GetWebhookByID", mock.Anything, 3).Return(&domain.Webhook{ID: 3, Status: domain.WebhookActive}, nil)
+	mockDeliveryRepo.On("GetDeliveryByID", mock.Anything, 10).Return(&domain.WebhookDelivery{ID: 10, WebhookID: 3, LoanID: 1, Status: domain.DeliveryFailed, Attempts: 10}, nil)
+	mockDeliveryRepo.On("GetDeliveryByID", mock.Anything, 11).Return(&domain.WebhookDelivery{ID: 11, WebhookID: 3, LoanID: 1, Status: domain.DeliveryRetrying}, nil)
+	mockDeliveryRepo.On("GetDeliveryByID", mock.Anything, 12).Return(&domain.WebhookDelivery{ID: 12, WebhookID: 4, LoanID: 1, Status: domain.DeliveryFailed}, nil)
+	mockDeliveryRepo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+		return d.ID == 10 && d.Status == domain.DeliveryPending && d.Attempts == 10
+	})).Return(nil).Once()
+	mockOutboxRepo.On("Enqueue", mock.Anything, queuedDelivery(3, 10)).Return(nil).Once()
+
+	d, err := uc.Redeliver(context.TODO(), 3, 10)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeliveryPending, d.Status)
+
+	_, err = uc.Redeliver(context.TODO(), 3, 11)
+	assert.ErrorIs(t, err, domain.ErrWebhookDeliveryInProgress)
+
+	// Deliveries of another webhook are not found under this one.
+	d, err = uc.Redeliver(context.TODO(), 3, 12)
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	mockDeliveryRepo.AssertExpectations(t)
+	mockOutboxRepo.AssertExpectations(t)
+}
+
+func TestListDeliveries_Paginates(t *testing.T) {
+	uc, mockWebhookRepo, mockDeliveryRepo, _, _ := newWebhookTestUsecase()
+
+	mockWebhookRepo.On("GetWebhookByID", mock.Anything, 3).Return(&domain.Webhook{ID: 3}, nil)
+	mockWebhookRepo.On("GetWebhookByID", mock.Anything, 4).Return(nil, nil)
+	mockDeliveryRepo.On("ListDeliveries", mock.Anything, domain.WebhookDeliveryFilter{WebhookID: 3, Status: domain.DeliveryFailed, BeforeID: 9, Limit: 3}).Return([]domain.WebhookDelivery{
+		{ID: 8}, {ID: 6}, {ID: 5},
+	}, nil)
+
+	page, err := uc.ListDeliveries(context.TODO(), dto.ListWebhookDeliveriesQuery{WebhookID: 3, Status: "failed", Limit: 2, Cursor: domain.EncodeIDCursor(9)})
+	require.NoError(t, err)
+	assert.Len(t, page.Data, 2)
+	assert.Equal(t, domain.EncodeIDCursor(6), page.NextCursor)
+
+	page, err = uc.ListDeliveries(context.TODO(), dto.ListWebhookDeliveriesQuery{WebhookID: 4})
+	require.NoError(t, err)
+	assert.Nil(t, page)
+}
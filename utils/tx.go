@@ -7,6 +7,8 @@ import (
 
 type txKey struct{}
 
+type afterCommitKey struct{}
+
 func WithTransaction(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
@@ -14,12 +16,30 @@ func WithTransaction(ctx context.Context, db *sql.DB, fn func(ctx context.Contex
 	}
 	defer tx.Rollback()
 
-	txCtx := context.WithValue(ctx, txKey{}, tx)
+	var hooks []func()
+	txCtx := context.WithValue(context.WithValue(ctx, txKey{}, tx), afterCommitKey{}, &hooks)
 	if err := fn(txCtx); err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		hook()
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction in ctx has committed, or right
+// away when ctx carries no transaction. fn is dropped if the transaction
+// rolls back.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*[]func()); ok {
+		*hooks = append(*hooks, fn)
+		return
+	}
+	fn()
 }
 
 func GetTx(ctx context.Context) (*sql.Tx, bool) {
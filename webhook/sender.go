@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+)
+
+// Envelope is the JSON body of a delivery. DeliveryID stays the same when a
+// delivery is retried or redelivered, so receivers can drop duplicates.
+type Envelope struct {
+	DeliveryID int              `json:"delivery_id"`
+	Event      domain.EventName `json:"event"`
+	LoanID     int              `json:"loan_id"`
+	Data       json.RawMessage  `json:"data"`
+}
+
+// StatusError reports a delivery the endpoint answered with a non-2xx status.
+type StatusError struct {
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("webhook endpoint responded %d", e.Code)
+	}
+	return fmt.Sprintf("webhook endpoint responded %d: %s", e.Code, e.Body)
+}
+
+// maxErrorBody caps how much of a failed response is kept for the delivery
+// log.
+const maxErrorBody = 512
+
+// Sender POSTs signed deliveries. Redirects are not followed: an endpoint
+// that moved has to be updated on the subscription.
+type Sender struct {
+	Client    *http.Client
+	UserAgent string
+}
+
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{
+		Client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		UserAgent: "loan-service-webhooks/1",
+	}
+}
+
+// Send delivers d to w and returns the response status, or 0 when no
+// response was received. Any status outside 2xx is a *StatusError.
+func (s *Sender) Send(ctx context.Context, w domain.Webhook, d domain.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(Envelope{DeliveryID: d.ID, Event: d.EventName, LoanID: d.LoanID, Data: d.Payload})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", s.UserAgent)
+	req.Header.Set(HeaderEvent, string(d.EventName))
+	req.Header.Set(HeaderDelivery, strconv.Itoa(d.ID))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign([]byte(w.Secret), timestamp, body))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return resp.StatusCode, &StatusError{Code: resp.StatusCode, Body: strings.TrimSpace(string(snippet))}
+	}
+	// Drain the body so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
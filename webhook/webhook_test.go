@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/martinusiron/loan-service/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret := []byte("partner-secret-0123")
+	body := []byte(`{"delivery_id":1}`)
+	now := time.Unix(1754000000, 0)
+
+	headers := func(ts int64, sig string) http.Header {
+		h := http.Header{}
+		h.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+		h.Set(HeaderSignature, sig)
+		return h
+	}
+	valid := Sign(secret, now.Unix(), body)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, valid)
+
+	assert.NoError(t, Verify(secret, headers(now.Unix(), valid), body, DefaultTolerance, now.Add(time.Minute)))
+	assert.ErrorIs(t, Verify(secret, headers(now.Unix(), valid), []byte(`{"delivery_id":2}`), DefaultTolerance, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify([]byte("another-secret-000"), headers(now.Unix(), valid), body, DefaultTolerance, now), ErrInvalidSignature)
+	// The timestamp is signed, so it cannot be moved forward to dodge the
+	// tolerance.
+	assert.ErrorIs(t, Verify(secret, headers(now.Unix()+60, valid), body, DefaultTolerance, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, headers(now.Unix(), valid), body, DefaultTolerance, now.Add(10*time.Minute)), ErrStaleTimestamp)
+	assert.ErrorIs(t, Verify(secret, http.Header{}, body, DefaultTolerance, now), ErrMissingSignature)
+}
+
+func TestSender_Send(t *testing.T) {
+	secret := "partner-secret-0123"
+	status := http.StatusNoContent
+	var (
+		got     Envelope
+		headers http.Header
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		headers = r.Header
+		if err := Verify([]byte(secret), r.Header, body, DefaultTolerance, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		require.NoError(t, json.Unmarshal(body, &got))
+		if status == http.StatusFound {
+			http.Redirect(w, r, "/elsewhere", status)
+			return
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, "busy")
+	}))
+	defer srv.Close()
+
+	s := NewSender(time.Second)
+	w := domain.Webhook{URL: srv.URL, Secret: secret}
+	d := domain.WebhookDelivery{ID: 42, EventName: domain.EventLoanApproved, LoanID: 7, Payload: []byte(`{"loan_id":7}`)}
+
+	code, err := s.Send(context.TODO(), w, d)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Equal(t, Envelope{DeliveryID: 42, Event: domain.EventLoanApproved, LoanID: 7, Data: []byte(`{"loan_id":7}`)}, got)
+	assert.Equal(t, "loan.approved", headers.Get(HeaderEvent))
+	assert.Equal(t, "42", headers.Get(HeaderDelivery))
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+
+	status = http.StatusServiceUnavailable
+	code, err = s.Send(context.TODO(), w, d)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.EqualError(t, err, "webhook endpoint responded 503: busy")
+
+	// Redirects are reported rather than followed.
+	status = http.StatusFound
+	code, err = s.Send(context.TODO(), w, d)
+	assert.Equal(t, http.StatusFound, code)
+	var statusErr *StatusError
+	assert.ErrorAs(t, err, &statusErr)
+
+	w.Secret = "a-different-secret-00"
+	code, err = s.Send(context.TODO(), w, d)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Error(t, err)
+}